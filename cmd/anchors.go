@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/diff"
+)
+
+// fitAnchor checks anchor against the pull request diff. With snap, an anchor
+// outside the diff is moved to the nearest commentable lines instead and the
+// adjustment is reported on w.
+func fitAnchor(index *diff.Index, anchor diff.Anchor, snap bool, w io.Writer) (diff.Anchor, error) {
+	if err := index.Check(anchor); err == nil || !snap {
+		return anchor, err
+	}
+
+	snapped, err := index.Snap(anchor)
+	if err != nil {
+		return anchor, err
+	}
+	if snapped.Line != anchor.Line {
+		fmt.Fprintf(w, "snapped %s line %d to %d (%s)\n", anchor.Path, anchor.Line, snapped.Line, anchor.Side)
+	}
+	if snapped.StartLine != anchor.StartLine {
+		if snapped.StartLine == 0 {
+			fmt.Fprintf(w, "dropped %s start line %d: it does not share a hunk with line %d\n", anchor.Path, anchor.StartLine, snapped.Line)
+		} else {
+			fmt.Fprintf(w, "snapped %s start line %d to %d\n", anchor.Path, anchor.StartLine, snapped.StartLine)
+		}
+	}
+	return snapped, nil
+}
+
+// warnNoopSuggestion reports on w when suggestion would leave the anchored
+// lines unchanged. Lines whose current text is not in the diff are not checked.
+func warnNoopSuggestion(index *diff.Index, anchor diff.Anchor, suggestion string, w io.Writer) {
+	start := anchor.StartLine
+	if start == 0 {
+		start = anchor.Line
+	}
+	current, ok := index.Content(anchor.Path, diff.SideRight, start, anchor.Line)
+	if !ok || strings.Join(current, "\n") != suggestion {
+		return
+	}
+	if start == anchor.Line {
+		fmt.Fprintf(w, "warning: suggestion for %s line %d matches the current code\n", anchor.Path, anchor.Line)
+		return
+	}
+	fmt.Fprintf(w, "warning: suggestion for %s lines %d-%d matches the current code\n", anchor.Path, start, anchor.Line)
+}
@@ -2,7 +2,7 @@ package cmd
 
 import (
 	"errors"
-	"os"
+	"fmt"
 
 	"github.com/spf13/cobra"
 
@@ -20,13 +20,13 @@ func newCommentsCommand() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "comments",
-		Short: "Reply to pull request review threads",
+		Short: "Post, reply to, edit, delete, react to, and hide pull request comments",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := cmd.Help(); err != nil {
 				return err
 			}
-			return errors.New("use 'gh pr-review comments reply' to respond to a review thread; run 'gh pr-review review view' to locate thread IDs")
+			return errors.New("use 'gh pr-review comments reply' to respond to a review thread or 'gh pr-review comments post' to comment on the conversation; run 'gh pr-review review view' to locate thread IDs")
 		},
 	}
 
@@ -34,6 +34,12 @@ func newCommentsCommand() *cobra.Command {
 	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 
 	cmd.AddCommand(newCommentsReplyCommand(opts))
+	cmd.AddCommand(newCommentsPostCommand(opts))
+	cmd.AddCommand(newCommentsEditCommand(opts))
+	cmd.AddCommand(newCommentsDeleteCommand(opts))
+	cmd.AddCommand(newCommentsReactCommand(opts))
+	cmd.AddCommand(newCommentsMinimizeCommand(opts))
+	cmd.AddCommand(newCommentsUnminimizeCommand(opts))
 
 	return cmd
 }
@@ -64,6 +70,8 @@ func newCommentsReplyCommand(parent *commentsOptions) *cobra.Command {
 	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "Review thread identifier to reply to")
 	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "GraphQL review identifier when replying inside a pending review")
 	cmd.Flags().StringVar(&opts.Body, "body", "", "Reply text")
+	cmd.Flags().StringVar(&opts.QuoteID, "quote", "", "Quote this comment (PRRC_..., IC_..., or PRR_...) above the reply")
+	cmd.Flags().BoolVar(&opts.Resolve, "resolve", false, "Resolve the thread after replying")
 	_ = cmd.MarkFlagRequired("thread-id")
 	_ = cmd.MarkFlagRequired("body")
 
@@ -77,6 +85,8 @@ type commentsReplyOptions struct {
 	ThreadID string
 	ReviewID string
 	Body     string
+	QuoteID  string
+	Resolve  bool
 }
 
 func runCommentsReply(cmd *cobra.Command, opts *commentsReplyOptions) error {
@@ -85,19 +95,27 @@ func runCommentsReply(cmd *cobra.Command, opts *commentsReplyOptions) error {
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
 
 	service := comments.NewService(apiClientFactory(identity.Host))
-
-	reply, err := service.Reply(identity, comments.ReplyOptions{
+	replyOpts := comments.ReplyOptions{
 		ThreadID: opts.ThreadID,
 		ReviewID: opts.ReviewID,
 		Body:     opts.Body,
-	})
+		QuoteID:  opts.QuoteID,
+	}
+	if opts.Resolve {
+		result, err := service.ReplyAndResolve(cmd.Context(), identity, replyOpts)
+		if err != nil {
+			return err
+		}
+		return encodeReplyResolution(cmd, result)
+	}
+
+	reply, err := service.Reply(cmd.Context(), identity, replyOpts)
 	if err != nil {
 		return err
 	}
@@ -106,3 +124,376 @@ func runCommentsReply(cmd *cobra.Command, opts *commentsReplyOptions) error {
 	}
 	return encodeJSON(cmd, map[string]string{"comment_node_id": reply.CommentNodeID})
 }
+
+// encodeReplyResolution prints result and fails when the reply was posted but
+// the thread was left unresolved.
+func encodeReplyResolution(cmd *cobra.Command, result comments.ReplyResolution) error {
+	if err := encodeJSON(cmd, result); err != nil {
+		return err
+	}
+	if result.ResolveError != "" {
+		return fmt.Errorf("reply %s was posted but thread %s was not resolved: %s", result.CommentNodeID, result.ThreadNodeID, result.ResolveError)
+	}
+	return nil
+}
+
+func newCommentsPostCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsPostOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "post [<number> | <url>]",
+		Short: "Post a comment on the pull request conversation",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsPost(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Comment text")
+	cmd.Flags().StringVar(&opts.QuoteID, "quote", "", "Quote this comment (IC_..., PRRC_..., or PRR_...) above the new comment")
+	_ = cmd.MarkFlagRequired("body")
+
+	return cmd
+}
+
+type commentsPostOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	Body     string
+	QuoteID  string
+}
+
+func runCommentsPost(cmd *cobra.Command, opts *commentsPostOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(apiClientFactory(identity.Host))
+	comment, err := service.Post(cmd.Context(), identity, comments.PostOptions{
+		Body:    opts.Body,
+		QuoteID: opts.QuoteID,
+	})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, comment)
+}
+
+func newCommentsEditCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsEditOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "edit [<number> | <url>]",
+		Short: "Edit a conversation or review comment",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsEdit(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.CommentID, "comment-id", "", "Comment node ID (IC_... or PRRC_...)")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "New comment text")
+	_ = cmd.MarkFlagRequired("comment-id")
+	_ = cmd.MarkFlagRequired("body")
+
+	return cmd
+}
+
+type commentsEditOptions struct {
+	Repo      string
+	Pull      int
+	Selector  string
+	CommentID string
+	Body      string
+}
+
+func runCommentsEdit(cmd *cobra.Command, opts *commentsEditOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(apiClientFactory(identity.Host))
+	comment, err := service.Edit(cmd.Context(), identity, comments.EditOptions{
+		CommentID: opts.CommentID,
+		Body:      opts.Body,
+	})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, comment)
+}
+
+func newCommentsDeleteCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsDeleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete [<number> | <url>]",
+		Short: "Delete a conversation or review comment",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsDelete(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.CommentID, "comment-id", "", "Comment node ID (IC_... or PRRC_...)")
+	_ = cmd.MarkFlagRequired("comment-id")
+
+	return cmd
+}
+
+type commentsDeleteOptions struct {
+	Repo      string
+	Pull      int
+	Selector  string
+	CommentID string
+}
+
+func runCommentsDelete(cmd *cobra.Command, opts *commentsDeleteOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(apiClientFactory(identity.Host))
+	result, err := service.Delete(cmd.Context(), identity, opts.CommentID)
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, result)
+}
+
+func newCommentsReactCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsReactOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "react [<number> | <url>]",
+		Short: "Add or remove a reaction on a conversation or review comment",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsReact(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.CommentID, "comment-id", "", "Comment node ID (IC_... or PRRC_...)")
+	cmd.Flags().StringVar(&opts.Reaction, "reaction", "", "Reaction: THUMBS_UP (+1), THUMBS_DOWN (-1), LAUGH, HOORAY, CONFUSED, HEART, ROCKET, or EYES")
+	cmd.Flags().BoolVar(&opts.Remove, "remove", false, "Remove your reaction instead of adding it")
+	_ = cmd.MarkFlagRequired("comment-id")
+	_ = cmd.MarkFlagRequired("reaction")
+
+	return cmd
+}
+
+type commentsReactOptions struct {
+	Repo      string
+	Pull      int
+	Selector  string
+	CommentID string
+	Reaction  string
+	Remove    bool
+}
+
+func runCommentsReact(cmd *cobra.Command, opts *commentsReactOptions) error {
+	if _, err := comments.ParseReaction(opts.Reaction); err != nil {
+		return err
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(apiClientFactory(identity.Host))
+	result, err := service.React(cmd.Context(), comments.ReactOptions{
+		CommentID: opts.CommentID,
+		Reaction:  opts.Reaction,
+		Remove:    opts.Remove,
+	})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, result)
+}
+
+func newCommentsMinimizeCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsMinimizeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "minimize [<number> | <url>]",
+		Short: "Hide a conversation or review comment",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsMinimize(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.CommentID, "comment-id", "", "Comment node ID (IC_... or PRRC_...)")
+	cmd.Flags().StringVar(&opts.Reason, "reason", "", "Why the comment is hidden: OUTDATED, RESOLVED, OFF_TOPIC, SPAM, DUPLICATE, or ABUSE")
+	_ = cmd.MarkFlagRequired("comment-id")
+	_ = cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+type commentsMinimizeOptions struct {
+	Repo      string
+	Pull      int
+	Selector  string
+	CommentID string
+	Reason    string
+}
+
+func runCommentsMinimize(cmd *cobra.Command, opts *commentsMinimizeOptions) error {
+	if _, err := comments.ParseMinimizeReason(opts.Reason); err != nil {
+		return err
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(apiClientFactory(identity.Host))
+	result, err := service.Minimize(cmd.Context(), comments.MinimizeOptions{CommentID: opts.CommentID, Reason: opts.Reason})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, result)
+}
+
+func newCommentsUnminimizeCommand(parent *commentsOptions) *cobra.Command {
+	opts := &commentsUnminimizeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "unminimize [<number> | <url>]",
+		Short: "Show a hidden conversation or review comment again",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			if opts.Repo == "" {
+				opts.Repo = parent.Repo
+			}
+			if opts.Pull == 0 {
+				opts.Pull = parent.Pull
+			}
+			return runCommentsUnminimize(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.CommentID, "comment-id", "", "Comment node ID (IC_... or PRRC_...)")
+	_ = cmd.MarkFlagRequired("comment-id")
+
+	return cmd
+}
+
+type commentsUnminimizeOptions struct {
+	Repo      string
+	Pull      int
+	Selector  string
+	CommentID string
+}
+
+func runCommentsUnminimize(cmd *cobra.Command, opts *commentsUnminimizeOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(apiClientFactory(identity.Host))
+	result, err := service.Unminimize(cmd.Context(), opts.CommentID)
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, result)
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -19,14 +20,14 @@ type commandFakeAPI struct {
 	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
 }
 
-func (f *commandFakeAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+func (f *commandFakeAPI) REST(_ context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
 	if f.restFunc == nil {
 		return errors.New("unexpected REST call")
 	}
 	return f.restFunc(method, path, params, body, result)
 }
 
-func (f *commandFakeAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+func (f *commandFakeAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	if f.graphqlFunc == nil {
 		return errors.New("unexpected GraphQL call")
 	}
@@ -205,6 +206,149 @@ func TestCommentsReplyCommandWithoutReviewID(t *testing.T) {
 	assert.Equal(t, "PRRC_reply", payload["comment_node_id"])
 }
 
+func TestCommentsPostAndDeleteCommands(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "query PullRequestID("):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"id": "PR_node"}}})
+		case strings.Contains(query, "mutation AddComment("):
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "PR_node", input["subjectId"])
+			assert.Equal(t, "Thanks, fixed in abc123.", input["body"])
+			return assignJSON(result, obj{"addComment": obj{"commentEdge": obj{"node": obj{"id": "IC_new"}}}})
+		case strings.Contains(query, "query CommentNode("):
+			return assignJSON(result, obj{"node": obj{
+				"__typename": "IssueComment", "id": "IC_new", "databaseId": 5, "body": "Thanks, fixed in abc123.",
+				"url": "https://github.com/octo/demo/pull/7#issuecomment-5", "createdAt": "2025-12-03T10:00:00Z",
+				"updatedAt": "2025-12-03T10:00:00Z", "author": obj{"login": "octocat"},
+			}})
+		case strings.Contains(query, "deleteIssueComment"):
+			assert.Equal(t, "IC_new", variables["input"].(map[string]interface{})["id"])
+			return nil
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "post", "--body", "Thanks, fixed in abc123.", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+
+	var comment map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &comment))
+	assert.Equal(t, "IC_new", comment["id"])
+	assert.Equal(t, "issue_comment", comment["type"])
+	assert.Equal(t, "octocat", comment["author_login"])
+
+	root = newRootCommand()
+	stdout.Reset()
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "delete", "--comment-id", "IC_new", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+	assert.JSONEq(t, `{"id":"IC_new","type":"issue_comment","deleted":true}`, stdout.String())
+}
+
+func TestCommentsReplyResolve(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			return assignJSON(result, obj{"addPullRequestReviewThreadReply": obj{
+				"comment": obj{"id": "PRRC_reply", "author": obj{"login": "octocat"}},
+			}})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRC_reply", "author": obj{"login": "octocat"}}})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread"}})
+		case strings.Contains(query, "ThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread", "viewerCanResolve": true}})
+		case strings.Contains(query, "resolveReviewThread"):
+			return assignJSON(result, obj{"resolveReviewThread": obj{"thread": obj{"id": "PRRT_thread", "isResolved": true}}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "reply", "--thread-id", "PRRT_thread", "--body", "Fixed in abc123", "--resolve", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_reply","thread_node_id":"PRRT_thread","is_resolved":true}`, stdout.String())
+}
+
+func TestCommentsReactCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "removeReaction")
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "IC_1", input["subjectId"])
+		assert.Equal(t, "THUMBS_UP", input["content"])
+		return assignJSON(result, obj{"removeReaction": obj{"subject": obj{"id": "IC_1", "reactionGroups": []obj{
+			{"content": "THUMBS_UP", "viewerHasReacted": false, "reactors": obj{"totalCount": 1}},
+		}}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "react", "--comment-id", "IC_1", "--reaction", "+1", "--remove", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+	assert.JSONEq(t, `{"comment_id":"IC_1","reaction":"THUMBS_UP","count":1,"viewer_has_reacted":false}`, stdout.String())
+
+	root = newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "react", "--comment-id", "IC_1", "--reaction", "tada", "--repo", "octo/demo", "7"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid reaction")
+}
+
+func TestCommentsMinimizeCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "mutation MinimizeComment(")
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "IC_1", input["subjectId"])
+		assert.Equal(t, "OFF_TOPIC", input["classifier"])
+		return assignJSON(result, obj{"minimizeComment": obj{"minimizedComment": obj{"isMinimized": true, "minimizedReason": "off-topic"}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"comments", "minimize", "--comment-id", "IC_1", "--reason", "off_topic", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+	assert.JSONEq(t, `{"comment_id":"IC_1","is_minimized":true,"minimized_reason":"OFF_TOPIC"}`, stdout.String())
+}
+
 func assignJSON(result interface{}, payload interface{}) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
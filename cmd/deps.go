@@ -2,6 +2,11 @@ package cmd
 
 import "github.com/agynio/gh-pr-review/internal/ghcli"
 
+var (
+	apiTransport   = ghcli.TransportGh
+	apiRetryPolicy = ghcli.DefaultRetryPolicy()
+)
+
 var apiClientFactory = func(host string) ghcli.API {
-	return &ghcli.Client{Host: host}
+	return ghcli.NewRetryingAPI(ghcli.New(host, apiTransport), apiRetryPolicy)
 }
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/diff"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+type diffOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	Paths    []string
+	Format   string
+}
+
+func newDiffCommand() *cobra.Command {
+	opts := &diffOptions{Format: "json"}
+
+	cmd := &cobra.Command{
+		Use:   "diff [<number> | <url>]",
+		Short: "Show pull request patches annotated with commentable line numbers",
+		Long: `Show the pull request diff with every line annotated with its old and new
+line numbers and the side to use with review add-comment.
+
+Added lines are commented on the RIGHT side using new_line, deleted lines on
+the LEFT side using old_line. Context lines are reported on the RIGHT side and
+may also be addressed from the LEFT using old_line.`,
+		Example: `  gh pr-review diff -R owner/repo 42 --path 'internal/*.go'
+  gh pr-review diff --format text --path '*.md'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runDiff(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringSliceVar(&opts.Paths, "path", nil, "Only include files matching the glob (repeatable)")
+	cmd.Flags().StringVar(&opts.Format, "format", opts.Format, "Output format: json or text")
+
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, opts *diffOptions) error {
+	format := strings.ToLower(strings.TrimSpace(opts.Format))
+	if format != "json" && format != "text" {
+		return fmt.Errorf("invalid --format %q: must be json or text", opts.Format)
+	}
+	for _, pattern := range opts.Paths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --path pattern %q: %w", pattern, err)
+		}
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := diff.NewService(apiClientFactory(identity.Host))
+	files, err := service.Files(cmd.Context(), identity)
+	if err != nil {
+		return err
+	}
+
+	annotated := diff.Annotate(diff.FilterPaths(files, opts.Paths))
+	if format == "text" {
+		return diff.WriteText(cmd.OutOrStdout(), annotated)
+	}
+	return encodeJSON(cmd, annotated)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+func TestDiffCommandJSON(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = pullFilesREST(t,
+		obj{"filename": "main.go", "status": "modified", "patch": "@@ -3,2 +3,2 @@\n keep\n-old\n+new"},
+		obj{"filename": "README.md", "status": "modified", "patch": "@@ -1 +1 @@\n-a\n+b"},
+	)
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"diff", "--path", "*.go", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+
+	var payload []map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	require.Len(t, payload, 1)
+	assert.Equal(t, "main.go", payload[0]["path"])
+	assert.Equal(t, true, payload[0]["commentable"])
+
+	hunks := payload[0]["hunks"].([]interface{})
+	lines := hunks[0].(map[string]interface{})["lines"].([]interface{})
+	require.Len(t, lines, 3)
+	deleted := lines[1].(map[string]interface{})
+	assert.Equal(t, "delete", deleted["type"])
+	assert.Equal(t, "LEFT", deleted["side"])
+	assert.Equal(t, float64(4), deleted["old_line"])
+	_, hasNew := deleted["new_line"]
+	assert.False(t, hasNew)
+}
+
+func TestDiffCommandText(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = pullFilesREST(t, obj{"filename": "main.go", "status": "added", "patch": "@@ -0,0 +1 @@\n+hello"})
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"diff", "--format", "text", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "=== main.go (added)\n@@ -0,0 +1 @@\n          1 RIGHT +hello\n", stdout.String())
+}
+
+func TestDiffCommandRejectsBadPattern(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"diff", "--path", "[", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --path pattern")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/mcp"
+)
+
+func newMCPCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve review operations as Model Context Protocol tools over stdio",
+		Long: `Run a Model Context Protocol server on stdin and stdout so agents can call
+review operations as typed tools instead of shelling out: review_start,
+review_add_comment, review_submit, review_edit_comment, review_delete_comment,
+review_view, review_preview, threads_list, threads_resolve, threads_unresolve,
+and comments_reply.
+
+Tool input and output schemas are derived from the same structures the
+commands use, and results match the commands' JSON output. Each tool takes
+the pull request as url, or repo and pr, and falls back to the pull request of
+the current branch. Global flags such as --transport and --retries apply to
+every tool call.`,
+		Example: `  # Register with an MCP client, e.g. in its JSON configuration:
+  #   {"command": "gh", "args": ["pr-review", "mcp"]}
+  gh pr-review mcp`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			server := mcp.NewServer(apiClientFactory, buildVersion())
+			return server.Serve(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+}
+
+// buildVersion reports the module version embedded by `go install`, or
+// "dev" for local builds.
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServesStdio(t *testing.T) {
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetIn(strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}` + "\n"))
+	root.SetArgs([]string{"mcp"})
+
+	require.NoError(t, root.Execute())
+
+	var resp struct {
+		ID     int `json:"id"`
+		Result struct {
+			ProtocolVersion string `json:"protocolVersion"`
+			ServerInfo      struct {
+				Name string `json:"name"`
+			} `json:"serverInfo"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &resp))
+	assert.Equal(t, 1, resp.ID)
+	assert.Equal(t, "2025-06-18", resp.Result.ProtocolVersion)
+	assert.Equal(t, "gh-pr-review", resp.Result.ServerInfo.Name)
+}
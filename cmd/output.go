@@ -3,6 +3,8 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -15,3 +17,30 @@ func encodeJSON(cmd *cobra.Command, payload interface{}) error {
 	}
 	return nil
 }
+
+// colorEnabled resolves a --color mode (auto, always, never). In auto mode
+// color is used only when stdout is a terminal and NO_COLOR is unset.
+func colorEnabled(cmd *cobra.Command, mode string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "", "auto":
+	default:
+		return false, fmt.Errorf("invalid --color %q: must be auto, always, or never", mode)
+	}
+
+	if _, set := os.LookupEnv("NO_COLOR"); set || os.Getenv("TERM") == "dumb" {
+		return false, nil
+	}
+	file, ok := cmd.OutOrStdout().(*os.File)
+	if !ok {
+		return false, nil
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false, nil
+	}
+	return info.Mode()&os.ModeCharDevice != 0, nil
+}
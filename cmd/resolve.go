@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// resolvePullRequest turns a normalized selector into a pull request identity.
+// An empty selector resolves to the open pull request for the current git branch.
+func resolvePullRequest(cmd *cobra.Command, selector, repo string) (resolver.Identity, error) {
+	host := os.Getenv("GH_HOST")
+	if selector == "" {
+		return resolver.ResolveCurrentBranch(cmd.Context(), repo, host, apiClientFactory)
+	}
+	return resolver.Resolve(selector, repo, host)
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/agynio/gh-pr-review/internal/review"
 )
 
 func newReviewCommand() *cobra.Command {
@@ -17,18 +20,23 @@ func newReviewCommand() *cobra.Command {
 			if err := cmd.Help(); err != nil {
 				return err
 			}
-			return errors.New("specify a subcommand: start, add-comment, edit, edit-comment, delete-comment, submit, preview, or view")
+			return errors.New("specify a subcommand: start, add-comment, apply, import, edit, edit-comment, delete-comment, submit, discard, preview, pending, latest, or view")
 		},
 	}
 
 	cmd.AddCommand(newReviewStartCommand())
 	cmd.AddCommand(newReviewAddCommentCommand())
+	cmd.AddCommand(newReviewApplyCommand())
+	cmd.AddCommand(newReviewImportCommand())
 	cmd.AddCommand(newReviewEditCommand())
 	cmd.AddCommand(newReviewEditCommentCommand())
 	cmd.AddCommand(newReviewDeleteCommentCommand())
 	cmd.AddCommand(newReviewSubmitCommand())
+	cmd.AddCommand(newReviewDiscardCommand())
 	cmd.AddCommand(newReviewPreviewCommand())
 	cmd.AddCommand(newReviewViewCommand())
+	cmd.AddCommand(newReviewPendingCommand())
+	cmd.AddCommand(newReviewLatestCommand())
 
 	return cmd
 }
@@ -75,3 +83,13 @@ func ensureGraphQLReviewID(value string) (string, error) {
 	}
 	return "", fmt.Errorf("--review-id %q is not a GraphQL review node id (expected prefix PRR_)", id)
 }
+
+// defaultPendingReviewID looks up the viewer's pending review when --review-id
+// is omitted.
+func defaultPendingReviewID(cmd *cobra.Command, service *reviewsvc.Service, identity resolver.Identity) (string, error) {
+	pending, err := service.SolePending(cmd.Context(), identity)
+	if err != nil {
+		return "", fmt.Errorf("--review-id not given: %w", err)
+	}
+	return pending.ID, nil
+}
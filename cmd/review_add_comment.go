@@ -1,28 +1,32 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/agynio/gh-pr-review/internal/diff"
 	"github.com/agynio/gh-pr-review/internal/resolver"
 	reviewsvc "github.com/agynio/gh-pr-review/internal/review"
 )
 
 type reviewAddCommentOptions struct {
-	Repo      string
-	Pull      int
-	Selector  string
-	ReviewID  string
-	Path      string
-	Line      int
-	Side      string
-	StartLine int
-	StartSide string
-	Body      string
+	Repo           string
+	Pull           int
+	Selector       string
+	ReviewID       string
+	Path           string
+	Line           int
+	Side           string
+	StartLine      int
+	StartSide      string
+	Body           string
+	Snap           bool
+	NoValidate     bool
+	Suggestion     string
+	SuggestionFile string
+	FileLevel      bool
 }
 
 func newReviewAddCommentCommand() *cobra.Command {
@@ -39,15 +43,30 @@ The --line flag takes the absolute line number in the file. For RIGHT side
 (default), use the line number in the modified file. For LEFT side, use the
 line number in the original file.
 
-The line must fall within a diff hunk range. Check the diff header:
-  @@ -oldStart,oldCount +newStart,newCount @@
-  Valid range for RIGHT: newStart to (newStart + newCount - 1)
+The line must fall within a diff hunk range, and --start-line must fall within
+the same hunk. Anchors are checked against the pull request diff before the
+comment is posted; the error lists the commentable ranges for the file. Pass
+--snap to move an out-of-range anchor to the nearest commentable line instead,
+or --no-validate to skip the check. Run "gh pr-review diff" to list every
+line with its number and side.
+
+FILE-LEVEL COMMENTS:
+
+--file-level comments on the whole file named by --path instead of a line;
+--line, --side, --start-line, --start-side, --snap, and the suggestion flags
+do not apply. The file must be part of the pull request.
+
+SUGGESTIONS:
+
+--suggestion or --suggestion-file turns the comment into a suggested change
+that replaces lines --start-line..--line (or just --line) with the given text;
+pass an empty --suggestion to suggest deleting them. Suggestions must be on
+the RIGHT side and are never snapped. A warning is printed when the
+suggestion matches the current code.
 
 Examples:
   - New file @@ -0,0 +1,173 @@:     use --line 80 for line 80
-  - Modified @@ -224,6 +224,112 @@: use --line 280 for line 280 of the new file
-
-Get diff info: gh api repos/OWNER/REPO/pulls/PR/files --jq '.[].patch'`,
+  - Modified @@ -224,6 +224,112 @@: use --line 280 for line 280 of the new file`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
@@ -59,26 +78,39 @@ Get diff info: gh api repos/OWNER/REPO/pulls/PR/files --jq '.[].patch'`,
 
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
-	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID)")
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID); defaults to your pending review")
 	cmd.Flags().StringVar(&opts.Path, "path", "", "File path for inline comment")
 	cmd.Flags().IntVar(&opts.Line, "line", 0, "Absolute line number in the file (must fall within a diff hunk range)")
 	cmd.Flags().StringVar(&opts.Side, "side", opts.Side, "Diff side for inline comment (LEFT or RIGHT)")
 	cmd.Flags().IntVar(&opts.StartLine, "start-line", 0, "Start line for multi-line comments")
 	cmd.Flags().StringVar(&opts.StartSide, "start-side", "", "Start side for multi-line comments")
 	cmd.Flags().StringVar(&opts.Body, "body", "", "Comment body")
+	cmd.Flags().BoolVar(&opts.Snap, "snap", false, "Move lines outside the diff to the nearest commentable line")
+	cmd.Flags().BoolVar(&opts.NoValidate, "no-validate", false, "Skip checking the anchor against the pull request diff")
+	cmd.Flags().StringVar(&opts.Suggestion, "suggestion", "", "Replacement text for the commented lines, posted as a suggested change")
+	cmd.Flags().StringVar(&opts.SuggestionFile, "suggestion-file", "", "Read the suggested replacement from a file (use - for stdin)")
+	cmd.Flags().BoolVar(&opts.FileLevel, "file-level", false, "Comment on the whole file instead of a line")
+	cmd.MarkFlagsMutuallyExclusive("snap", "no-validate")
+	cmd.MarkFlagsMutuallyExclusive("suggestion", "suggestion-file")
+	cmd.MarkFlagsMutuallyExclusive("snap", "suggestion")
+	cmd.MarkFlagsMutuallyExclusive("snap", "suggestion-file")
+	for _, flag := range []string{"line", "side", "start-line", "start-side", "snap", "suggestion", "suggestion-file"} {
+		cmd.MarkFlagsMutuallyExclusive("file-level", flag)
+	}
 
 	return cmd
 }
 
 func runReviewAddComment(cmd *cobra.Command, opts *reviewAddCommentOptions) error {
 	reviewID := strings.TrimSpace(opts.ReviewID)
-	if reviewID == "" {
-		return errors.New("--review-id is required")
-	}
-	if !strings.HasPrefix(reviewID, "PRR_") {
+	if reviewID != "" && !strings.HasPrefix(reviewID, "PRR_") {
 		return fmt.Errorf("invalid --review-id %q: must be a GraphQL node id (PRR_...)", opts.ReviewID)
 	}
 
+	if opts.FileLevel {
+		return runReviewAddFileComment(cmd, opts, reviewID)
+	}
+
 	side, err := normalizeSide(opts.Side)
 	if err != nil {
 		return err
@@ -96,17 +128,38 @@ func runReviewAddComment(cmd *cobra.Command, opts *reviewAddCommentOptions) erro
 		startSide = &normalized
 	}
 
+	suggestion, err := readSuggestion(cmd, opts)
+	if err != nil {
+		return err
+	}
+	if suggestion != nil {
+		startSideValue := ""
+		if startSide != nil {
+			startSideValue = *startSide
+		}
+		if err := reviewsvc.CheckSuggestionSides(side, startSideValue); err != nil {
+			return err
+		}
+	}
+
 	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
 	if err != nil {
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
 
-	service := reviewsvc.NewService(apiClientFactory(identity.Host))
+	api := apiClientFactory(identity.Host)
+	service := reviewsvc.NewService(api)
+	if reviewID == "" {
+		reviewID, err = defaultPendingReviewID(cmd, service, identity)
+		if err != nil {
+			return err
+		}
+	}
 
 	input := reviewsvc.ThreadInput{
 		ReviewID:  reviewID,
@@ -118,9 +171,102 @@ func runReviewAddComment(cmd *cobra.Command, opts *reviewAddCommentOptions) erro
 		Body:      opts.Body,
 	}
 
-	thread, err := service.AddThread(identity, input)
+	if !opts.NoValidate && input.Path != "" && input.Line > 0 {
+		index, err := diff.NewService(api).Index(cmd.Context(), identity)
+		if err != nil {
+			return err
+		}
+		anchor := diff.Anchor{Path: input.Path, Line: input.Line, Side: side, StartLine: opts.StartLine}
+		if startSide != nil {
+			anchor.StartSide = *startSide
+		}
+		anchor, err = fitAnchor(index, anchor, opts.Snap, cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+		input.Line = anchor.Line
+		input.StartLine, input.StartSide = nil, nil
+		if anchor.StartLine > 0 {
+			input.StartLine = &anchor.StartLine
+		}
+		if anchor.StartSide != "" {
+			input.StartSide = &anchor.StartSide
+		}
+		if suggestion != nil {
+			warnNoopSuggestion(index, anchor, *suggestion, cmd.ErrOrStderr())
+		}
+	}
+	if suggestion != nil {
+		input.Body = reviewsvc.SuggestionBody(input.Body, *suggestion)
+	}
+
+	thread, err := service.AddThread(cmd.Context(), identity, input)
 	if err != nil {
 		return err
 	}
 	return encodeJSON(cmd, thread)
 }
+
+// runReviewAddFileComment adds a file-level thread for --file-level.
+func runReviewAddFileComment(cmd *cobra.Command, opts *reviewAddCommentOptions, reviewID string) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	api := apiClientFactory(identity.Host)
+	service := reviewsvc.NewService(api)
+	if reviewID == "" {
+		reviewID, err = defaultPendingReviewID(cmd, service, identity)
+		if err != nil {
+			return err
+		}
+	}
+
+	input := reviewsvc.ThreadInput{
+		ReviewID:  reviewID,
+		Path:      strings.TrimSpace(opts.Path),
+		Body:      opts.Body,
+		FileLevel: true,
+	}
+	if !opts.NoValidate && input.Path != "" {
+		index, err := diff.NewService(api).Index(cmd.Context(), identity)
+		if err != nil {
+			return err
+		}
+		if err := index.CheckFile(input.Path); err != nil {
+			return err
+		}
+	}
+
+	thread, err := service.AddThread(cmd.Context(), identity, input)
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, thread)
+}
+
+// readSuggestion returns the suggested replacement from --suggestion or
+// --suggestion-file, or nil when neither is given.
+func readSuggestion(cmd *cobra.Command, opts *reviewAddCommentOptions) (*string, error) {
+	var suggestion string
+	switch {
+	case cmd.Flags().Changed("suggestion"):
+		suggestion = opts.Suggestion
+	case strings.TrimSpace(opts.SuggestionFile) != "":
+		data, err := readFileArg(cmd, opts.SuggestionFile, "suggestion")
+		if err != nil {
+			return nil, err
+		}
+		suggestion = string(data)
+	default:
+		return nil, nil
+	}
+	suggestion = reviewsvc.TrimSuggestion(suggestion)
+	return &suggestion, nil
+}
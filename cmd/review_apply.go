@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/diff"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/agynio/gh-pr-review/internal/review"
+)
+
+type reviewApplyOptions struct {
+	Repo       string
+	Pull       int
+	Selector   string
+	File       string
+	Event      string
+	Rollback   bool
+	Snap       bool
+	NoValidate bool
+}
+
+func newReviewApplyCommand() *cobra.Command {
+	opts := &reviewApplyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply [<number> | <url>]",
+		Short: "Create a review with all of its comments from a manifest",
+		Long: `Create a pending review, add every inline comment, and optionally submit it
+from a single JSON or YAML manifest.
+
+MANIFEST:
+
+  body: Overall review body
+  event: COMMENT            # optional; omit to leave the review pending
+  commit: <sha>             # optional; defaults to the pull request head
+  comments:
+    - path: internal/foo.go
+      line: 42
+      side: RIGHT           # optional, LEFT or RIGHT (default RIGHT)
+      start_line: 40        # optional, for multi-line comments
+      start_side: RIGHT     # optional
+      body: Consider handling the error here.
+      suggestion: |         # optional suggested replacement for the lines
+        if err != nil {
+          return err
+        }
+
+All comments are validated before anything is created, including their
+anchors against the pull request diff (use --snap to move them to the nearest
+commentable lines, or --no-validate to skip the diff check). Results are
+reported per comment. If any comment fails the review is not submitted; with
+--rollback the pending review is deleted instead.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewApply(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVarP(&opts.File, "file", "f", "", "Path to the JSON or YAML manifest (use - for stdin)")
+	cmd.Flags().StringVar(&opts.Event, "event", "", "Submit with this event, overriding the manifest (APPROVE, COMMENT, REQUEST_CHANGES)")
+	cmd.Flags().BoolVar(&opts.Rollback, "rollback", false, "Delete the pending review if any comment or the submission fails")
+	cmd.Flags().BoolVar(&opts.Snap, "snap", false, "Move comment lines outside the diff to the nearest commentable line")
+	cmd.Flags().BoolVar(&opts.NoValidate, "no-validate", false, "Skip checking comment anchors against the pull request diff")
+	cmd.MarkFlagsMutuallyExclusive("snap", "no-validate")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runReviewApply(cmd *cobra.Command, opts *reviewApplyOptions) error {
+	data, err := readFileArg(cmd, opts.File, "manifest")
+	if err != nil {
+		return err
+	}
+	manifest, err := reviewsvc.ParseManifest(data)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(opts.Event) != "" {
+		event, err := normalizeEvent(opts.Event)
+		if err != nil {
+			return err
+		}
+		manifest.Event = event
+	}
+	if err := manifest.Normalize(); err != nil {
+		return err
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	api := apiClientFactory(identity.Host)
+	if !opts.NoValidate {
+		index, err := diff.NewService(api).Index(cmd.Context(), identity)
+		if err != nil {
+			return err
+		}
+		if err := fitManifestAnchors(index, manifest, opts.Snap, cmd.ErrOrStderr()); err != nil {
+			return err
+		}
+	}
+
+	service := reviewsvc.NewService(api)
+	result, err := service.Apply(cmd.Context(), identity, manifest, reviewsvc.ApplyOptions{Rollback: opts.Rollback})
+	if result != nil {
+		if encodeErr := encodeJSON(cmd, result); encodeErr != nil {
+			return encodeErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if result.Failed() {
+		return errors.New("review apply failed")
+	}
+	return nil
+}
+
+// fitManifestAnchors checks every comment against the diff, reporting all
+// out-of-range anchors together.
+func fitManifestAnchors(index *diff.Index, manifest *reviewsvc.Manifest, snap bool, w io.Writer) error {
+	var problems []string
+	for i := range manifest.Comments {
+		comment := &manifest.Comments[i]
+		if comment.FileLevel {
+			if err := index.CheckFile(comment.Path); err != nil {
+				problems = append(problems, fmt.Sprintf("comments[%d]: %s", i, err))
+			}
+			continue
+		}
+		// Moving a suggestion would apply it to different lines, so those
+		// anchors are only checked.
+		anchor, err := fitAnchor(index, diff.Anchor{
+			Path:      comment.Path,
+			Line:      comment.Line,
+			Side:      comment.Side,
+			StartLine: comment.StartLine,
+			StartSide: comment.StartSide,
+		}, snap && comment.Suggestion == nil, w)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("comments[%d]: %s", i, err))
+			continue
+		}
+		comment.Line, comment.StartLine, comment.StartSide = anchor.Line, anchor.StartLine, anchor.StartSide
+		if comment.Suggestion != nil {
+			warnNoopSuggestion(index, anchor, reviewsvc.TrimSuggestion(*comment.Suggestion), w)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid manifest:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// readFileArg reads the --file argument, with "-" meaning stdin. label names
+// the content in error messages.
+func readFileArg(cmd *cobra.Command, path, label string) ([]byte, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("--file is required")
+	}
+	if path == "-" {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("read %s from stdin: %w", label, err)
+		}
+		return data, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", label, err)
+	}
+	return data, nil
+}
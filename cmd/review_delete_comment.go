@@ -3,7 +3,6 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -55,7 +54,7 @@ func runReviewDeleteComment(cmd *cobra.Command, opts *reviewDeleteCommentOptions
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
@@ -65,7 +64,7 @@ func runReviewDeleteComment(cmd *cobra.Command, opts *reviewDeleteCommentOptions
 	input := reviewsvc.DeleteCommentInput{
 		CommentID: commentID,
 	}
-	if err := service.DeleteComment(identity, input); err != nil {
+	if err := service.DeleteComment(cmd.Context(), identity, input); err != nil {
 		return err
 	}
 	return encodeJSON(cmd, map[string]string{"status": "Comment deleted successfully"})
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/agynio/gh-pr-review/internal/review"
+)
+
+type reviewDiscardOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	ReviewID string
+	Force    bool
+}
+
+func newReviewDiscardCommand() *cobra.Command {
+	opts := &reviewDiscardOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "discard [<number> | <url>]",
+		Short: "Delete a pending review and its comments",
+		Long: `Delete a pending review together with all of its draft comments.
+
+Without --review-id the authenticated viewer's pending review on the pull
+request is discarded. Reviews that still contain comments are only deleted
+when --force is given.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewDiscard(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID); defaults to your pending review")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Discard the review even if it still contains comments")
+
+	return cmd
+}
+
+func runReviewDiscard(cmd *cobra.Command, opts *reviewDiscardOptions) error {
+	var reviewID string
+	if strings.TrimSpace(opts.ReviewID) != "" {
+		id, err := ensureGraphQLReviewID(opts.ReviewID)
+		if err != nil {
+			return err
+		}
+		reviewID = id
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := reviewsvc.NewService(apiClientFactory(identity.Host))
+	result, err := service.Discard(cmd.Context(), identity, reviewsvc.DiscardInput{ReviewID: reviewID, Force: opts.Force})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, result)
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"errors"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -58,7 +57,7 @@ func runReviewEdit(cmd *cobra.Command, opts *reviewEditOptions) error {
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
@@ -69,7 +68,7 @@ func runReviewEdit(cmd *cobra.Command, opts *reviewEditOptions) error {
 		ReviewID: reviewID,
 		Body:     trimmedBody,
 	}
-	if err := service.UpdateReview(identity, input); err != nil {
+	if err := service.UpdateReview(cmd.Context(), identity, input); err != nil {
 		return err
 	}
 	return encodeJSON(cmd, map[string]string{"status": "Review updated successfully"})
@@ -3,7 +3,6 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -62,7 +61,7 @@ func runReviewEditComment(cmd *cobra.Command, opts *reviewEditCommentOptions) er
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
@@ -73,7 +72,7 @@ func runReviewEditComment(cmd *cobra.Command, opts *reviewEditCommentOptions) er
 		CommentID: commentID,
 		Body:      trimmedBody,
 	}
-	if err := service.UpdateComment(identity, input); err != nil {
+	if err := service.UpdateComment(cmd.Context(), identity, input); err != nil {
 		return err
 	}
 	return encodeJSON(cmd, map[string]string{"status": "Comment updated successfully"})
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/findings"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+type reviewImportOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	File     string
+	Format   string
+	Root     string
+	ReviewID string
+	Submit   bool
+	Body     string
+	EventMap map[string]string
+}
+
+func newReviewImportCommand() *cobra.Command {
+	opts := &reviewImportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import [<number> | <url>]",
+		Short: "Post linter findings as inline review comments",
+		Long: `Post findings from a SARIF 2.1, checkstyle XML, or reviewdog rdjsonl report
+as inline comments on a pending review.
+
+Findings on lines outside the pull request diff are skipped, as are findings
+that already exist as a review thread with the same path, line, and body, so
+re-running the import is safe. Comments go to --review-id, your existing
+pending review, or a newly opened one.
+
+With --submit the review is submitted using the strongest event mapped from
+the severities of the created comments. The default mapping is
+error=REQUEST_CHANGES, warning=COMMENT, note=COMMENT; override entries with
+--event-map, for example --event-map error=COMMENT.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewImport(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVarP(&opts.File, "file", "f", "", "Path to the report (use - for stdin)")
+	cmd.Flags().StringVar(&opts.Format, "format", "", "Report format: sarif, checkstyle, or rdjsonl (detected when omitted)")
+	cmd.Flags().StringVar(&opts.Root, "root", "", "Directory that absolute report paths are relative to (default: current directory)")
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID); defaults to your pending review")
+	cmd.Flags().BoolVar(&opts.Submit, "submit", false, "Submit the review after adding the comments")
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Review body used with --submit")
+	cmd.Flags().StringToStringVar(&opts.EventMap, "event-map", nil, "Severity to review event mapping, e.g. error=REQUEST_CHANGES,warning=COMMENT")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runReviewImport(cmd *cobra.Command, opts *reviewImportOptions) error {
+	var reviewID string
+	if strings.TrimSpace(opts.ReviewID) != "" {
+		id, err := ensureGraphQLReviewID(opts.ReviewID)
+		if err != nil {
+			return err
+		}
+		reviewID = id
+	}
+	events, err := findings.ParseEvents(opts.EventMap)
+	if err != nil {
+		return err
+	}
+
+	data, err := readFileArg(cmd, opts.File, "report")
+	if err != nil {
+		return err
+	}
+	format := findings.DetectFormat(opts.File, data)
+	if strings.TrimSpace(opts.Format) != "" {
+		if format, err = findings.ParseFormat(opts.Format); err != nil {
+			return err
+		}
+	}
+	parsed, err := findings.Parse(format, data)
+	if err != nil {
+		return err
+	}
+	root := opts.Root
+	if root == "" {
+		if root, err = os.Getwd(); err != nil {
+			return fmt.Errorf("determine working directory: %w", err)
+		}
+	}
+	findings.Relativize(parsed, root)
+	findings.Sort(parsed)
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := findings.NewService(apiClientFactory(identity.Host))
+	result, err := service.Import(cmd.Context(), identity, parsed, findings.ImportOptions{
+		ReviewID: reviewID,
+		Submit:   opts.Submit,
+		Body:     opts.Body,
+		Events:   events,
+	})
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(cmd, result); err != nil {
+		return err
+	}
+	if result.Failed() {
+		return errors.New("review import failed")
+	}
+	return nil
+}
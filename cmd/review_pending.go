@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	reviewsvc "github.com/agynio/gh-pr-review/internal/review"
+)
+
+type reviewLookupOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	Reviewer string
+	PerPage  int
+}
+
+func addReviewLookupFlags(cmd *cobra.Command, opts *reviewLookupOptions) {
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.Reviewer, "reviewer", "", "Reviewer login (defaults to the authenticated user)")
+	cmd.Flags().IntVar(&opts.PerPage, "per-page", 100, "Reviews fetched per request (max 100)")
+}
+
+func newReviewPendingCommand() *cobra.Command {
+	opts := &reviewLookupOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "pending [<number> | <url>]",
+		Short: "List pending reviews for a reviewer",
+		Long: `List the pending (unsubmitted) reviews a reviewer has on the pull request,
+oldest first. Use the returned id as --review-id for add-comment, submit, or
+discard.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewPending(cmd, opts)
+		},
+	}
+	addReviewLookupFlags(cmd, opts)
+
+	return cmd
+}
+
+func runReviewPending(cmd *cobra.Command, opts *reviewLookupOptions) error {
+	identity, err := resolveLookupPullRequest(cmd, opts)
+	if err != nil {
+		return err
+	}
+
+	service := reviewsvc.NewService(apiClientFactory(identity.Host))
+	summaries, reviewer, err := service.PendingSummaries(cmd.Context(), identity, reviewsvc.PendingOptions{
+		Reviewer: opts.Reviewer,
+		PerPage:  opts.PerPage,
+	})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, map[string]interface{}{
+		"reviewer": reviewer,
+		"reviews":  summaries,
+	})
+}
+
+func newReviewLatestCommand() *cobra.Command {
+	opts := &reviewLookupOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "latest [<number> | <url>]",
+		Short: "Show a reviewer's most recent submitted review",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runReviewLatest(cmd, opts)
+		},
+	}
+	addReviewLookupFlags(cmd, opts)
+
+	return cmd
+}
+
+func runReviewLatest(cmd *cobra.Command, opts *reviewLookupOptions) error {
+	identity, err := resolveLookupPullRequest(cmd, opts)
+	if err != nil {
+		return err
+	}
+
+	service := reviewsvc.NewService(apiClientFactory(identity.Host))
+	summary, err := service.LatestSubmitted(cmd.Context(), identity, reviewsvc.LatestOptions{
+		Reviewer: opts.Reviewer,
+		PerPage:  opts.PerPage,
+	})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, summary)
+}
+
+func resolveLookupPullRequest(cmd *cobra.Command, opts *reviewLookupOptions) (resolver.Identity, error) {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return resolver.Identity{}, err
+	}
+	return resolvePullRequest(cmd, selector, opts.Repo)
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -15,6 +14,7 @@ type reviewPreviewOptions struct {
 	Repo     string
 	Pull     int
 	Selector string
+	ReviewID string
 	ThreadID string
 }
 
@@ -35,6 +35,7 @@ func newReviewPreviewCommand() *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID); defaults to your pending review")
 	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "Filter by review thread GraphQL node ID (PRRT_...)")
 
 	return cmd
@@ -46,18 +47,27 @@ func runReviewPreview(cmd *cobra.Command, opts *reviewPreviewOptions) error {
 		return fmt.Errorf("invalid thread id %q: must be a GraphQL node id (PRRT_...)", threadID)
 	}
 
+	var reviewID string
+	if strings.TrimSpace(opts.ReviewID) != "" {
+		id, err := ensureGraphQLReviewID(opts.ReviewID)
+		if err != nil {
+			return err
+		}
+		reviewID = id
+	}
+
 	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
 	if err != nil {
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
 
 	service := preview.NewService(apiClientFactory(identity.Host))
-	result, err := service.Preview(identity, threadID)
+	result, err := service.Preview(cmd.Context(), identity, preview.PreviewOptions{ReviewID: reviewID, ThreadID: threadID})
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -45,13 +44,13 @@ func runReviewStart(cmd *cobra.Command, opts *reviewStartOptions) error {
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
 
 	service := reviewsvc.NewService(apiClientFactory(identity.Host))
-	state, err := service.Start(identity, strings.TrimSpace(opts.Commit))
+	state, err := service.Start(cmd.Context(), identity, strings.TrimSpace(opts.Commit))
 	if err != nil {
 		return err
 	}
@@ -2,7 +2,7 @@ package cmd
 
 import (
 	"errors"
-	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -36,7 +36,7 @@ func newReviewSubmitCommand() *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
-	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID)")
+	cmd.Flags().StringVar(&opts.ReviewID, "review-id", "", "Review identifier (GraphQL review node ID); defaults to your pending review")
 	cmd.Flags().StringVar(&opts.Event, "event", opts.Event, "Review submission event (APPROVE, COMMENT, REQUEST_CHANGES)")
 	cmd.Flags().StringVar(&opts.Body, "body", "", "Review body")
 
@@ -48,9 +48,12 @@ func runReviewSubmit(cmd *cobra.Command, opts *reviewSubmitOptions) error {
 	if err != nil {
 		return err
 	}
-	reviewID, err := ensureGraphQLReviewID(opts.ReviewID)
-	if err != nil {
-		return err
+	var reviewID string
+	if strings.TrimSpace(opts.ReviewID) != "" {
+		reviewID, err = ensureGraphQLReviewID(opts.ReviewID)
+		if err != nil {
+			return err
+		}
 	}
 
 	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
@@ -58,19 +61,25 @@ func runReviewSubmit(cmd *cobra.Command, opts *reviewSubmitOptions) error {
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
 
 	service := reviewsvc.NewService(apiClientFactory(identity.Host))
+	if reviewID == "" {
+		reviewID, err = defaultPendingReviewID(cmd, service, identity)
+		if err != nil {
+			return err
+		}
+	}
 
 	input := reviewsvc.SubmitInput{
 		ReviewID: reviewID,
 		Event:    event,
 		Body:     opts.Body,
 	}
-	status, err := service.Submit(identity, input)
+	status, err := service.Submit(cmd.Context(), identity, input)
 	if err != nil {
 		return err
 	}
@@ -100,6 +100,7 @@ func TestReviewAddCommentCommand_GraphQLOnly(t *testing.T) {
 		}
 		return assignJSON(result, payload)
 	}
+	fake.restFunc = pullFilesREST(t, obj{"filename": "scenario.md", "patch": "@@ -10,3 +10,4 @@\n a\n b\n+c\n d"})
 	apiClientFactory = func(host string) ghcli.API { return fake }
 
 	root := newRootCommand()
@@ -885,3 +886,434 @@ func TestReviewPreviewCommand_ThreadIDNotFound(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "thread PRRT_nonexistent not found in pending review")
 }
+
+func TestReviewApplyCommandFromStdin(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var threads []string
+	submitted := ""
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "headRefOid"):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"id": "PR_node", "headRefOid": "abc123"}}})
+		case strings.Contains(query, "addPullRequestReviewThread"):
+			input := variables["input"].(map[string]interface{})
+			threads = append(threads, input["path"].(string))
+			return assignJSON(result, obj{"addPullRequestReviewThread": obj{"thread": obj{"id": "PRRT_1", "path": input["path"]}}})
+		case strings.Contains(query, "addPullRequestReview("):
+			return assignJSON(result, obj{"addPullRequestReview": obj{"pullRequestReview": obj{"id": "PRR_review", "state": "PENDING"}}})
+		case strings.Contains(query, "submitPullRequestReview"):
+			submitted = variables["input"].(map[string]interface{})["event"].(string)
+			return nil
+		default:
+			return errors.New("unexpected graphql invocation")
+		}
+	}
+	fake.restFunc = pullFilesREST(t, obj{"filename": "a.go", "patch": "@@ -1,2 +1,3 @@\n x\n+y\n z"})
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetIn(strings.NewReader("body: done\ncomments:\n  - path: a.go\n    line: 3\n    body: nit\n"))
+	root.SetArgs([]string{"review", "apply", "--file", "-", "--event", "request_changes", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, []string{"a.go"}, threads)
+	assert.Equal(t, "REQUEST_CHANGES", submitted)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, "PRR_review", payload["review_id"])
+	assert.Equal(t, "CHANGES_REQUESTED", payload["state"])
+	assert.Equal(t, true, payload["submitted"])
+	comments := payload["comments"].([]interface{})
+	require.Len(t, comments, 1)
+	assert.Equal(t, "created", comments[0].(map[string]interface{})["status"])
+}
+
+func TestReviewApplyCommandReportsAnchorsOutsideDiff(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	fake := &commandFakeAPI{}
+	fake.restFunc = pullFilesREST(t, obj{"filename": "a.go", "patch": "@@ -1,2 +1,3 @@\n x\n+y\n z"})
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetIn(strings.NewReader("comments:\n  - {path: a.go, line: 9, body: x}\n  - {path: b.go, line: 1, body: y}\n"))
+	root.SetArgs([]string{"review", "apply", "--file", "-", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "comments[0]: line 9 (RIGHT) of a.go is not part of the diff; commentable RIGHT ranges: 1-3")
+	assert.Contains(t, err.Error(), "comments[1]: b.go is not changed in this pull request")
+}
+
+func TestReviewApplyCommandRejectsInvalidManifest(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	apiClientFactory = func(host string) ghcli.API { return &commandFakeAPI{} }
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetIn(strings.NewReader(`{"comments":[{"path":"a.go","line":0,"body":"x"}]}`))
+	root.SetArgs([]string{"review", "apply", "--file", "-", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "comments[0]: line must be positive")
+}
+
+// pullFilesREST serves a single page of the pull request files listing.
+func pullFilesREST(t *testing.T, files ...obj) func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+	t.Helper()
+	return func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		require.Equal(t, "GET", method)
+		require.Equal(t, "repos/octo/demo/pulls/7/files", path)
+		if files == nil {
+			files = []obj{}
+		}
+		return assignJSON(result, files)
+	}
+}
+
+func TestReviewAddCommentCommandRejectsLineOutsideDiff(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = pullFilesREST(t, obj{"filename": "scenario.md", "patch": "@@ -10,3 +10,4 @@\n a\n b\n+c\n d\n@@ -40,2 +41,2 @@\n x\n y"})
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "add-comment", "--review-id", "PRR_review", "--path", "scenario.md", "--line", "30", "--body", "note", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "line 30 (RIGHT) of scenario.md is not part of the diff; commentable RIGHT ranges: 10-13, 41-42", err.Error())
+}
+
+func TestReviewAddCommentCommandSnapsToNearestLine(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = pullFilesREST(t, obj{"filename": "scenario.md", "patch": "@@ -10,3 +10,4 @@\n a\n b\n+c\n d\n@@ -40,2 +41,2 @@\n x\n y"})
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, 41, input["line"])
+		assert.Equal(t, 40, input["startLine"])
+		assert.Equal(t, "LEFT", input["startSide"])
+		return assignJSON(result, obj{"addPullRequestReviewThread": obj{"thread": obj{"id": "PRRT_1", "path": "scenario.md", "line": 41}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stderr := &bytes.Buffer{}
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(stderr)
+	root.SetArgs([]string{"review", "add-comment", "--review-id", "PRR_review", "--path", "scenario.md", "--line", "35",
+		"--start-line", "12", "--start-side", "LEFT", "--body", "note", "--snap", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.Contains(t, stderr.String(), "snapped scenario.md line 35 to 41 (RIGHT)")
+	assert.Contains(t, stderr.String(), "snapped scenario.md start line 12 to 40")
+}
+
+func TestReviewAddCommentCommandFileLevel(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = pullFilesREST(t, obj{"filename": "big.go", "patch": "@@ -1,1 +1,2 @@\n a\n+b"})
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "FILE", input["subjectType"])
+		assert.NotContains(t, input, "line")
+		return assignJSON(result, obj{"addPullRequestReviewThread": obj{"thread": obj{"id": "PRRT_1", "path": "big.go", "subjectType": "FILE"}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "add-comment", "--review-id", "PRR_review", "--file-level", "--path", "big.go", "--body", "split this file", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+	assert.JSONEq(t, `{"id":"PRRT_1","path":"big.go","subject_type":"FILE","is_outdated":false}`, stdout.String())
+
+	root = newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "add-comment", "--review-id", "PRR_review", "--file-level", "--path", "other.go", "--body", "x", "--repo", "octo/demo", "7"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "other.go is not changed in this pull request", err.Error())
+}
+
+func TestReviewDiscardCommandRequiresForce(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	deleted := false
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ReviewDetails"):
+			require.Equal(t, "PRR_kwM123", variables["id"])
+			return assignJSON(result, obj{"node": obj{"id": "PRR_kwM123", "state": "PENDING", "comments": obj{"totalCount": 2}}})
+		case strings.Contains(query, "deletePullRequestReview"):
+			deleted = true
+			return assignJSON(result, obj{"deletePullRequestReview": obj{"pullRequestReview": obj{"id": "PRR_kwM123"}}})
+		default:
+			return errors.New("unexpected GraphQL call")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "discard", "--review-id", "PRR_kwM123", "--repo", "octo/demo", "7"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pass --force")
+	assert.False(t, deleted)
+
+	root = newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "discard", "--review-id", "PRR_kwM123", "--force", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+	assert.True(t, deleted)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, obj{"id": "PRR_kwM123", "state": "DELETED", "comment_count": float64(2)}, obj(payload))
+}
+
+func pendingReviewsPayload(nodes ...obj) obj {
+	return obj{"repository": obj{"pullRequest": obj{"reviews": obj{
+		"nodes":    nodes,
+		"pageInfo": obj{"hasNextPage": false},
+	}}}}
+}
+
+func TestReviewPendingCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "PendingReviews")
+		require.EqualValues(t, 7, variables["number"])
+		return assignJSON(result, pendingReviewsPayload(
+			obj{"id": "PRR_kwM1", "databaseId": 11, "state": "PENDING", "updatedAt": "2024-06-01T00:00:00Z", "author": obj{"login": "octocat", "databaseId": 5}},
+			obj{"id": "PRR_kwM2", "databaseId": 12, "state": "PENDING", "updatedAt": "2024-06-01T00:00:00Z", "author": obj{"login": "hubot"}},
+		))
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "pending", "--reviewer", "octocat", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+
+	var payload struct {
+		Reviewer string `json:"reviewer"`
+		Reviews  []obj  `json:"reviews"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, "octocat", payload.Reviewer)
+	require.Len(t, payload.Reviews, 1)
+	assert.Equal(t, "PRR_kwM1", payload.Reviews[0]["id"])
+	assert.Equal(t, float64(11), payload.Reviews[0]["database_id"])
+}
+
+func TestReviewLatestCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		require.Equal(t, "repos/octo/demo/pulls/7/reviews", path)
+		return assignJSON(result, []obj{
+			{"id": 1, "node_id": "PRR_old", "state": "COMMENTED", "submitted_at": "2024-06-01T00:00:00Z", "user": obj{"login": "octocat"}},
+			{"id": 2, "node_id": "PRR_new", "state": "APPROVED", "submitted_at": "2024-06-02T00:00:00Z", "user": obj{"login": "octocat"}},
+		})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "latest", "--reviewer", "octocat", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+
+	var payload obj
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, float64(2), payload["id"])
+	assert.Equal(t, "PRR_new", payload["node_id"])
+	assert.Equal(t, "APPROVED", payload["state"])
+}
+
+func TestReviewSubmitCommandDefaultsToPendingReview(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ViewerLogin"):
+			return assignJSON(result, obj{"viewer": obj{"login": "casey"}})
+		case strings.Contains(query, "PendingReviews"):
+			return assignJSON(result, pendingReviewsPayload(
+				obj{"id": "PRR_kwMine", "databaseId": 3, "state": "PENDING", "updatedAt": "2024-06-01T00:00:00Z", "author": obj{"login": "casey"}},
+			))
+		case strings.Contains(query, "submitPullRequestReview"):
+			payload, ok := variables["input"].(map[string]interface{})
+			require.True(t, ok)
+			require.Equal(t, "PRR_kwMine", payload["pullRequestReviewId"])
+			return assignJSON(result, obj{"submitPullRequestReview": obj{"pullRequestReview": obj{"id": "PRR_kwMine"}}})
+		default:
+			return errors.New("unexpected GraphQL call")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "submit", "--event", "COMMENT", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+	assert.Contains(t, stdout.String(), "Review submitted successfully")
+}
+
+func TestReviewAddCommentCommandWithoutPendingReview(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "ViewerLogin") {
+			return assignJSON(result, obj{"viewer": obj{"login": "casey"}})
+		}
+		require.Contains(t, query, "PendingReviews")
+		return assignJSON(result, pendingReviewsPayload())
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "add-comment", "--path", "scenario.md", "--line", "12", "--body", "note", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "--review-id not given: no pending reviews for casey", err.Error())
+}
+
+func TestReviewImportCommandFromStdin(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var added []map[string]interface{}
+	fake := &commandFakeAPI{restFunc: pullFilesREST(t, obj{"filename": "main.go", "status": "modified", "patch": "@@ -1,2 +1,3 @@\n a\n+b\n c"})}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "query Report("):
+			return assignJSON(result, obj{"repository": obj{"pullRequest": obj{"reviews": obj{"nodes": []obj{}}, "reviewThreads": obj{"nodes": []obj{}}}}})
+		case strings.Contains(query, "addPullRequestReviewThread"):
+			input := variables["input"].(map[string]interface{})
+			added = append(added, input)
+			return assignJSON(result, obj{"addPullRequestReviewThread": obj{"thread": obj{"id": "PRRT_1", "path": input["path"], "line": input["line"]}}})
+		default:
+			return errors.New("unexpected GraphQL call")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	report := `{"message":"unchecked error","location":{"path":"main.go","range":{"start":{"line":2}}},"severity":"ERROR","source":{"name":"golangci-lint"},"code":{"value":"errcheck"}}
+{"message":"unchanged line","location":{"path":"main.go","range":{"start":{"line":9}}},"severity":"WARNING"}
+`
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetIn(strings.NewReader(report))
+	root.SetArgs([]string{"review", "import", "--file", "-", "--review-id", "PRR_kwM1", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+
+	require.Len(t, added, 1)
+	assert.Equal(t, "PRR_kwM1", added[0]["pullRequestReviewId"])
+	assert.Equal(t, "**error** `errcheck` (golangci-lint)\n\nunchecked error", added[0]["body"])
+
+	var payload struct {
+		ReviewID string `json:"review_id"`
+		Event    string `json:"event"`
+		Comments []obj  `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	assert.Equal(t, "PRR_kwM1", payload.ReviewID)
+	assert.Equal(t, "REQUEST_CHANGES", payload.Event)
+	require.Len(t, payload.Comments, 2)
+	assert.Equal(t, "created", payload.Comments[0]["status"])
+	assert.Equal(t, "outside_diff", payload.Comments[1]["status"])
+}
+
+func TestReviewAddCommentCommandSuggestion(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	var body interface{}
+	fake := &commandFakeAPI{restFunc: pullFilesREST(t, obj{"filename": "scenario.md", "patch": "@@ -10,3 +10,4 @@\n a\n b\n+c\n d"})}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		body = input["body"]
+		require.Equal(t, 11, input["startLine"])
+		return assignJSON(result, obj{"addPullRequestReviewThread": obj{"thread": obj{"id": "PRRT_1", "path": "scenario.md", "line": 12}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stderr := &bytes.Buffer{}
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(stderr)
+	root.SetIn(strings.NewReader("b\nc\n"))
+	root.SetArgs([]string{"review", "add-comment", "--review-id", "PRR_review", "--path", "scenario.md", "--start-line", "11", "--line", "12", "--body", "Keep as is?", "--suggestion-file", "-", "--repo", "octo/demo", "7"})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, "Keep as is?\n\n```suggestion\nb\nc\n```", body)
+	assert.Equal(t, "warning: suggestion for scenario.md lines 11-12 matches the current code\n", stderr.String())
+}
+
+func TestReviewAddCommentCommandSuggestionRequiresRightSide(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"review", "add-comment", "--review-id", "PRR_review", "--path", "scenario.md", "--line", "11", "--side", "LEFT", "--suggestion", "x", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RIGHT side")
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -13,7 +12,7 @@ import (
 )
 
 func newReviewViewCommand() *cobra.Command {
-	opts := &reviewViewOptions{}
+	opts := &reviewViewOptions{Format: "json", Color: "auto"}
 
 	cmd := &cobra.Command{
 		Use:   "view [<number> | <url>]",
@@ -35,6 +34,10 @@ func newReviewViewCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&opts.NotOutdated, "not_outdated", false, "Exclude outdated threads")
 	cmd.Flags().IntVar(&opts.TailReplies, "tail", 0, "Limit to the last N replies per thread (0 = all)")
 	cmd.Flags().BoolVar(&opts.IncludeCommentNodeID, "include-comment-node-id", false, "Include comment_node_id fields for parent comments and replies")
+	cmd.Flags().BoolVar(&opts.IncludeReactions, "include-reactions", false, "Include reaction counts for parent comments and replies")
+	cmd.Flags().BoolVar(&opts.HideMinimized, "hide-minimized", false, "Drop minimized replies, and threads whose first comment is minimized")
+	cmd.Flags().StringVar(&opts.Format, "format", opts.Format, "Output format: json, markdown, or text")
+	cmd.Flags().StringVar(&opts.Color, "color", opts.Color, "Colorize text output: auto, always, or never")
 
 	return cmd
 }
@@ -49,12 +52,26 @@ type reviewViewOptions struct {
 	NotOutdated          bool
 	TailReplies          int
 	IncludeCommentNodeID bool
+	IncludeReactions     bool
+	HideMinimized        bool
+	Format               string
+	Color                string
 }
 
 func runReviewView(cmd *cobra.Command, opts *reviewViewOptions) error {
 	if opts.TailReplies < 0 {
 		return fmt.Errorf("invalid --tail value %d: must be non-negative", opts.TailReplies)
 	}
+	format := strings.ToLower(strings.TrimSpace(opts.Format))
+	switch format {
+	case "json", "markdown", "md", "text":
+	default:
+		return fmt.Errorf("invalid --format %q: must be json, markdown, or text", opts.Format)
+	}
+	color, err := colorEnabled(cmd, opts.Color)
+	if err != nil {
+		return err
+	}
 
 	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
 	if err != nil {
@@ -66,13 +83,13 @@ func runReviewView(cmd *cobra.Command, opts *reviewViewOptions) error {
 		return err
 	}
 
-	identity, err := resolver.Resolve(selector, opts.Repo, os.Getenv("GH_HOST"))
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
 
 	service := report.NewService(apiClientFactory(identity.Host))
-	output, err := service.Fetch(identity, report.Options{
+	output, err := service.Fetch(cmd.Context(), identity, report.Options{
 		Reviewer:             strings.TrimSpace(opts.Reviewer),
 		States:               states,
 		StatesProvided:       statesProvided,
@@ -80,12 +97,21 @@ func runReviewView(cmd *cobra.Command, opts *reviewViewOptions) error {
 		RequireNotOutdated:   opts.NotOutdated,
 		TailReplies:          opts.TailReplies,
 		IncludeCommentNodeID: opts.IncludeCommentNodeID,
+		IncludeReactions:     opts.IncludeReactions,
+		HideMinimized:        opts.HideMinimized,
 	})
 	if err != nil {
 		return err
 	}
 
-	return encodeJSON(cmd, output)
+	switch format {
+	case "markdown", "md":
+		return report.RenderMarkdown(cmd.OutOrStdout(), output)
+	case "text":
+		return report.RenderText(cmd.OutOrStdout(), output, report.RenderOptions{Color: color})
+	default:
+		return encodeJSON(cmd, output)
+	}
 }
 
 func parseStateFilters(raw []string) ([]report.State, bool, error) {
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"strings"
@@ -175,18 +176,80 @@ func TestReviewViewCommandIncludesCommentNodeID(t *testing.T) {
 	}
 }
 
+func TestReviewViewCommandMarkdownFormat(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--format", "markdown", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "## Reviews\n") {
+		t.Fatalf("expected markdown output, got %q", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Fatalf("expected no ANSI escapes in markdown, got %q", out)
+	}
+}
+
+func TestReviewViewCommandInvalidFormat(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--format", "html", "51"})
+
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Fatalf("expected invalid format error, got %v", err)
+	}
+}
+
+func TestReviewViewCommandTextFormatWithoutTTY(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &fakeViewAPI{payload: viewResponse, t: t}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"review", "view", "--repo", "agyn/repo", "--format", "text", "51"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("execute command: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "Reviews\n") {
+		t.Fatalf("expected text output, got %q", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Fatalf("expected color disabled when stdout is not a terminal, got %q", out)
+	}
+}
+
 type fakeViewAPI struct {
 	t         *testing.T
 	payload   []byte
 	variables map[string]interface{}
 }
 
-func (f *fakeViewAPI) REST(string, string, map[string]string, interface{}, interface{}) error {
+func (f *fakeViewAPI) REST(context.Context, string, string, map[string]string, interface{}, interface{}) error {
 	f.t.Fatalf("unexpected REST call in view command")
 	return nil
 }
 
-func (f *fakeViewAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+func (f *fakeViewAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	f.variables = variables
 	return json.Unmarshal(f.payload, result)
 }
@@ -1,36 +1,112 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
 )
 
-// Execute sets up the root command tree and executes it.
+// errInterrupted is the cancellation cause recorded when the process receives
+// SIGINT or SIGTERM, letting callers tell a user abort apart from a timeout.
+var errInterrupted = errors.New("interrupted")
+
+// Execute sets up the root command tree and executes it. In-flight requests
+// are cancelled when the process is interrupted.
 func Execute() error {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case <-signals:
+			cancel(errInterrupted)
+		case <-ctx.Done():
+		}
+	}()
+
 	root := newRootCommand()
-	return root.Execute()
+	return root.ExecuteContext(ctx)
 }
 
 func newRootCommand() *cobra.Command {
+	var (
+		transport     string
+		timeout       time.Duration
+		retries       int
+		cancelTimeout context.CancelFunc
+	)
+
 	cmd := &cobra.Command{
 		Use:           "gh-pr-review",
 		Short:         "PR review helper commands for gh",
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			parsed, err := ghcli.ParseTransport(transport)
+			if err != nil {
+				return err
+			}
+			apiTransport = parsed
+
+			if retries < 0 {
+				return fmt.Errorf("invalid --retries value %d: must be non-negative", retries)
+			}
+			apiRetryPolicy.MaxRetries = retries
+
+			if timeout < 0 {
+				return fmt.Errorf("invalid --timeout value %s: must be non-negative", timeout)
+			}
+			if timeout > 0 {
+				cause := fmt.Errorf("timed out after %s: %w", timeout, context.DeadlineExceeded)
+				ctx, cancel := context.WithTimeoutCause(cmd.Context(), timeout, cause)
+				cancelTimeout = cancel
+				cmd.SetContext(ctx)
+			}
+			return nil
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if cancelTimeout != nil {
+				cancelTimeout()
+			}
+		},
 	}
 
+	cmd.PersistentFlags().StringVar(&transport, "transport", os.Getenv(ghcli.TransportEnv), "API transport: gh (spawn `gh api`) or http (direct HTTPS); defaults to $"+ghcli.TransportEnv)
+	cmd.PersistentFlags().IntVar(&retries, "retries", ghcli.DefaultRetryPolicy().MaxRetries, "Retries for transient API failures (5xx, rate limits); 0 disables")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Abort the command if it has not finished within this duration, e.g. 30s (0 = no limit)")
+
 	cmd.AddCommand(newCommentsCommand())
+	cmd.AddCommand(newDiffCommand())
 	cmd.AddCommand(newReviewCommand())
 	cmd.AddCommand(newThreadsCommand())
+	cmd.AddCommand(newSuggestionsCommand())
+	cmd.AddCommand(newTimelineCommand())
+	cmd.AddCommand(newMCPCommand())
+	cmd.AddCommand(newServeWebhooksCommand())
+	cmd.AddCommand(newWatchCommand())
 
 	return cmd
 }
 
 // ExecuteOrExit runs the command tree and exits with a non-zero status on error.
+// Interrupted runs exit with status 130, following shell convention for SIGINT.
 func ExecuteOrExit() {
 	if err := Execute(); err != nil {
+		if errors.Is(err, errInterrupted) {
+			fmt.Fprintln(os.Stderr, "interrupted: in-flight requests cancelled")
+			os.Exit(130)
+		}
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
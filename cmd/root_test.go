@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+// blockingAPI waits for the request context to end, simulating a hung `gh` process.
+type blockingAPI struct{}
+
+func (blockingAPI) REST(ctx context.Context, _, _ string, _ map[string]string, _, _ interface{}) error {
+	<-ctx.Done()
+	return context.Cause(ctx)
+}
+
+func (blockingAPI) GraphQL(ctx context.Context, _ string, _ map[string]interface{}, _ interface{}) error {
+	<-ctx.Done()
+	return context.Cause(ctx)
+}
+
+func TestRootTimeoutCancelsRequests(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	apiClientFactory = func(host string) ghcli.API { return blockingAPI{} }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--timeout", "20ms", "review", "start", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "timed out after 20ms")
+}
+
+func TestRootInterruptReportsDistinctError(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	apiClientFactory = func(host string) ghcli.API { return blockingAPI{} }
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errInterrupted)
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"threads", "list", "--repo", "octo/demo", "7"})
+
+	err := root.ExecuteContext(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errInterrupted))
+	assert.False(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRootRejectsInvalidTransport(t *testing.T) {
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"--transport", "grpc", "threads", "list", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid transport")
+	assert.Empty(t, stdout.String())
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/webhook"
+)
+
+// webhookSecretEnv supplies --secret without exposing it in the process list.
+const webhookSecretEnv = "GH_PR_REVIEW_WEBHOOK_SECRET"
+
+type serveWebhooksOptions struct {
+	Secret string
+	Addr   string
+	Exec   string
+	Replay string
+	Event  string
+}
+
+func newServeWebhooksCommand() *cobra.Command {
+	opts := &serveWebhooksOptions{Addr: "127.0.0.1:8080"}
+
+	cmd := &cobra.Command{
+		Use:   "serve-webhooks",
+		Short: "Receive review webhooks and run a command or print JSON Lines",
+		Long: `Listen for GitHub webhook deliveries and act on pull_request_review,
+pull_request_review_comment, and pull_request_review_thread events. Each
+delivery's X-Hub-Signature-256 is verified against --secret (or
+` + webhookSecretEnv + `); other events are acknowledged and ignored.
+
+Events are normalized to the review, comment, and thread shapes used by
+` + "`review view`" + ` and ` + "`threads list`" + `. By default each event is printed as one
+JSON line. With --exec, the command runs through sh once per event, one at a
+time, with the event JSON on stdin and GH_PR_REVIEW_EVENT, GH_PR_REVIEW_ACTION,
+GH_PR_REVIEW_REPO, GH_PR_REVIEW_PR, and GH_PR_REVIEW_DELIVERY set; a failing
+command answers the delivery with HTTP 500. GitHub waits 10 seconds for a
+response, so hand long work off to the background.
+
+--replay reads a recorded payload from a file ("-" for stdin) named by --event
+and handles it once without starting a server or checking a signature.`,
+		Example: `  GH_PR_REVIEW_WEBHOOK_SECRET=... gh pr-review serve-webhooks --addr :8080
+  gh pr-review serve-webhooks --secret "$SECRET" --exec './on-review.sh'
+  gh pr-review serve-webhooks --replay payload.json --event pull_request_review_comment`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServeWebhooks(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Secret, "secret", "", "Webhook secret used to verify signatures (default $"+webhookSecretEnv+")")
+	cmd.Flags().StringVar(&opts.Addr, "addr", opts.Addr, "Address to listen on")
+	cmd.Flags().StringVar(&opts.Exec, "exec", "", "Shell command to run for each event instead of printing it")
+	cmd.Flags().StringVar(&opts.Replay, "replay", "", "Handle a recorded payload file ('-' for stdin) and exit")
+	cmd.Flags().StringVar(&opts.Event, "event", "", "Event name of the --replay payload, e.g. pull_request_review")
+
+	return cmd
+}
+
+func runServeWebhooks(cmd *cobra.Command, opts *serveWebhooksOptions) error {
+	handle := webhookPrinter(cmd)
+	if strings.TrimSpace(opts.Exec) != "" {
+		handle = (&webhook.Command{Line: opts.Exec, Stdout: cmd.OutOrStdout(), Stderr: cmd.ErrOrStderr()}).Handle
+	}
+
+	if opts.Replay != "" {
+		return replayWebhook(cmd, opts, handle)
+	}
+	if opts.Event != "" {
+		return errors.New("--event requires --replay")
+	}
+
+	secret := opts.Secret
+	if secret == "" {
+		secret = os.Getenv(webhookSecretEnv)
+	}
+	if secret == "" {
+		return fmt.Errorf("--secret or %s is required", webhookSecretEnv)
+	}
+
+	listener, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	server := &http.Server{
+		Handler:           &webhook.Handler{Secret: []byte(secret), Handle: handle},
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Listening for webhooks on http://%s\n", listener.Addr())
+
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+		return context.Cause(ctx)
+	}
+}
+
+// webhookPrinter writes each event as one JSON line; deliveries may arrive
+// concurrently.
+func webhookPrinter(cmd *cobra.Command) func(context.Context, webhook.Event) error {
+	var mu sync.Mutex
+	return func(_ context.Context, event webhook.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return encodeJSON(cmd, event)
+	}
+}
+
+func replayWebhook(cmd *cobra.Command, opts *serveWebhooksOptions, handle func(context.Context, webhook.Event) error) error {
+	if opts.Event == "" {
+		return errors.New("--event is required with --replay")
+	}
+	body, err := readFileArg(cmd, opts.Replay, "webhook payload")
+	if err != nil {
+		return err
+	}
+	event, err := webhook.Parse(opts.Event, body)
+	if err != nil {
+		return err
+	}
+	return handle(cmd.Context(), event)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const recordedReviewComment = `{"action":"created","comment":{"node_id":"PRRC_1","path":"a.go","line":3,"subject_type":"line",
+"body":"nit","created_at":"2024-06-01T10:05:41Z","user":{"login":"alice"}},
+"pull_request":{"number":7},"repository":{"full_name":"octo/demo"},"sender":{"login":"alice"}}`
+
+func TestServeWebhooksReplayPrintsEvent(t *testing.T) {
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetIn(strings.NewReader(recordedReviewComment))
+	root.SetArgs([]string{"serve-webhooks", "--replay", "-", "--event", "pull_request_review_comment"})
+
+	require.NoError(t, root.Execute())
+
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &event))
+	assert.Equal(t, "pull_request_review_comment", event["event"])
+	assert.Equal(t, "octo/demo", event["repository"])
+	assert.Equal(t, float64(7), event["pull_request"])
+	comment := event["comment"].(map[string]interface{})
+	assert.Equal(t, "PRRC_1", comment["comment_node_id"])
+	assert.Equal(t, "LINE", comment["subject_type"])
+}
+
+func TestServeWebhooksReplayRunsExec(t *testing.T) {
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(io.Discard)
+	root.SetIn(strings.NewReader(recordedReviewComment))
+	root.SetArgs([]string{"serve-webhooks", "--replay", "-", "--event", "pull_request_review_comment",
+		"--exec", `echo "$GH_PR_REVIEW_ACTION #$GH_PR_REVIEW_PR"`})
+
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "created #7\n", stdout.String())
+}
+
+func TestServeWebhooksValidation(t *testing.T) {
+	t.Setenv(webhookSecretEnv, "")
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{}, "--secret or GH_PR_REVIEW_WEBHOOK_SECRET is required"},
+		{[]string{"--event", "pull_request_review"}, "--event requires --replay"},
+		{[]string{"--replay", "-"}, "--event is required with --replay"},
+	} {
+		root := newRootCommand()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetIn(strings.NewReader("{}"))
+		root.SetArgs(append([]string{"serve-webhooks"}, tc.args...))
+
+		err := root.Execute()
+		require.Error(t, err, tc.args)
+		assert.Contains(t, err.Error(), tc.want)
+	}
+}
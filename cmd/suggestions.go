@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/suggestions"
+)
+
+func newSuggestionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suggestions",
+		Short: "List and apply suggested changes from review threads",
+	}
+
+	cmd.AddCommand(newSuggestionsListCommand())
+	cmd.AddCommand(newSuggestionsApplyCommand())
+
+	return cmd
+}
+
+type suggestionsListOptions struct {
+	Repo           string
+	Pull           int
+	Selector       string
+	UnresolvedOnly bool
+}
+
+func newSuggestionsListCommand() *cobra.Command {
+	opts := &suggestionsListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list [<number> | <url>]",
+		Short: "List suggested changes left in review threads",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runSuggestionsList(cmd, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.UnresolvedOnly, "unresolved", false, "Filter to suggestions in unresolved threads only")
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+
+	return cmd
+}
+
+func runSuggestionsList(cmd *cobra.Command, opts *suggestionsListOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := suggestions.NewService(apiClientFactory(identity.Host))
+	payload, err := service.List(cmd.Context(), identity, suggestions.ListOptions{OnlyUnresolved: opts.UnresolvedOnly})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, payload)
+}
+
+type suggestionsApplyOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	IDs      []string
+	All      bool
+	Root     string
+	Resolve  bool
+}
+
+func newSuggestionsApplyCommand() *cobra.Command {
+	opts := &suggestionsApplyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply [<number> | <url>]",
+		Short: "Apply suggested changes to the local worktree",
+		Long: `Apply suggested changes to the local worktree.
+
+Select suggestions with --id, which accepts a suggestion id or a thread id
+(PRRT_...) to take the latest suggestion in that thread, or with --all to take
+the latest suggestion of every unresolved thread. Paths are relative to
+--root, which defaults to the top of the current git checkout.
+
+Each suggestion is applied only when its lines in the worktree still match
+the pull request head; otherwise it is reported as a conflict and left out.
+Pass --resolve to resolve each thread whose suggestion was applied. The
+command exits non-zero when any selected suggestion was not applied.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runSuggestionsApply(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.IDs, "id", nil, "Suggestion id or review thread id (PRRT_...) to apply; repeatable")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Apply the latest suggestion of every unresolved thread")
+	cmd.Flags().StringVar(&opts.Root, "root", "", "Worktree directory the suggestion paths are relative to (default: git checkout root)")
+	cmd.Flags().BoolVar(&opts.Resolve, "resolve", false, "Resolve each thread whose suggestion was applied")
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.MarkFlagsMutuallyExclusive("id", "all")
+
+	return cmd
+}
+
+func runSuggestionsApply(cmd *cobra.Command, opts *suggestionsApplyOptions) error {
+	if !opts.All && len(opts.IDs) == 0 {
+		return errors.New("--id or --all is required")
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	root := opts.Root
+	if root == "" {
+		if root, err = resolver.RepoRoot(); err != nil {
+			return fmt.Errorf("find worktree root: %w; pass --root", err)
+		}
+	}
+
+	service := suggestions.NewService(apiClientFactory(identity.Host))
+	result, err := service.Apply(cmd.Context(), identity, suggestions.ApplyOptions{
+		IDs:     opts.IDs,
+		All:     opts.All,
+		Root:    root,
+		Resolve: opts.Resolve,
+	})
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(cmd, result); err != nil {
+		return err
+	}
+	if result.Failed() {
+		return errors.New("some suggestions were not applied")
+	}
+	return nil
+}
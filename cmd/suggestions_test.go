@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+func TestSuggestionsListCommandOutputsJSON(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if !strings.Contains(query, "query Report(") {
+			return errors.New("unexpected query")
+		}
+		return assignJSON(result, obj{"repository": obj{"pullRequest": obj{
+			"reviews": obj{"nodes": []obj{}},
+			"reviewThreads": obj{"nodes": []obj{{
+				"id": "PRRT_a", "path": "main.go", "line": 4, "startLine": 3, "diffSide": "RIGHT",
+				"comments": obj{"nodes": []obj{{
+					"id": "PRRC_a", "databaseId": 1, "body": "```suggestion\nfixed()\n```",
+					"createdAt": "2024-06-01T00:00:00Z", "author": obj{"login": "alice"},
+				}}},
+			}}},
+		}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"suggestions", "list", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+
+	var payload []map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &payload))
+	require.Len(t, payload, 1)
+	assert.Equal(t, "PRRC_a", payload[0]["id"])
+	assert.Equal(t, "PRRT_a", payload[0]["thread_id"])
+	assert.Equal(t, "main.go", payload[0]["path"])
+	assert.EqualValues(t, 3, payload[0]["start_line"])
+	assert.EqualValues(t, 4, payload[0]["line"])
+	assert.Equal(t, []interface{}{"fixed()"}, payload[0]["replacement"])
+}
+
+func TestSuggestionsApplyCommandRequiresSelection(t *testing.T) {
+	root := newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"suggestions", "apply", "--repo", "octo/demo", "7"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Equal(t, "--id or --all is required", err.Error())
+}
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"errors"
-	"os"
+	"fmt"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/agynio/gh-pr-review/internal/comments"
 	"github.com/agynio/gh-pr-review/internal/resolver"
 	"github.com/agynio/gh-pr-review/internal/threads"
 )
@@ -18,6 +22,7 @@ func newThreadsCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(newThreadsListCommand())
+	cmd.AddCommand(newThreadsViewCommand())
 	cmd.AddCommand(newThreadsResolveCommand())
 	cmd.AddCommand(newThreadsUnresolveCommand())
 
@@ -61,14 +66,13 @@ func runThreadsList(cmd *cobra.Command, opts *threadsListOptions) error {
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
 
 	service := threads.NewService(apiClientFactory(identity.Host))
-	payload, err := service.List(identity, threads.ListOptions{
+	payload, err := service.List(cmd.Context(), identity, threads.ListOptions{
 		OnlyUnresolved: opts.UnresolvedOnly,
 		MineOnly:       opts.MineOnly,
 	})
@@ -79,6 +83,60 @@ func runThreadsList(cmd *cobra.Command, opts *threadsListOptions) error {
 	return encodeJSON(cmd, payload)
 }
 
+func newThreadsViewCommand() *cobra.Command {
+	opts := &threadsViewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "view [<number> | <url>]",
+		Short: "Show one review thread with its conversation and code context",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runThreadsView(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "GraphQL node ID for the review thread")
+	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	_ = cmd.MarkFlagRequired("thread-id")
+
+	return cmd
+}
+
+type threadsViewOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	ThreadID string
+}
+
+func runThreadsView(cmd *cobra.Command, opts *threadsViewOptions) error {
+	threadID := strings.TrimSpace(opts.ThreadID)
+	if !strings.HasPrefix(threadID, "PRRT_") {
+		return fmt.Errorf("invalid thread id %q: must be a GraphQL node id (PRRT_...)", opts.ThreadID)
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := threads.NewService(apiClientFactory(identity.Host))
+	view, err := service.View(cmd.Context(), identity, threadID)
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, view)
+}
+
 func newThreadsResolveCommand() *cobra.Command {
 	return newThreadsMutationCommand(true)
 }
@@ -91,23 +149,43 @@ func newThreadsMutationCommand(resolve bool) *cobra.Command {
 	opts := &threadsMutationOptions{}
 
 	use := "resolve"
-	short := "Resolve a review thread"
+	short := "Resolve review threads"
 	if !resolve {
 		use = "unresolve"
-		short = "Reopen a review thread"
+		short = "Reopen review threads"
+	}
+	long := short + ` by --thread-id, or every matching thread with --all.
+
+--all acts on each thread not already in the requested state, narrowed by
+--mine, --outdated, --path, --author, and --older-than. Mutations run
+--concurrency at a time; --dry-run lists the selected threads without
+changing them.`
+	if resolve {
+		long += `
+
+--reply posts a closing reply to the --thread-id thread before resolving it.
+If the reply lands but the resolve fails, the output says so and the command
+exits non-zero.`
 	}
 
 	cmd := &cobra.Command{
 		Use:   use + " [<number> | <url>]",
 		Short: short,
+		Long:  long,
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				opts.Selector = args[0]
 			}
-			if err := opts.Validate(); err != nil {
+			if err := opts.Validate(cmd); err != nil {
 				return err
 			}
+			if opts.All {
+				return runThreadsMutationAll(cmd, opts, resolve)
+			}
+			if opts.Reply != "" {
+				return runThreadsResolveWithReply(cmd, opts)
+			}
 			if resolve {
 				return runThreadsResolve(cmd, opts)
 			}
@@ -116,6 +194,19 @@ func newThreadsMutationCommand(resolve bool) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&opts.ThreadID, "thread-id", "", "GraphQL node ID for the review thread")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Act on every thread matching the filters")
+	cmd.Flags().BoolVar(&opts.MineOnly, "mine", false, "With --all, only threads involving or resolvable by the viewer")
+	cmd.Flags().BoolVar(&opts.OutdatedOnly, "outdated", false, "With --all, only outdated threads")
+	cmd.Flags().StringSliceVar(&opts.Paths, "path", nil, "With --all, only threads on files matching the glob (repeatable)")
+	cmd.Flags().StringVar(&opts.Author, "author", "", "With --all, only threads started by this login")
+	cmd.Flags().StringVar(&opts.OlderThan, "older-than", "", "With --all, only threads without activity for this long (e.g. 72h, 14d)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "With --all, list the selected threads without changing them")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", threads.DefaultConcurrency, "With --all, number of mutations to run at once")
+	cmd.MarkFlagsMutuallyExclusive("thread-id", "all")
+	if resolve {
+		cmd.Flags().StringVar(&opts.Reply, "reply", "", "Post this reply to the thread before resolving it")
+		cmd.MarkFlagsMutuallyExclusive("reply", "all")
+	}
 	cmd.PersistentFlags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
 	cmd.PersistentFlags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
 
@@ -123,15 +214,46 @@ func newThreadsMutationCommand(resolve bool) *cobra.Command {
 }
 
 type threadsMutationOptions struct {
-	Repo     string
-	Pull     int
-	Selector string
-	ThreadID string
+	Repo         string
+	Pull         int
+	Selector     string
+	ThreadID     string
+	All          bool
+	MineOnly     bool
+	OutdatedOnly bool
+	Paths        []string
+	Author       string
+	OlderThan    string
+	DryRun       bool
+	Concurrency  int
+	Reply        string
 }
 
-func (o *threadsMutationOptions) Validate() error {
-	if strings.TrimSpace(o.ThreadID) == "" {
-		return errors.New("--thread-id is required")
+func (o *threadsMutationOptions) Validate(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("reply") && strings.TrimSpace(o.Reply) == "" {
+		return errors.New("--reply must not be empty")
+	}
+	if !o.All {
+		for _, name := range []string{"mine", "outdated", "path", "author", "older-than", "dry-run", "concurrency"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s requires --all", name)
+			}
+		}
+		if strings.TrimSpace(o.ThreadID) == "" {
+			return errors.New("--thread-id is required unless --all is set")
+		}
+		return nil
+	}
+	for _, pattern := range o.Paths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --path pattern %q: %w", pattern, err)
+		}
+	}
+	if o.Concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	if _, err := parseAge(o.OlderThan); err != nil {
+		return err
 	}
 	return nil
 }
@@ -150,8 +272,7 @@ func runThreadsMutation(cmd *cobra.Command, opts *threadsMutationOptions, resolv
 		return err
 	}
 
-	hostEnv := os.Getenv("GH_HOST")
-	identity, err := resolver.Resolve(selector, opts.Repo, hostEnv)
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
 	if err != nil {
 		return err
 	}
@@ -161,12 +282,98 @@ func runThreadsMutation(cmd *cobra.Command, opts *threadsMutationOptions, resolv
 
 	var result threads.ActionResult
 	if resolve {
-		result, err = service.Resolve(identity, action)
+		result, err = service.Resolve(cmd.Context(), identity, action)
 	} else {
-		result, err = service.Unresolve(identity, action)
+		result, err = service.Unresolve(cmd.Context(), identity, action)
 	}
 	if err != nil {
 		return err
 	}
 	return encodeJSON(cmd, result)
 }
+
+func runThreadsResolveWithReply(cmd *cobra.Command, opts *threadsMutationOptions) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := comments.NewService(apiClientFactory(identity.Host))
+	result, err := service.ReplyAndResolve(cmd.Context(), identity, comments.ReplyOptions{
+		ThreadID: strings.TrimSpace(opts.ThreadID),
+		Body:     opts.Reply,
+	})
+	if err != nil {
+		return err
+	}
+	return encodeReplyResolution(cmd, result)
+}
+
+func runThreadsMutationAll(cmd *cobra.Command, opts *threadsMutationOptions, resolve bool) error {
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	bulk := threads.BulkOptions{
+		MineOnly:     opts.MineOnly,
+		OutdatedOnly: opts.OutdatedOnly,
+		Paths:        opts.Paths,
+		Author:       strings.TrimPrefix(strings.TrimSpace(opts.Author), "@"),
+		DryRun:       opts.DryRun,
+		Concurrency:  opts.Concurrency,
+	}
+	age, err := parseAge(opts.OlderThan)
+	if err != nil {
+		return err
+	}
+	if age > 0 {
+		bulk.UpdatedBefore = time.Now().Add(-age)
+	}
+
+	service := threads.NewService(apiClientFactory(identity.Host))
+	var result *threads.BulkResult
+	if resolve {
+		result, err = service.ResolveAll(cmd.Context(), identity, bulk)
+	} else {
+		result, err = service.UnresolveAll(cmd.Context(), identity, bulk)
+	}
+	if err != nil {
+		return err
+	}
+	if err := encodeJSON(cmd, result); err != nil {
+		return err
+	}
+	if result.Failed() {
+		return errors.New("some threads could not be changed")
+	}
+	return nil
+}
+
+// parseAge parses a --older-than value: a Go duration such as "72h" or a
+// whole number of days such as "14d". An empty value means no limit.
+func parseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if age, err := time.ParseDuration(value); err == nil && age > 0 {
+		return age, nil
+	}
+	return 0, fmt.Errorf("invalid --older-than %q: use a duration such as 72h or 14d", value)
+}
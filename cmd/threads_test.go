@@ -102,6 +102,52 @@ func TestThreadsListCommandOutputsJSON(t *testing.T) {
 	assert.Equal(t, float64(27), payload[0]["line"])
 }
 
+func TestThreadsViewCommand(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "query ThreadView(")
+		assert.Equal(t, "PRRT_thread", variables["id"])
+		return assignJSON(result, obj{"node": obj{
+			"id": "PRRT_thread", "path": "main.go", "subjectType": "FILE", "pullRequest": obj{"number": 9},
+			"comments": obj{"nodes": []obj{{
+				"id": "PRRC_1", "body": "Split this file", "createdAt": "2025-12-03T10:00:00Z",
+				"author": obj{"login": "octocat"}, "originalCommit": obj{"oid": "abc123"},
+			}}},
+		}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "view", "--thread-id", "PRRT_thread", "--repo", "octo/demo", "9"})
+	require.NoError(t, root.Execute())
+	assert.JSONEq(t, `{
+		"thread_id": "PRRT_thread",
+		"path": "main.go",
+		"subject_type": "FILE",
+		"original_commit": "abc123",
+		"is_resolved": false,
+		"is_outdated": false,
+		"viewer_can_reply": false,
+		"viewer_can_resolve": false,
+		"viewer_can_unresolve": false,
+		"comments": [{"id": "PRRC_1", "author_login": "octocat", "body": "Split this file", "created_at": "2025-12-03T10:00:00Z"}]
+	}`, stdout.String())
+
+	root = newRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "view", "--thread-id", "T1", "--repo", "octo/demo", "9"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a GraphQL node id (PRRT_...)")
+}
+
 func TestThreadsResolveCommandByThreadID(t *testing.T) {
 	originalFactory := apiClientFactory
 	defer func() { apiClientFactory = originalFactory }()
@@ -155,6 +201,100 @@ func TestThreadsResolveCommandByThreadID(t *testing.T) {
 	assert.Equal(t, true, payload["is_resolved"])
 }
 
+func TestThreadsResolveAllCommandDryRun(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.restFunc = func(method, path string, params map[string]string, body interface{}, result interface{}) error {
+		switch path {
+		case "repos/octo/demo":
+			return assignJSON(result, obj{"full_name": "octo/demo"})
+		case "repos/octo/demo/pulls/9":
+			return assignJSON(result, obj{"node_id": "PR_node"})
+		default:
+			return errors.New("unexpected REST path: " + path)
+		}
+	}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "reviewThreads")
+		thread := func(id, path string) obj {
+			return obj{
+				"id": id, "isResolved": false, "isOutdated": true, "path": path, "line": 3,
+				"viewerCanResolve": true, "viewerCanUnresolve": false,
+				"comments": obj{"nodes": []obj{{"updatedAt": "2025-01-01T00:00:00Z", "author": obj{"login": "octocat"}}}},
+			}
+		}
+		return assignJSON(result, obj{"node": obj{"reviewThreads": obj{
+			"nodes":    []obj{thread("T1", "internal/a.go"), thread("T2", "docs/b.md")},
+			"pageInfo": obj{"hasNextPage": false},
+		}}})
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--all", "--outdated", "--path", "internal/", "--older-than", "14d", "--dry-run", "--repo", "octo/demo", "9"})
+	require.NoError(t, root.Execute())
+	assert.JSONEq(t, `{"dry_run":true,"threads":[{"thread_node_id":"T1","path":"internal/a.go","line":3,"is_resolved":false,"status":"planned"}]}`, stdout.String())
+}
+
+func TestThreadsResolveValidatesBulkFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"threads", "resolve", "--outdated", "--thread-id", "T1"},
+		{"threads", "resolve", "--all", "--older-than", "soon"},
+		{"threads", "resolve"},
+	} {
+		root := newRootCommand()
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(append(args, "--repo", "octo/demo", "9"))
+		assert.Error(t, root.Execute(), args)
+	}
+}
+
+func TestThreadsResolveWithReplyReportsPartialFailure(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+
+	fake := &commandFakeAPI{}
+	fake.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			input := variables["input"].(map[string]interface{})
+			assert.Equal(t, "PRRT_thread", input["pullRequestReviewThreadId"])
+			assert.Equal(t, "Fixed in abc123", input["body"])
+			return assignJSON(result, obj{"addPullRequestReviewThreadReply": obj{
+				"comment": obj{"id": "PRRC_reply", "author": obj{"login": "octocat"}},
+			}})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRC_reply", "author": obj{"login": "octocat"}}})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread"}})
+		case strings.Contains(query, "ThreadDetails"):
+			return assignJSON(result, obj{"node": obj{"id": "PRRT_thread", "viewerCanResolve": true}})
+		case strings.Contains(query, "resolveReviewThread"):
+			return errors.New("resolve failed")
+		default:
+			return errors.New("unexpected query")
+		}
+	}
+	apiClientFactory = func(host string) ghcli.API { return fake }
+
+	root := newRootCommand()
+	stdout := &bytes.Buffer{}
+	root.SetOut(stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"threads", "resolve", "--thread-id", "PRRT_thread", "--reply", "Fixed in abc123", "--repo", "octo/demo", "9"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reply PRRC_reply was posted but thread PRRT_thread was not resolved")
+	assert.JSONEq(t, `{"comment_node_id":"PRRC_reply","thread_node_id":"PRRT_thread","is_resolved":false,"resolve_error":"resolve failed"}`, stdout.String())
+}
+
 func TestThreadsUnresolveCommandByThreadID(t *testing.T) {
 	originalFactory := apiClientFactory
 	defer func() { apiClientFactory = originalFactory }()
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/timeline"
+)
+
+type timelineOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	Types    []string
+	Author   string
+}
+
+func newTimelineCommand() *cobra.Command {
+	opts := &timelineOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "timeline [<number> | <url>]",
+		Short: "Show conversation comments, reviews, review comments, and events in order",
+		Long: `Show the pull request conversation as one chronological stream.
+
+The timeline merges general conversation comments (issue_comment), review
+summaries (review), inline review comments (review_comment), pushed commits
+(commit), force pushes (force_pushed), review requests (review_requested), and
+dismissed reviews (review_dismissed). Filter with --type and --author.`,
+		Example: `  gh pr-review timeline -R owner/repo 42
+  gh pr-review timeline --type issue_comment,review --author alice`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runTimeline(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().StringSliceVar(&opts.Types, "type", nil, "Only include these event types (comma-separated or repeatable)")
+	cmd.Flags().StringVar(&opts.Author, "author", "", "Only include events by this login (case-insensitive)")
+
+	return cmd
+}
+
+func runTimeline(cmd *cobra.Command, opts *timelineOptions) error {
+	types := make([]timeline.Type, 0, len(opts.Types))
+	for _, value := range opts.Types {
+		t, err := timeline.ParseType(value)
+		if err != nil {
+			return err
+		}
+		types = append(types, t)
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	service := timeline.NewService(apiClientFactory(identity.Host))
+	result, err := service.Fetch(cmd.Context(), identity, timeline.Options{Types: types, Author: opts.Author})
+	if err != nil {
+		return err
+	}
+	return encodeJSON(cmd, result)
+}
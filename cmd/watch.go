@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/watch"
+)
+
+// errWatchElapsed ends a watch whose --for duration has passed.
+var errWatchElapsed = errors.New("watch duration elapsed")
+
+// minWatchInterval keeps --interval from hammering the API.
+const minWatchInterval = 5 * time.Second
+
+type watchOptions struct {
+	Repo     string
+	Pull     int
+	Selector string
+	Interval time.Duration
+	Until    []string
+	For      time.Duration
+}
+
+func newWatchCommand() *cobra.Command {
+	opts := &watchOptions{Interval: watch.DefaultInterval}
+
+	cmd := &cobra.Command{
+		Use:   "watch [<number> | <url>]",
+		Short: "Stream new review activity as JSON Lines",
+		Long: `Poll the pull request and print one JSON object per line for each change:
+review_submitted, thread_created, comment_added, thread_resolved, and
+thread_unresolved. Activity present when the watch starts is not reported.
+
+Each poll runs a small query for the pull request's state, updatedAt, and
+review and thread counts; the full review report is fetched only when that
+changes, and every fifth poll to catch thread resolutions.
+
+The watch ends with pr_merged or pr_closed when the pull request is merged or
+closed, once any --until condition holds, or after --for. --until accepts
+approved, changes_requested, resolved (no unresolved threads remain), or an
+event type to stop after the first such event.`,
+		Example: `  gh pr-review watch -R owner/repo 42
+  gh pr-review watch --interval 30s --until approved --until changes_requested 42`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.Selector = args[0]
+			}
+			return runWatch(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in 'owner/repo' format")
+	cmd.Flags().IntVar(&opts.Pull, "pr", 0, "Pull request number")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", opts.Interval, "Delay between polls (minimum 5s)")
+	cmd.Flags().StringSliceVar(&opts.Until, "until", nil, "Stop once this condition holds (repeatable)")
+	cmd.Flags().DurationVar(&opts.For, "for", 0, "Stop after this long, e.g. 2h (0 = no limit)")
+
+	return cmd
+}
+
+func runWatch(cmd *cobra.Command, opts *watchOptions) error {
+	if opts.Interval < minWatchInterval {
+		return fmt.Errorf("invalid --interval %s: must be at least %s", opts.Interval, minWatchInterval)
+	}
+	if opts.For < 0 {
+		return fmt.Errorf("invalid --for %s: must be non-negative", opts.For)
+	}
+	conditions := make([]watch.Condition, 0, len(opts.Until))
+	for _, value := range opts.Until {
+		condition, err := watch.ParseCondition(value)
+		if err != nil {
+			return err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	selector, err := resolver.NormalizeSelector(opts.Selector, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	identity, err := resolvePullRequest(cmd, selector, opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if opts.For > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.For, errWatchElapsed)
+		defer cancel()
+	}
+
+	service := watch.NewService(apiClientFactory(identity.Host))
+	err = service.Watch(ctx, identity, watch.Options{Interval: opts.Interval, Until: conditions}, func(event watch.Event) error {
+		return encodeJSON(cmd, event)
+	})
+	if err != nil && errors.Is(context.Cause(ctx), errWatchElapsed) {
+		return nil
+	}
+	return err
+}
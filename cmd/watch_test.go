@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+func TestWatchForEndsCleanly(t *testing.T) {
+	originalFactory := apiClientFactory
+	defer func() { apiClientFactory = originalFactory }()
+	apiClientFactory = func(host string) ghcli.API { return blockingAPI{} }
+
+	root := newRootCommand()
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	root.SetArgs([]string{"watch", "--for", "20ms", "--repo", "octo/demo", "7"})
+
+	require.NoError(t, root.Execute())
+}
+
+func TestWatchRejectsInvalidFlags(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--interval", "1s"}, "invalid --interval 1s: must be at least 5s"},
+		{[]string{"--until", "merged"}, "invalid --until \"merged\""},
+		{[]string{"--for", "-1m"}, "invalid --for -1m0s"},
+	} {
+		root := newRootCommand()
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		root.SetArgs(append([]string{"watch", "--repo", "octo/demo", "7"}, tc.args...))
+
+		err := root.Execute()
+		require.Error(t, err, tc.args)
+		assert.Contains(t, err.Error(), tc.want)
+	}
+}
@@ -0,0 +1,302 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/review"
+)
+
+// Comment types reported in Comment.Type.
+const (
+	TypeIssueComment  = "issue_comment"
+	TypeReviewComment = "review_comment"
+	TypeReview        = "review"
+)
+
+const pullRequestIDQuery = `query PullRequestID($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) { id }
+  }
+}`
+
+const addCommentMutation = `mutation AddComment($input: AddCommentInput!) {
+  addComment(input: $input) {
+    commentEdge { node { id } }
+  }
+}`
+
+const updateIssueCommentMutation = `mutation UpdateIssueComment($input: UpdateIssueCommentInput!) {
+  updateIssueComment(input: $input) {
+    issueComment { id }
+  }
+}`
+
+const deleteIssueCommentMutation = `mutation DeleteIssueComment($input: DeleteIssueCommentInput!) {
+  deleteIssueComment(input: $input) {
+    clientMutationId
+  }
+}`
+
+const commentNodeQuery = `query CommentNode($id: ID!) {
+  node(id: $id) {
+    __typename
+    ... on IssueComment {
+      id
+      databaseId
+      body
+      url
+      createdAt
+      updatedAt
+      author { login }
+    }
+    ... on PullRequestReviewComment {
+      id
+      databaseId
+      body
+      url
+      createdAt
+      updatedAt
+      author { login }
+    }
+    ... on PullRequestReview {
+      id
+      databaseId
+      body
+      url
+      createdAt
+      updatedAt
+      author { login }
+    }
+  }
+}`
+
+// Comment is a pull request conversation comment, review comment, or review
+// body as returned by Post, Edit, and quoting.
+type Comment struct {
+	ID          string `json:"id"`
+	DatabaseID  int    `json:"database_id,omitempty"`
+	Type        string `json:"type"`
+	Body        string `json:"body"`
+	HtmlURL     string `json:"html_url,omitempty"`
+	AuthorLogin string `json:"author_login,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+// PostOptions contains the payload for a top-level pull request comment.
+// QuoteID, when set, cites that comment above Body.
+type PostOptions struct {
+	Body    string
+	QuoteID string
+}
+
+// EditOptions identifies the comment to edit and its new body.
+type EditOptions struct {
+	CommentID string
+	Body      string
+}
+
+// DeleteResult reports a deleted comment.
+type DeleteResult struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Deleted bool   `json:"deleted"`
+}
+
+// Post adds a conversation comment to the pull request.
+func (s *Service) Post(ctx context.Context, pr resolver.Identity, opts PostOptions) (Comment, error) {
+	if strings.TrimSpace(opts.Body) == "" {
+		return Comment{}, errors.New("comment body is required")
+	}
+	body, err := s.withQuote(ctx, opts.QuoteID, opts.Body)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	var pull struct {
+		Repository *struct {
+			PullRequest *struct {
+				ID string `json:"id"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	variables := map[string]interface{}{"owner": pr.Owner, "name": pr.Repo, "number": pr.Number}
+	if err := s.API.GraphQL(ctx, pullRequestIDQuery, variables, &pull); err != nil {
+		return Comment{}, err
+	}
+	if pull.Repository == nil || pull.Repository.PullRequest == nil || pull.Repository.PullRequest.ID == "" {
+		return Comment{}, errors.New("pull request not found or inaccessible")
+	}
+
+	var response struct {
+		AddComment struct {
+			CommentEdge *struct {
+				Node *struct {
+					ID string `json:"id"`
+				} `json:"node"`
+			} `json:"commentEdge"`
+		} `json:"addComment"`
+	}
+	input := map[string]interface{}{"subjectId": pull.Repository.PullRequest.ID, "body": body}
+	if err := s.API.GraphQL(ctx, addCommentMutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return Comment{}, err
+	}
+	edge := response.AddComment.CommentEdge
+	if edge == nil || edge.Node == nil || strings.TrimSpace(edge.Node.ID) == "" {
+		return Comment{}, errors.New("mutation response missing comment id")
+	}
+	return s.Comment(ctx, edge.Node.ID)
+}
+
+// Edit replaces the body of a conversation comment (IC_...) or review
+// comment (PRRC_...).
+func (s *Service) Edit(ctx context.Context, pr resolver.Identity, opts EditOptions) (Comment, error) {
+	commentID := strings.TrimSpace(opts.CommentID)
+	commentType, err := commentTypeOf(commentID)
+	if err != nil {
+		return Comment{}, err
+	}
+	body := strings.TrimSpace(opts.Body)
+	if body == "" {
+		return Comment{}, errors.New("comment body is required")
+	}
+
+	if commentType == TypeReviewComment {
+		input := review.UpdateCommentInput{CommentID: commentID, Body: body}
+		if err := review.NewService(s.API).UpdateComment(ctx, pr, input); err != nil {
+			return Comment{}, err
+		}
+		return s.Comment(ctx, commentID)
+	}
+
+	var response struct{}
+	input := map[string]interface{}{"id": commentID, "body": body}
+	if err := s.API.GraphQL(ctx, updateIssueCommentMutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return Comment{}, err
+	}
+	return s.Comment(ctx, commentID)
+}
+
+// Delete removes a conversation comment (IC_...) or review comment (PRRC_...).
+func (s *Service) Delete(ctx context.Context, pr resolver.Identity, commentID string) (DeleteResult, error) {
+	commentID = strings.TrimSpace(commentID)
+	commentType, err := commentTypeOf(commentID)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	if commentType == TypeReviewComment {
+		if err := review.NewService(s.API).DeleteComment(ctx, pr, review.DeleteCommentInput{CommentID: commentID}); err != nil {
+			return DeleteResult{}, err
+		}
+	} else {
+		var response struct{}
+		input := map[string]interface{}{"id": commentID}
+		if err := s.API.GraphQL(ctx, deleteIssueCommentMutation, map[string]interface{}{"input": input}, &response); err != nil {
+			return DeleteResult{}, err
+		}
+	}
+	return DeleteResult{ID: commentID, Type: commentType, Deleted: true}, nil
+}
+
+// Comment loads a conversation comment, review comment, or review by node id.
+func (s *Service) Comment(ctx context.Context, id string) (Comment, error) {
+	var response struct {
+		Node *struct {
+			Typename   string `json:"__typename"`
+			ID         string `json:"id"`
+			DatabaseID int    `json:"databaseId"`
+			Body       string `json:"body"`
+			URL        string `json:"url"`
+			CreatedAt  string `json:"createdAt"`
+			UpdatedAt  string `json:"updatedAt"`
+			Author     *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+		} `json:"node"`
+	}
+	if err := s.API.GraphQL(ctx, commentNodeQuery, map[string]interface{}{"id": id}, &response); err != nil {
+		return Comment{}, err
+	}
+	node := response.Node
+	if node == nil || strings.TrimSpace(node.ID) == "" {
+		return Comment{}, fmt.Errorf("comment %s not found", id)
+	}
+
+	comment := Comment{
+		ID:         node.ID,
+		DatabaseID: node.DatabaseID,
+		Body:       node.Body,
+		HtmlURL:    node.URL,
+		CreatedAt:  node.CreatedAt,
+		UpdatedAt:  node.UpdatedAt,
+	}
+	switch node.Typename {
+	case "IssueComment":
+		comment.Type = TypeIssueComment
+	case "PullRequestReviewComment":
+		comment.Type = TypeReviewComment
+	case "PullRequestReview":
+		comment.Type = TypeReview
+	default:
+		return Comment{}, fmt.Errorf("%s is a %s, not a comment", id, node.Typename)
+	}
+	if node.Author != nil {
+		comment.AuthorLogin = node.Author.Login
+	}
+	return comment, nil
+}
+
+// withQuote prefixes body with a citation of the comment quoteID, if set.
+func (s *Service) withQuote(ctx context.Context, quoteID, body string) (string, error) {
+	quoteID = strings.TrimSpace(quoteID)
+	if quoteID == "" {
+		return body, nil
+	}
+	quoted, err := s.Comment(ctx, quoteID)
+	if err != nil {
+		return "", err
+	}
+	return QuoteBody(quoted, body), nil
+}
+
+// QuoteBody cites quoted above body the way GitHub's "Quote reply" does,
+// attributing it to its author and linking the original when possible.
+func QuoteBody(quoted Comment, body string) string {
+	var b strings.Builder
+	if quoted.AuthorLogin != "" {
+		if quoted.HtmlURL != "" {
+			fmt.Fprintf(&b, "@%s [wrote](%s):\n", quoted.AuthorLogin, quoted.HtmlURL)
+		} else {
+			fmt.Fprintf(&b, "@%s wrote:\n", quoted.AuthorLogin)
+		}
+	}
+	text := strings.TrimSpace(strings.ReplaceAll(quoted.Body, "\r\n", "\n"))
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			b.WriteString(">\n")
+			continue
+		}
+		b.WriteString("> " + line + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(body)
+	return b.String()
+}
+
+func commentTypeOf(id string) (string, error) {
+	switch {
+	case id == "":
+		return "", errors.New("comment id is required")
+	case strings.HasPrefix(id, "IC_"):
+		return TypeIssueComment, nil
+	case strings.HasPrefix(id, "PRRC_"):
+		return TypeReviewComment, nil
+	default:
+		return "", fmt.Errorf("invalid comment id %q: must be a GraphQL node id (IC_... or PRRC_...)", id)
+	}
+}
@@ -0,0 +1,126 @@
+package comments
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+func commentNode(typename, id, login, body string) map[string]interface{} {
+	return map[string]interface{}{"node": map[string]interface{}{
+		"__typename": typename,
+		"id":         id,
+		"databaseId": 11,
+		"body":       body,
+		"url":        "https://github.com/octo/demo/pull/7#" + id,
+		"createdAt":  "2024-06-01T10:00:00Z",
+		"updatedAt":  "2024-06-01T10:00:00Z",
+		"author":     map[string]interface{}{"login": login},
+	}}
+}
+
+func TestServicePostQuotesComment(t *testing.T) {
+	var posted map[string]interface{}
+	api := &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "query CommentNode("):
+			if variables["id"] == "PRRC_orig" {
+				return assign(result, commentNode("PullRequestReviewComment", "PRRC_orig", "alice", "Why not a map?\n\nIt would be simpler."))
+			}
+			return assign(result, commentNode("IssueComment", variables["id"].(string), "bot", "posted"))
+		case strings.Contains(query, "query PullRequestID("):
+			assert.Equal(t, 7, variables["number"])
+			return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{"id": "PR_node"}}})
+		case strings.Contains(query, "mutation AddComment("):
+			posted = variables["input"].(map[string]interface{})
+			return assign(result, map[string]interface{}{"addComment": map[string]interface{}{"commentEdge": map[string]interface{}{"node": map[string]interface{}{"id": "IC_new"}}}})
+		}
+		t.Fatalf("unexpected query: %s", query)
+		return nil
+	}}
+
+	svc := NewService(api)
+	comment, err := svc.Post(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, PostOptions{Body: "Order matters here.", QuoteID: "PRRC_orig"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "PR_node", posted["subjectId"])
+	assert.Equal(t, "@alice [wrote](https://github.com/octo/demo/pull/7#PRRC_orig):\n> Why not a map?\n>\n> It would be simpler.\n\nOrder matters here.", posted["body"])
+	assert.Equal(t, "IC_new", comment.ID)
+	assert.Equal(t, TypeIssueComment, comment.Type)
+	assert.Equal(t, 11, comment.DatabaseID)
+}
+
+func TestServiceEditRoutesByCommentType(t *testing.T) {
+	var mutations []string
+	api := &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "updateIssueComment"):
+			input := variables["input"].(map[string]interface{})
+			mutations = append(mutations, "issue:"+input["id"].(string)+":"+input["body"].(string))
+			return nil
+		case strings.Contains(query, "updatePullRequestReviewComment"):
+			input := variables["input"].(map[string]interface{})
+			mutations = append(mutations, "review:"+input["pullRequestReviewCommentId"].(string)+":"+input["body"].(string))
+			return nil
+		case strings.Contains(query, "query CommentNode("):
+			id := variables["id"].(string)
+			typename := "IssueComment"
+			if strings.HasPrefix(id, "PRRC_") {
+				typename = "PullRequestReviewComment"
+			}
+			return assign(result, commentNode(typename, id, "bot", "edited"))
+		}
+		t.Fatalf("unexpected query: %s", query)
+		return nil
+	}}
+
+	svc := NewService(api)
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+	issue, err := svc.Edit(context.Background(), pr, EditOptions{CommentID: "IC_1", Body: " edited "})
+	require.NoError(t, err)
+	assert.Equal(t, TypeIssueComment, issue.Type)
+
+	reviewComment, err := svc.Edit(context.Background(), pr, EditOptions{CommentID: "PRRC_2", Body: "edited"})
+	require.NoError(t, err)
+	assert.Equal(t, TypeReviewComment, reviewComment.Type)
+
+	assert.Equal(t, []string{"issue:IC_1:edited", "review:PRRC_2:edited"}, mutations)
+
+	_, err = svc.Edit(context.Background(), pr, EditOptions{CommentID: "PRRT_3", Body: "x"})
+	require.EqualError(t, err, `invalid comment id "PRRT_3": must be a GraphQL node id (IC_... or PRRC_...)`)
+}
+
+func TestServiceDelete(t *testing.T) {
+	var deleted []string
+	api := &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		switch {
+		case strings.Contains(query, "deleteIssueComment"):
+			deleted = append(deleted, "issue:"+input["id"].(string))
+		case strings.Contains(query, "deletePullRequestReviewComment"):
+			deleted = append(deleted, "review:"+input["id"].(string))
+		default:
+			t.Fatalf("unexpected query: %s", query)
+		}
+		return nil
+	}}
+
+	svc := NewService(api)
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+	result, err := svc.Delete(context.Background(), pr, "IC_1")
+	require.NoError(t, err)
+	assert.Equal(t, DeleteResult{ID: "IC_1", Type: TypeIssueComment, Deleted: true}, result)
+
+	_, err = svc.Delete(context.Background(), pr, "PRRC_2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"issue:IC_1", "review:PRRC_2"}, deleted)
+}
+
+func TestQuoteBodyWithoutAuthor(t *testing.T) {
+	assert.Equal(t, "> original\n\nreply", QuoteBody(Comment{Body: "original\r\n"}, "reply"))
+}
@@ -0,0 +1,62 @@
+package comments
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMinimizeReason(t *testing.T) {
+	reason, err := ParseMinimizeReason("off-topic")
+	require.NoError(t, err)
+	assert.Equal(t, "OFF_TOPIC", reason)
+
+	_, err = ParseMinimizeReason("boring")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid reason")
+}
+
+func TestServiceMinimizeAndUnminimize(t *testing.T) {
+	api := &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "PRRC_1", input["subjectId"])
+		switch {
+		case strings.Contains(query, "unminimizeComment"):
+			assert.NotContains(t, input, "classifier")
+			return assign(result, map[string]interface{}{"unminimizeComment": map[string]interface{}{
+				"unminimizedComment": map[string]interface{}{"isMinimized": false, "minimizedReason": nil},
+			}})
+		case strings.Contains(query, "minimizeComment"):
+			assert.Equal(t, "SPAM", input["classifier"])
+			return assign(result, map[string]interface{}{"minimizeComment": map[string]interface{}{
+				"minimizedComment": map[string]interface{}{"isMinimized": true, "minimizedReason": "spam"},
+			}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}}
+
+	svc := NewService(api)
+	result, err := svc.Minimize(context.Background(), MinimizeOptions{CommentID: "PRRC_1", Reason: "spam"})
+	require.NoError(t, err)
+	assert.Equal(t, MinimizeResult{CommentID: "PRRC_1", IsMinimized: true, MinimizedReason: "SPAM"}, result)
+
+	result, err = svc.Unminimize(context.Background(), "PRRC_1")
+	require.NoError(t, err)
+	assert.Equal(t, MinimizeResult{CommentID: "PRRC_1"}, result)
+}
+
+func TestServiceMinimizeRejectsInvalidInput(t *testing.T) {
+	svc := NewService(&fakeAPI{})
+	_, err := svc.Minimize(context.Background(), MinimizeOptions{CommentID: "PRR_1", Reason: "SPAM"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid comment id")
+
+	_, err = svc.Minimize(context.Background(), MinimizeOptions{CommentID: "IC_1", Reason: "nope"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid reason")
+}
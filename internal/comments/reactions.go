@@ -0,0 +1,129 @@
+package comments
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const addReactionMutation = `mutation AddReaction($input: AddReactionInput!) {
+  addReaction(input: $input) {
+    subject {
+      id
+      reactionGroups {
+        content
+        viewerHasReacted
+        reactors { totalCount }
+      }
+    }
+  }
+}`
+
+const removeReactionMutation = `mutation RemoveReaction($input: RemoveReactionInput!) {
+  removeReaction(input: $input) {
+    subject {
+      id
+      reactionGroups {
+        content
+        viewerHasReacted
+        reactors { totalCount }
+      }
+    }
+  }
+}`
+
+// reactionAliases maps the shorthand accepted by ParseReaction to GitHub's
+// ReactionContent values.
+var reactionAliases = map[string]string{
+	"+1":          "THUMBS_UP",
+	"-1":          "THUMBS_DOWN",
+	"THUMBS_UP":   "THUMBS_UP",
+	"THUMBS_DOWN": "THUMBS_DOWN",
+	"LAUGH":       "LAUGH",
+	"HOORAY":      "HOORAY",
+	"CONFUSED":    "CONFUSED",
+	"HEART":       "HEART",
+	"ROCKET":      "ROCKET",
+	"EYES":        "EYES",
+}
+
+// ParseReaction normalizes a reaction name such as "thumbs_up" or "+1" to its
+// ReactionContent value.
+func ParseReaction(value string) (string, error) {
+	key := strings.ToUpper(strings.TrimSpace(value))
+	if content, ok := reactionAliases[key]; ok {
+		return content, nil
+	}
+	return "", fmt.Errorf("invalid reaction %q: must be one of THUMBS_UP, THUMBS_DOWN, LAUGH, HOORAY, CONFUSED, HEART, ROCKET, EYES", value)
+}
+
+// ReactOptions identifies the comment and the reaction to add or remove.
+type ReactOptions struct {
+	CommentID string
+	Reaction  string
+	Remove    bool
+}
+
+// ReactionResult reports the state of one reaction on a comment after React.
+type ReactionResult struct {
+	CommentID        string `json:"comment_id"`
+	Reaction         string `json:"reaction"`
+	Count            int    `json:"count"`
+	ViewerHasReacted bool   `json:"viewer_has_reacted"`
+}
+
+// React adds the viewer's reaction to a conversation comment (IC_...) or
+// review comment (PRRC_...), or removes it when Remove is set. Both are
+// no-ops when the reaction is already in the requested state.
+func (s *Service) React(ctx context.Context, opts ReactOptions) (ReactionResult, error) {
+	commentID := strings.TrimSpace(opts.CommentID)
+	if _, err := commentTypeOf(commentID); err != nil {
+		return ReactionResult{}, err
+	}
+	content, err := ParseReaction(opts.Reaction)
+	if err != nil {
+		return ReactionResult{}, err
+	}
+
+	type payload struct {
+		Subject *struct {
+			ID             string `json:"id"`
+			ReactionGroups []struct {
+				Content          string `json:"content"`
+				ViewerHasReacted bool   `json:"viewerHasReacted"`
+				Reactors         struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"reactors"`
+			} `json:"reactionGroups"`
+		} `json:"subject"`
+	}
+	var response struct {
+		AddReaction    *payload `json:"addReaction"`
+		RemoveReaction *payload `json:"removeReaction"`
+	}
+	mutation := addReactionMutation
+	if opts.Remove {
+		mutation = removeReactionMutation
+	}
+	input := map[string]interface{}{"subjectId": commentID, "content": content}
+	if err := s.API.GraphQL(ctx, mutation, map[string]interface{}{"input": input}, &response); err != nil {
+		return ReactionResult{}, err
+	}
+
+	data := response.AddReaction
+	if opts.Remove {
+		data = response.RemoveReaction
+	}
+	if data == nil || data.Subject == nil {
+		return ReactionResult{}, fmt.Errorf("comment %s not found", commentID)
+	}
+
+	result := ReactionResult{CommentID: commentID, Reaction: content}
+	for _, group := range data.Subject.ReactionGroups {
+		if group.Content == content {
+			result.Count = group.Reactors.TotalCount
+			result.ViewerHasReacted = group.ViewerHasReacted
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package comments
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReaction(t *testing.T) {
+	for input, want := range map[string]string{"thumbs_up": "THUMBS_UP", "+1": "THUMBS_UP", "-1": "THUMBS_DOWN", " Rocket ": "ROCKET"} {
+		got, err := ParseReaction(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseReaction("tada")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid reaction")
+}
+
+func TestServiceReact(t *testing.T) {
+	var mutations []string
+	api := &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "PRRC_1", input["subjectId"])
+		assert.Equal(t, "HEART", input["content"])
+		groups := []map[string]interface{}{
+			{"content": "THUMBS_UP", "viewerHasReacted": false, "reactors": map[string]interface{}{"totalCount": 2}},
+		}
+		switch {
+		case strings.Contains(query, "addReaction"):
+			mutations = append(mutations, "add")
+			groups = append(groups, map[string]interface{}{"content": "HEART", "viewerHasReacted": true, "reactors": map[string]interface{}{"totalCount": 3}})
+			return assign(result, map[string]interface{}{"addReaction": map[string]interface{}{"subject": map[string]interface{}{"id": "PRRC_1", "reactionGroups": groups}}})
+		case strings.Contains(query, "removeReaction"):
+			mutations = append(mutations, "remove")
+			return assign(result, map[string]interface{}{"removeReaction": map[string]interface{}{"subject": map[string]interface{}{"id": "PRRC_1", "reactionGroups": groups}}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}}
+
+	svc := NewService(api)
+	result, err := svc.React(context.Background(), ReactOptions{CommentID: "PRRC_1", Reaction: "heart"})
+	require.NoError(t, err)
+	assert.Equal(t, ReactionResult{CommentID: "PRRC_1", Reaction: "HEART", Count: 3, ViewerHasReacted: true}, result)
+
+	result, err = svc.React(context.Background(), ReactOptions{CommentID: "PRRC_1", Reaction: "HEART", Remove: true})
+	require.NoError(t, err)
+	assert.Equal(t, ReactionResult{CommentID: "PRRC_1", Reaction: "HEART"}, result)
+	assert.Equal(t, []string{"add", "remove"}, mutations)
+}
+
+func TestServiceReactRejectsInvalidCommentID(t *testing.T) {
+	svc := NewService(&fakeAPI{})
+	_, err := svc.React(context.Background(), ReactOptions{CommentID: "PRRT_1", Reaction: "HEART"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid comment id")
+}
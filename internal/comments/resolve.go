@@ -0,0 +1,51 @@
+package comments
+
+import (
+	"context"
+	"errors"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/threads"
+)
+
+// ReplyResolution reports a closing reply and the thread's resolution after
+// ReplyAndResolve. ResolveError is set when the reply was posted but the
+// thread could not be resolved.
+type ReplyResolution struct {
+	CommentNodeID string `json:"comment_node_id"`
+	ThreadNodeID  string `json:"thread_node_id"`
+	IsResolved    bool   `json:"is_resolved"`
+	ResolveError  string `json:"resolve_error,omitempty"`
+}
+
+// ReplyAndResolve posts a reply to the thread and then resolves it. A thread
+// the viewer cannot resolve is refused before anything is posted. Once the
+// reply lands, a failed resolve is reported in ReplyResolution.ResolveError
+// rather than as an error so callers can tell the reply was posted.
+func (s *Service) ReplyAndResolve(ctx context.Context, pr resolver.Identity, opts ReplyOptions) (ReplyResolution, error) {
+	threadService := threads.NewService(s.API)
+	if err := threadService.CheckResolvable(ctx, pr, threads.ActionOptions{ThreadID: opts.ThreadID}); err != nil {
+		return ReplyResolution{}, err
+	}
+
+	reply, err := s.Reply(ctx, pr, opts)
+	if err != nil {
+		return ReplyResolution{}, err
+	}
+	if reply.CommentNodeID == "" {
+		return ReplyResolution{}, errors.New("reply response missing comment node id")
+	}
+
+	result := ReplyResolution{
+		CommentNodeID: reply.CommentNodeID,
+		ThreadNodeID:  reply.ThreadID,
+		IsResolved:    reply.ThreadIsResolved,
+	}
+	resolved, err := threadService.Resolve(ctx, pr, threads.ActionOptions{ThreadID: reply.ThreadID})
+	if err != nil {
+		result.ResolveError = err.Error()
+		return result, nil
+	}
+	result.IsResolved = resolved.IsResolved
+	return result, nil
+}
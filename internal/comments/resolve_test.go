@@ -0,0 +1,89 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// replyAndResolveAPI answers the reply queries and the thread resolve,
+// failing the resolve mutation with resolveErr when set.
+func replyAndResolveAPI(t *testing.T, canResolve bool, resolveErr error) *fakeAPI {
+	return &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "AddPullRequestReviewThreadReply"):
+			return assign(result, map[string]interface{}{"addPullRequestReviewThreadReply": map[string]interface{}{
+				"comment": map[string]interface{}{"id": "PRRC_reply", "author": map[string]interface{}{"login": "octocat"}},
+			}})
+		case strings.Contains(query, "PullRequestReviewCommentDetails"):
+			return assign(result, map[string]interface{}{"node": map[string]interface{}{
+				"id": "PRRC_reply", "body": "Fixed in abc123", "author": map[string]interface{}{"login": "octocat"},
+			}})
+		case strings.Contains(query, "PullRequestReviewThreadDetails"):
+			return assign(result, map[string]interface{}{"node": map[string]interface{}{"id": "PRRT_thread"}})
+		case strings.Contains(query, "ThreadDetails"):
+			return assign(result, map[string]interface{}{"node": map[string]interface{}{
+				"id": "PRRT_thread", "viewerCanResolve": canResolve,
+			}})
+		case strings.Contains(query, "resolveReviewThread"):
+			if resolveErr != nil {
+				return resolveErr
+			}
+			return assign(result, map[string]interface{}{"resolveReviewThread": map[string]interface{}{
+				"thread": map[string]interface{}{"id": "PRRT_thread", "isResolved": true},
+			}})
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}}
+}
+
+func TestServiceReplyAndResolve(t *testing.T) {
+	svc := NewService(replyAndResolveAPI(t, true, nil))
+	result, err := svc.ReplyAndResolve(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Fixed in abc123"})
+	require.NoError(t, err)
+	assert.Equal(t, ReplyResolution{CommentNodeID: "PRRC_reply", ThreadNodeID: "PRRT_thread", IsResolved: true}, result)
+}
+
+func TestServiceReplyAndResolveRefusesBeforePosting(t *testing.T) {
+	api := replyAndResolveAPI(t, false, nil)
+	answer := api.graphqlFunc
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		require.NotContains(t, query, "AddPullRequestReviewThreadReply")
+		return answer(query, variables, result)
+	}
+
+	_, err := NewService(api).ReplyAndResolve(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Fixed in abc123"})
+	require.EqualError(t, err, "viewer cannot resolve this thread")
+}
+
+func TestServiceReplyAndResolveReportsResolveFailure(t *testing.T) {
+	svc := NewService(replyAndResolveAPI(t, true, errors.New("boom")))
+	result, err := svc.ReplyAndResolve(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Fixed in abc123"})
+	require.NoError(t, err)
+	assert.Equal(t, "PRRC_reply", result.CommentNodeID)
+	assert.False(t, result.IsResolved)
+	assert.Equal(t, "boom", result.ResolveError)
+}
+
+func TestServiceReplyAndResolveReturnsReplyError(t *testing.T) {
+	api := replyAndResolveAPI(t, true, nil)
+	answer := api.graphqlFunc
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "AddPullRequestReviewThreadReply") {
+			return errors.New("reply failed")
+		}
+		require.NotContains(t, query, "resolveReviewThread")
+		return answer(query, variables, result)
+	}
+	svc := NewService(api)
+	_, err := svc.ReplyAndResolve(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Fixed"})
+	require.EqualError(t, err, "reply failed")
+}
@@ -1,6 +1,7 @@
 package comments
 
 import (
+	"context"
 	"errors"
 	"strings"
 
@@ -53,10 +54,12 @@ type Service struct {
 }
 
 // ReplyOptions contains the payload for replying to a review comment thread.
+// QuoteID, when set, cites that comment above Body.
 type ReplyOptions struct {
 	ThreadID string
 	ReviewID string
 	Body     string
+	QuoteID  string
 }
 
 // Reply represents the normalized GraphQL response after adding a thread reply.
@@ -113,7 +116,7 @@ func NewService(api ghcli.API) *Service {
 }
 
 // Reply posts a reply to an existing review thread using the GraphQL API.
-func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
+func (s *Service) Reply(ctx context.Context, _ resolver.Identity, opts ReplyOptions) (Reply, error) {
 	threadID := strings.TrimSpace(opts.ThreadID)
 	if threadID == "" {
 		return Reply{}, errors.New("thread id is required")
@@ -121,10 +124,14 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 	if strings.TrimSpace(opts.Body) == "" {
 		return Reply{}, errors.New("reply body is required")
 	}
+	body, err := s.withQuote(ctx, opts.QuoteID, opts.Body)
+	if err != nil {
+		return Reply{}, err
+	}
 
 	input := map[string]interface{}{
 		"pullRequestReviewThreadId": threadID,
-		"body":                      opts.Body,
+		"body":                      body,
 	}
 	if reviewID := strings.TrimSpace(opts.ReviewID); reviewID != "" {
 		input["pullRequestReviewId"] = reviewID
@@ -145,7 +152,7 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 		} `json:"addPullRequestReviewThreadReply"`
 	}
 
-	if err := s.API.GraphQL(addThreadReplyMutation, variables, &response); err != nil {
+	if err := s.API.GraphQL(ctx, addThreadReplyMutation, variables, &response); err != nil {
 		return Reply{}, err
 	}
 
@@ -159,12 +166,12 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 	if comment.Author == nil || strings.TrimSpace(comment.Author.Login) == "" {
 		return Reply{}, errors.New("mutation response missing author login")
 	}
-	commentDetails, err := s.loadCommentDetails(comment.ID)
+	commentDetails, err := s.loadCommentDetails(ctx, comment.ID)
 	if err != nil {
 		return Reply{}, err
 	}
 
-	threadDetails, err := s.loadThreadDetails(threadID)
+	threadDetails, err := s.loadThreadDetails(ctx, threadID)
 	if err != nil {
 		return Reply{}, err
 	}
@@ -212,12 +219,12 @@ func (s *Service) Reply(_ resolver.Identity, opts ReplyOptions) (Reply, error) {
 	return reply, nil
 }
 
-func (s *Service) loadCommentDetails(id string) (commentDetails, error) {
+func (s *Service) loadCommentDetails(ctx context.Context, id string) (commentDetails, error) {
 	variables := map[string]interface{}{"id": id}
 	var response struct {
 		Node *commentDetails `json:"node"`
 	}
-	if err := s.API.GraphQL(commentDetailsQuery, variables, &response); err != nil {
+	if err := s.API.GraphQL(ctx, commentDetailsQuery, variables, &response); err != nil {
 		return commentDetails{}, err
 	}
 	if response.Node == nil || strings.TrimSpace(response.Node.ID) == "" {
@@ -229,12 +236,12 @@ func (s *Service) loadCommentDetails(id string) (commentDetails, error) {
 	return *response.Node, nil
 }
 
-func (s *Service) loadThreadDetails(id string) (threadDetails, error) {
+func (s *Service) loadThreadDetails(ctx context.Context, id string) (threadDetails, error) {
 	variables := map[string]interface{}{"id": id}
 	var response struct {
 		Node *threadDetails `json:"node"`
 	}
-	if err := s.API.GraphQL(threadDetailsQuery, variables, &response); err != nil {
+	if err := s.API.GraphQL(ctx, threadDetailsQuery, variables, &response); err != nil {
 		return threadDetails{}, err
 	}
 	if response.Node == nil || strings.TrimSpace(response.Node.ID) == "" {
@@ -1,6 +1,7 @@
 package comments
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strings"
@@ -17,14 +18,14 @@ type fakeAPI struct {
 	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
 }
 
-func (f *fakeAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+func (f *fakeAPI) REST(_ context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
 	if f.restFunc == nil {
 		return errors.New("unexpected REST call")
 	}
 	return f.restFunc(method, path, params, body, result)
 }
 
-func (f *fakeAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+func (f *fakeAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	if f.graphqlFunc == nil {
 		return errors.New("unexpected GraphQL call")
 	}
@@ -43,7 +44,7 @@ func TestServiceReply_RejectsMissingThreadID(t *testing.T) {
 	api := &fakeAPI{}
 	svc := NewService(api)
 
-	_, err := svc.Reply(resolver.Identity{}, ReplyOptions{ThreadID: "", Body: "hello"})
+	_, err := svc.Reply(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "", Body: "hello"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "thread id is required")
 }
@@ -52,7 +53,7 @@ func TestServiceReply_RejectsBlankBody(t *testing.T) {
 	api := &fakeAPI{}
 	svc := NewService(api)
 
-	_, err := svc.Reply(resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "   "})
+	_, err := svc.Reply(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "   "})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "reply body is required")
 }
@@ -118,7 +119,7 @@ func TestServiceReply_SendsMutation(t *testing.T) {
 	}
 
 	svc := NewService(api)
-	reply, err := svc.Reply(resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", ReviewID: "PRR_pending", Body: "Body text"})
+	reply, err := svc.Reply(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", ReviewID: "PRR_pending", Body: "Body text"})
 	require.NoError(t, err)
 	assert.Equal(t, "PRRC_reply", reply.CommentNodeID)
 	assert.Equal(t, "PRRT_thread", reply.ThreadID)
@@ -204,7 +205,7 @@ func TestServiceReply_OmitsOptionalFields(t *testing.T) {
 	}
 
 	svc := NewService(api)
-	reply, err := svc.Reply(resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Ack"})
+	reply, err := svc.Reply(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Ack"})
 	require.NoError(t, err)
 	assert.Equal(t, "PRRC_reply", reply.CommentNodeID)
 	assert.Equal(t, "PRRT_thread", reply.ThreadID)
@@ -235,7 +236,7 @@ func TestServiceReply_ErrorsOnMissingComment(t *testing.T) {
 	}
 
 	svc := NewService(api)
-	_, err := svc.Reply(resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Ack"})
+	_, err := svc.Reply(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Ack"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "mutation response missing comment")
 }
@@ -282,7 +283,7 @@ func TestServiceReply_ErrorsOnMissingThread(t *testing.T) {
 	}
 
 	svc := NewService(api)
-	_, err := svc.Reply(resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Ack"})
+	_, err := svc.Reply(context.Background(), resolver.Identity{}, ReplyOptions{ThreadID: "PRRT_thread", Body: "Ack"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to load thread details")
 }
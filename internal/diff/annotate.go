@@ -0,0 +1,110 @@
+package diff
+
+import (
+	"path"
+	"strings"
+)
+
+// AnnotatedFile is the `diff` command's view of a changed file.
+type AnnotatedFile struct {
+	Path         string          `json:"path"`
+	PreviousPath string          `json:"previous_path,omitempty"`
+	Status       string          `json:"status,omitempty"`
+	Commentable  bool            `json:"commentable"`
+	Hunks        []AnnotatedHunk `json:"hunks"`
+}
+
+// AnnotatedHunk groups the annotated lines of one hunk.
+type AnnotatedHunk struct {
+	Header string          `json:"header"`
+	Lines  []AnnotatedLine `json:"lines"`
+}
+
+// AnnotatedLine carries everything needed to anchor a comment on a diff line:
+// pass Side as --side and the line number for that side as --line.
+type AnnotatedLine struct {
+	Type        LineKind `json:"type"`
+	OldLine     *int     `json:"old_line,omitempty"`
+	NewLine     *int     `json:"new_line,omitempty"`
+	Side        string   `json:"side"`
+	Commentable bool     `json:"commentable"`
+	Content     string   `json:"content"`
+}
+
+// Annotate converts parsed files into their annotated form. Context lines are
+// reported on the RIGHT side, which is what GitHub uses by default; they can
+// also be addressed from LEFT using old_line. Files without a textual patch
+// are listed with no hunks and commentable set to false.
+func Annotate(files []File) []AnnotatedFile {
+	annotated := make([]AnnotatedFile, 0, len(files))
+	for _, file := range files {
+		out := AnnotatedFile{
+			Path:         file.Path,
+			PreviousPath: file.PreviousPath,
+			Status:       file.Status,
+			Commentable:  len(file.Hunks) > 0,
+			Hunks:        make([]AnnotatedHunk, 0, len(file.Hunks)),
+		}
+		for _, hunk := range file.Hunks {
+			lines := make([]AnnotatedLine, 0, len(hunk.Lines))
+			for _, line := range hunk.Lines {
+				entry := AnnotatedLine{Type: line.Kind, Side: SideRight, Commentable: true, Content: line.Content}
+				if line.OldLine > 0 {
+					oldLine := line.OldLine
+					entry.OldLine = &oldLine
+				}
+				if line.NewLine > 0 {
+					newLine := line.NewLine
+					entry.NewLine = &newLine
+				}
+				if line.Kind == KindDelete {
+					entry.Side = SideLeft
+				}
+				lines = append(lines, entry)
+			}
+			out.Hunks = append(out.Hunks, AnnotatedHunk{Header: hunk.Header, Lines: lines})
+		}
+		annotated = append(annotated, out)
+	}
+	return annotated
+}
+
+// FilterPaths keeps files whose path (or previous path) matches any of the
+// glob patterns. Patterns without a slash also match against the base name,
+// so "*.go" selects Go files in every directory. No patterns keeps all files.
+func FilterPaths(files []File, patterns []string) []File {
+	if len(patterns) == 0 {
+		return files
+	}
+	filtered := make([]File, 0, len(files))
+	for _, file := range files {
+		if MatchPath(file.Path, patterns) || (file.PreviousPath != "" && MatchPath(file.PreviousPath, patterns)) {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
+
+// MatchPath reports whether name matches any of the glob patterns. Patterns
+// without a slash also match the base name, and a trailing slash matches a
+// directory prefix.
+func MatchPath(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(name)); ok {
+				return true
+			}
+		}
+		if strings.HasSuffix(pattern, "/") && strings.HasPrefix(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotate(t *testing.T) {
+	files := []File{
+		{Path: "main.go", Status: "modified", Hunks: ParsePatch("@@ -1,2 +1,2 @@\n a\n-b\n+B")},
+		{Path: "logo.png", Status: "added"},
+	}
+
+	annotated := Annotate(files)
+	require.Len(t, annotated, 2)
+
+	main := annotated[0]
+	assert.True(t, main.Commentable)
+	require.Len(t, main.Hunks, 1)
+	lines := main.Hunks[0].Lines
+	require.Len(t, lines, 3)
+
+	assert.Equal(t, KindContext, lines[0].Type)
+	assert.Equal(t, SideRight, lines[0].Side)
+	assert.Equal(t, 1, *lines[0].OldLine)
+	assert.Equal(t, 1, *lines[0].NewLine)
+
+	assert.Equal(t, SideLeft, lines[1].Side)
+	assert.Equal(t, 2, *lines[1].OldLine)
+	assert.Nil(t, lines[1].NewLine)
+
+	assert.Equal(t, SideRight, lines[2].Side)
+	assert.Nil(t, lines[2].OldLine)
+	assert.Equal(t, 2, *lines[2].NewLine)
+
+	assert.False(t, annotated[1].Commentable)
+	assert.Empty(t, annotated[1].Hunks)
+}
+
+func TestFilterPaths(t *testing.T) {
+	files := []File{
+		{Path: "cmd/root.go"},
+		{Path: "internal/diff/diff.go"},
+		{Path: "docs/USAGE.md"},
+		{Path: "docs/new.md", PreviousPath: "docs/old.txt"},
+	}
+
+	paths := func(files []File) []string {
+		var out []string
+		for _, f := range files {
+			out = append(out, f.Path)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"cmd/root.go", "internal/diff/diff.go"}, paths(FilterPaths(files, []string{"*.go"})))
+	assert.Equal(t, []string{"cmd/root.go"}, paths(FilterPaths(files, []string{"cmd/*"})))
+	assert.Equal(t, []string{"docs/USAGE.md", "docs/new.md"}, paths(FilterPaths(files, []string{"docs/"})))
+	assert.Equal(t, []string{"docs/new.md"}, paths(FilterPaths(files, []string{"*.txt"})))
+	assert.Len(t, FilterPaths(files, nil), 4)
+}
+
+func TestWriteText(t *testing.T) {
+	annotated := Annotate([]File{
+		{Path: "main.go", Status: "modified", Hunks: ParsePatch("@@ -1,2 +1,2 @@\n a\n-b\n+B")},
+		{Path: "logo.png", PreviousPath: "old.png", Status: "renamed"},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteText(&buf, annotated))
+	assert.Equal(t, "=== main.go (modified)\n"+
+		"@@ -1,2 +1,2 @@\n"+
+		"    1     1 RIGHT  a\n"+
+		"    2       LEFT  -b\n"+
+		"          2 RIGHT +B\n"+
+		"\n"+
+		"=== old.png → logo.png (renamed)\n"+
+		"    (no textual diff available)\n", buf.String())
+}
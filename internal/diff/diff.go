@@ -0,0 +1,162 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Diff sides as used by GitHub review comments.
+const (
+	SideLeft  = "LEFT"
+	SideRight = "RIGHT"
+)
+
+// LineKind classifies a line within a hunk.
+type LineKind string
+
+// Line kinds appearing in unified diffs.
+const (
+	KindContext LineKind = "context"
+	KindAdd     LineKind = "add"
+	KindDelete  LineKind = "delete"
+)
+
+// Line is a single line of a hunk. OldLine is zero for added lines and
+// NewLine is zero for deleted lines.
+type Line struct {
+	Kind    LineKind
+	OldLine int
+	NewLine int
+	Content string
+}
+
+// Hunk is one "@@" section of a patch.
+type Hunk struct {
+	Header   string
+	OldStart int
+	NewStart int
+	Lines    []Line
+}
+
+// Range is an inclusive span of line numbers.
+type Range struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Contains reports whether line falls inside the range.
+func (r Range) Contains(line int) bool {
+	return line >= r.Start && line <= r.End
+}
+
+func (r Range) String() string {
+	if r.Start == r.End {
+		return strconv.Itoa(r.Start)
+	}
+	return strconv.Itoa(r.Start) + "-" + strconv.Itoa(r.End)
+}
+
+// ParsePatch splits a unified diff patch, as returned in the `patch` field of
+// the pull request files API, into hunks with per-line numbering.
+func ParsePatch(patch string) []Hunk {
+	var hunks []Hunk
+	var current *Hunk
+	var oldLine, newLine int
+
+	for _, raw := range strings.Split(patch, "\n") {
+		raw = strings.TrimSuffix(raw, "\r")
+		if strings.HasPrefix(raw, "@@") {
+			oldStart, newStart := ParseHunkHeader(raw)
+			hunks = append(hunks, Hunk{Header: raw, OldStart: oldStart, NewStart: newStart})
+			current = &hunks[len(hunks)-1]
+			oldLine, newLine = oldStart, newStart
+			continue
+		}
+		if current == nil || raw == "" || strings.HasPrefix(raw, "\\") {
+			continue
+		}
+
+		switch raw[0] {
+		case '+':
+			current.Lines = append(current.Lines, Line{Kind: KindAdd, NewLine: newLine, Content: raw[1:]})
+			newLine++
+		case '-':
+			current.Lines = append(current.Lines, Line{Kind: KindDelete, OldLine: oldLine, Content: raw[1:]})
+			oldLine++
+		case ' ':
+			current.Lines = append(current.Lines, Line{Kind: KindContext, OldLine: oldLine, NewLine: newLine, Content: raw[1:]})
+			oldLine++
+			newLine++
+		}
+	}
+
+	return hunks
+}
+
+// ParseHunkHeader extracts the starting line numbers from a hunk header.
+// Handles formats like "@@ -1,5 +1,6 @@" or "@@ -1 +1 @@"
+func ParseHunkHeader(header string) (oldStart, newStart int) {
+	// Remove the @@ markers
+	content := strings.TrimSpace(strings.TrimPrefix(strings.TrimSuffix(header, "@@"), "@@"))
+
+	// Parse the old file info
+	oldIdx := strings.Index(content, "-")
+	if oldIdx == -1 {
+		return 0, 0
+	}
+	content = content[oldIdx+1:]
+
+	// Find the comma or space for old file
+	oldEnd := strings.IndexAny(content, ", ")
+	if oldEnd == -1 {
+		return 0, 0
+	}
+	oldStart, _ = strconv.Atoi(content[:oldEnd])
+
+	// Parse the new file info
+	newIdx := strings.Index(content, "+")
+	if newIdx == -1 {
+		return 0, 0
+	}
+	content = content[newIdx+1:]
+
+	// Find the comma or space for new file
+	newEnd := strings.IndexAny(content, ", ")
+	if newEnd == -1 {
+		newStart, _ = strconv.Atoi(content)
+	} else {
+		newStart, _ = strconv.Atoi(content[:newEnd])
+	}
+
+	return oldStart, newStart
+}
+
+// Number returns the line number of l on side, or zero when the line does not
+// exist on that side (added lines on LEFT, deleted lines on RIGHT).
+func (l Line) Number(side string) int {
+	if side == SideLeft {
+		return l.OldLine
+	}
+	return l.NewLine
+}
+
+// Range returns the lines of h that can be commented on from side. Context
+// lines are commentable from both sides.
+func (h Hunk) Range(side string) (Range, bool) {
+	var r Range
+	found := false
+	for _, line := range h.Lines {
+		n := line.Number(side)
+		if n == 0 {
+			continue
+		}
+		if !found || n < r.Start {
+			r.Start = n
+		}
+		if !found || n > r.End {
+			r.End = n
+		}
+		found = true
+	}
+	return r, found
+}
@@ -0,0 +1,163 @@
+package diff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+const samplePatch = "@@ -1,4 +1,5 @@ func main() {\n" +
+	" a\n" +
+	"-b\n" +
+	"+B\n" +
+	"+C\n" +
+	" d\n" +
+	" e\n" +
+	"@@ -20,2 +21,3 @@\n" +
+	" x\n" +
+	"+y\n" +
+	" z\n" +
+	"\\ No newline at end of file"
+
+func TestParsePatch(t *testing.T) {
+	hunks := ParsePatch(samplePatch)
+	require.Len(t, hunks, 2)
+
+	first := hunks[0]
+	assert.Equal(t, 1, first.OldStart)
+	assert.Equal(t, 1, first.NewStart)
+	assert.Equal(t, []Line{
+		{Kind: KindContext, OldLine: 1, NewLine: 1, Content: "a"},
+		{Kind: KindDelete, OldLine: 2, Content: "b"},
+		{Kind: KindAdd, NewLine: 2, Content: "B"},
+		{Kind: KindAdd, NewLine: 3, Content: "C"},
+		{Kind: KindContext, OldLine: 3, NewLine: 4, Content: "d"},
+		{Kind: KindContext, OldLine: 4, NewLine: 5, Content: "e"},
+	}, first.Lines)
+
+	r, ok := first.Range(SideLeft)
+	require.True(t, ok)
+	assert.Equal(t, Range{Start: 1, End: 4}, r)
+	r, ok = hunks[1].Range(SideRight)
+	require.True(t, ok)
+	assert.Equal(t, Range{Start: 21, End: 23}, r)
+}
+
+func TestParseHunkHeader(t *testing.T) {
+	oldStart, newStart := ParseHunkHeader("@@ -224,6 +230,112 @@ func foo()")
+	assert.Equal(t, 224, oldStart)
+	assert.Equal(t, 230, newStart)
+
+	oldStart, newStart = ParseHunkHeader("@@ -1 +1 @@")
+	assert.Equal(t, 1, oldStart)
+	assert.Equal(t, 1, newStart)
+}
+
+func TestIndexCheck(t *testing.T) {
+	idx := NewIndex([]File{{Path: "main.go", Patch: samplePatch}, {Path: "logo.png"}})
+
+	require.NoError(t, idx.Check(Anchor{Path: "main.go", Line: 3, Side: SideRight}))
+	require.NoError(t, idx.Check(Anchor{Path: "main.go", Line: 2, Side: SideLeft}))
+	require.NoError(t, idx.Check(Anchor{Path: "main.go", Line: 5, Side: SideRight, StartLine: 2, StartSide: SideLeft}))
+	require.NoError(t, idx.Check(Anchor{Path: "logo.png", Line: 1, Side: SideRight}))
+
+	err := idx.Check(Anchor{Path: "main.go", Line: 10, Side: SideRight})
+	require.Error(t, err)
+	assert.Equal(t, "line 10 (RIGHT) of main.go is not part of the diff; commentable RIGHT ranges: 1-5, 21-23", err.Error())
+
+	err = idx.Check(Anchor{Path: "main.go", Line: 22, Side: SideRight, StartLine: 4})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be in the same hunk as line 22")
+
+	err = idx.Check(Anchor{Path: "other.go", Line: 1, Side: SideRight})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "other.go is not changed")
+}
+
+func TestIndexSnap(t *testing.T) {
+	idx := NewIndex([]File{{Path: "main.go", Patch: samplePatch}})
+
+	snapped, err := idx.Snap(Anchor{Path: "main.go", Line: 12, Side: SideRight})
+	require.NoError(t, err)
+	assert.Equal(t, 5, snapped.Line)
+
+	snapped, err = idx.Snap(Anchor{Path: "main.go", Line: 30, Side: SideRight, StartLine: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 23, snapped.Line)
+	assert.Equal(t, 21, snapped.StartLine)
+
+	snapped, err = idx.Snap(Anchor{Path: "main.go", Line: 21, Side: SideRight, StartLine: 25})
+	require.NoError(t, err)
+	assert.Equal(t, 21, snapped.Line)
+	assert.Zero(t, snapped.StartLine)
+}
+
+type pagedAPI struct {
+	pages map[string][]map[string]string
+	calls []map[string]string
+}
+
+func (f *pagedAPI) REST(_ context.Context, method, path string, params map[string]string, _ interface{}, result interface{}) error {
+	if method != "GET" || path != "repos/octo/demo/pulls/7/files" {
+		return errors.New("unexpected request " + method + " " + path)
+	}
+	f.calls = append(f.calls, params)
+	page := f.pages[params["page"]]
+	if page == nil {
+		page = []map[string]string{}
+	}
+	data, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (f *pagedAPI) GraphQL(context.Context, string, map[string]interface{}, interface{}) error {
+	return errors.New("unexpected graphql call")
+}
+
+func TestServiceFilesPaginates(t *testing.T) {
+	first := make([]map[string]string, filesPerPage)
+	for i := range first {
+		first[i] = map[string]string{"filename": "f" + strconv.Itoa(i) + ".go", "status": "modified", "patch": "@@ -1 +1 @@\n-a\n+b"}
+	}
+	api := &pagedAPI{pages: map[string][]map[string]string{
+		"1": first,
+		"2": {{"filename": "new.go", "previous_filename": "old.go", "status": "renamed"}},
+	}}
+
+	files, err := NewService(api).Files(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 7})
+	require.NoError(t, err)
+	require.Len(t, files, filesPerPage+1)
+	assert.Len(t, api.calls, 2)
+	assert.Equal(t, "100", api.calls[0]["per_page"])
+	assert.Len(t, files[0].Hunks, 1)
+
+	last := files[len(files)-1]
+	assert.Equal(t, File{Path: "new.go", PreviousPath: "old.go", Status: "renamed"}, last)
+}
+
+func TestIndexContent(t *testing.T) {
+	idx := NewIndex([]File{{Path: "main.go", Patch: samplePatch}})
+
+	lines, ok := idx.Content("main.go", SideRight, 2, 4)
+	require.True(t, ok)
+	assert.Equal(t, []string{"B", "C", "d"}, lines)
+
+	lines, ok = idx.Content("main.go", SideLeft, 2, 2)
+	require.True(t, ok)
+	assert.Equal(t, []string{"b"}, lines)
+
+	_, ok = idx.Content("main.go", SideRight, 5, 21)
+	assert.False(t, ok)
+	_, ok = idx.Content("other.go", SideRight, 1, 1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,222 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// File is a changed file in a pull request.
+type File struct {
+	Path         string
+	PreviousPath string
+	Status       string
+	// Patch is empty for binary files and for files whose diff GitHub
+	// considers too large to return.
+	Patch string
+	Hunks []Hunk
+}
+
+// Ranges lists the commentable line spans on side, one per hunk.
+func (f *File) Ranges(side string) []Range {
+	ranges := make([]Range, 0, len(f.Hunks))
+	for _, hunk := range f.Hunks {
+		if r, ok := hunk.Range(side); ok {
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// hunkFor returns the index of the hunk that makes line commentable on side, or -1.
+func (f *File) hunkFor(side string, line int) int {
+	for i, hunk := range f.Hunks {
+		if r, ok := hunk.Range(side); ok && r.Contains(line) {
+			return i
+		}
+	}
+	return -1
+}
+
+// nearest returns the commentable line on side closest to line, preferring the
+// earlier line on ties.
+func (f *File) nearest(side string, line int) (int, bool) {
+	best, bestDistance, found := 0, 0, false
+	for _, r := range f.Ranges(side) {
+		candidate := line
+		switch {
+		case line < r.Start:
+			candidate = r.Start
+		case line > r.End:
+			candidate = r.End
+		}
+		distance := candidate - line
+		if distance < 0 {
+			distance = -distance
+		}
+		if !found || distance < bestDistance {
+			best, bestDistance, found = candidate, distance, true
+		}
+	}
+	return best, found
+}
+
+// Index maps file paths of a pull request to their parsed diffs.
+type Index struct {
+	files map[string]*File
+}
+
+// NewIndex builds an Index from files, parsing each patch.
+func NewIndex(files []File) *Index {
+	idx := &Index{files: make(map[string]*File, len(files))}
+	for i := range files {
+		file := files[i]
+		if file.Hunks == nil && file.Patch != "" {
+			file.Hunks = ParsePatch(file.Patch)
+		}
+		idx.files[file.Path] = &file
+	}
+	return idx
+}
+
+// File returns the diff for path.
+func (idx *Index) File(path string) (*File, bool) {
+	file, ok := idx.files[path]
+	return file, ok
+}
+
+// Content returns the text of lines start..end on side of path as shown in the
+// diff. It reports false unless every line in the range is part of one hunk.
+func (idx *Index) Content(path, side string, start, end int) ([]string, bool) {
+	file, ok := idx.files[path]
+	if !ok || start <= 0 || end < start {
+		return nil, false
+	}
+	hunk := file.hunkFor(side, end)
+	if hunk < 0 {
+		return nil, false
+	}
+	lines := make([]string, 0, end-start+1)
+	for _, line := range file.Hunks[hunk].Lines {
+		if n := line.Number(side); n >= start && n <= end {
+			lines = append(lines, line.Content)
+		}
+	}
+	if len(lines) != end-start+1 {
+		return nil, false
+	}
+	return lines, true
+}
+
+// Anchor locates an inline review comment.
+type Anchor struct {
+	Path      string
+	Line      int
+	Side      string
+	StartLine int
+	StartSide string
+}
+
+func (a Anchor) startSide() string {
+	if a.StartSide != "" {
+		return a.StartSide
+	}
+	return a.Side
+}
+
+// Check verifies that anchor points at lines GitHub accepts for inline
+// comments: the path must be part of the pull request, line must fall inside a
+// hunk on side, and start_line, when set, must fall inside the same hunk.
+// Files without a textual patch cannot be verified and are accepted.
+func (idx *Index) Check(anchor Anchor) error {
+	file, err := idx.lookup(anchor.Path)
+	if err != nil {
+		return err
+	}
+	if file.Patch == "" {
+		return nil
+	}
+
+	hunk := file.hunkFor(anchor.Side, anchor.Line)
+	if hunk < 0 {
+		return rangeError(file, anchor.Side, anchor.Line)
+	}
+
+	if anchor.StartLine > 0 {
+		startSide := anchor.startSide()
+		r, ok := file.Hunks[hunk].Range(startSide)
+		if !ok || !r.Contains(anchor.StartLine) {
+			return fmt.Errorf("start line %d (%s) of %s must be in the same hunk as line %d; commentable %s range for that hunk: %s",
+				anchor.StartLine, startSide, file.Path, anchor.Line, startSide, describeRange(r, ok))
+		}
+	}
+	return nil
+}
+
+// CheckFile verifies that path is part of the pull request, which is all a
+// file-level comment requires.
+func (idx *Index) CheckFile(path string) error {
+	_, err := idx.lookup(path)
+	return err
+}
+
+// Snap moves line and start_line to the nearest commentable lines, keeping
+// start_line within the hunk chosen for line. It fails only when the path is
+// not part of the pull request or has no commentable lines on side.
+func (idx *Index) Snap(anchor Anchor) (Anchor, error) {
+	file, err := idx.lookup(anchor.Path)
+	if err != nil {
+		return anchor, err
+	}
+	if file.Patch == "" {
+		return anchor, nil
+	}
+
+	line, ok := file.nearest(anchor.Side, anchor.Line)
+	if !ok {
+		return anchor, fmt.Errorf("%s has no commentable %s lines", file.Path, anchor.Side)
+	}
+	anchor.Line = line
+
+	if anchor.StartLine > 0 {
+		startSide := anchor.startSide()
+		r, ok := file.Hunks[file.hunkFor(anchor.Side, line)].Range(startSide)
+		switch {
+		case !ok:
+			anchor.StartLine, anchor.StartSide = 0, ""
+		case anchor.StartLine < r.Start:
+			anchor.StartLine = r.Start
+		case anchor.StartLine > r.End:
+			anchor.StartLine = r.End
+		}
+		if anchor.StartLine > 0 && startSide == anchor.Side && anchor.StartLine >= anchor.Line {
+			anchor.StartLine, anchor.StartSide = 0, ""
+		}
+	}
+	return anchor, nil
+}
+
+func (idx *Index) lookup(path string) (*File, error) {
+	if file, ok := idx.files[path]; ok {
+		return file, nil
+	}
+	return nil, fmt.Errorf("%s is not changed in this pull request", path)
+}
+
+func rangeError(file *File, side string, line int) error {
+	ranges := file.Ranges(side)
+	if len(ranges) == 0 {
+		return fmt.Errorf("line %d (%s) of %s is not part of the diff; the file has no commentable %s lines", line, side, file.Path, side)
+	}
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = r.String()
+	}
+	return fmt.Errorf("line %d (%s) of %s is not part of the diff; commentable %s ranges: %s", line, side, file.Path, side, strings.Join(parts, ", "))
+}
+
+func describeRange(r Range, ok bool) string {
+	if !ok {
+		return "none"
+	}
+	return r.String()
+}
@@ -0,0 +1,75 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// filesPerPage is the largest page size the pull request files API accepts.
+const filesPerPage = 100
+
+// Service fetches pull request diffs through the REST API.
+type Service struct {
+	API ghcli.API
+}
+
+// NewService constructs a diff Service.
+func NewService(api ghcli.API) *Service {
+	return &Service{API: api}
+}
+
+// Files lists every changed file of the pull request with its parsed hunks,
+// following pagination (GitHub caps the listing at 3000 files).
+func (s *Service) Files(ctx context.Context, pr resolver.Identity) ([]File, error) {
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/files", pr.Owner, pr.Repo, pr.Number)
+
+	var files []File
+	for page := 1; ; page++ {
+		var chunk []struct {
+			Filename         string `json:"filename"`
+			PreviousFilename string `json:"previous_filename"`
+			Status           string `json:"status"`
+			Patch            string `json:"patch"`
+		}
+		params := map[string]string{
+			"per_page": strconv.Itoa(filesPerPage),
+			"page":     strconv.Itoa(page),
+		}
+		if err := s.API.REST(ctx, "GET", path, params, nil, &chunk); err != nil {
+			return nil, fmt.Errorf("list pull request files: %w", err)
+		}
+
+		for _, f := range chunk {
+			file := File{
+				Path:         strings.TrimSpace(f.Filename),
+				PreviousPath: strings.TrimSpace(f.PreviousFilename),
+				Status:       strings.TrimSpace(f.Status),
+				Patch:        f.Patch,
+			}
+			if file.Patch != "" {
+				file.Hunks = ParsePatch(file.Patch)
+			}
+			files = append(files, file)
+		}
+
+		if len(chunk) < filesPerPage {
+			break
+		}
+	}
+
+	return files, nil
+}
+
+// Index fetches the pull request files and indexes them by path.
+func (s *Service) Index(ctx context.Context, pr resolver.Identity) (*Index, error) {
+	files, err := s.Files(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	return NewIndex(files), nil
+}
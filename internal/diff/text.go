@@ -0,0 +1,57 @@
+package diff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteText renders annotated files as a human-readable diff. Each line is
+// prefixed with its old and new line numbers and the side to pass to
+// `review add-comment --side`.
+func WriteText(w io.Writer, files []AnnotatedFile) error {
+	out := bufio.NewWriter(w)
+	for i, file := range files {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		header := file.Path
+		if file.PreviousPath != "" && file.PreviousPath != file.Path {
+			header = file.PreviousPath + " → " + file.Path
+		}
+		if file.Status != "" {
+			header += " (" + file.Status + ")"
+		}
+		fmt.Fprintf(out, "=== %s\n", header)
+		if !file.Commentable {
+			fmt.Fprintln(out, "    (no textual diff available)")
+			continue
+		}
+		for _, hunk := range file.Hunks {
+			fmt.Fprintln(out, hunk.Header)
+			for _, line := range hunk.Lines {
+				fmt.Fprintf(out, "%5s %5s %-5s %s%s\n", lineNumber(line.OldLine), lineNumber(line.NewLine), line.Side, marker(line.Type), line.Content)
+			}
+		}
+	}
+	return out.Flush()
+}
+
+func lineNumber(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func marker(kind LineKind) string {
+	switch kind {
+	case KindAdd:
+		return "+"
+	case KindDelete:
+		return "-"
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,44 @@
+package findings
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+type checkstyleReport struct {
+	Files []struct {
+		Name   string `xml:"name,attr"`
+		Errors []struct {
+			Line     int    `xml:"line,attr"`
+			Severity string `xml:"severity,attr"`
+			Message  string `xml:"message,attr"`
+			Source   string `xml:"source,attr"`
+		} `xml:"error"`
+	} `xml:"file"`
+}
+
+// parseCheckstyle reads checkstyle XML as emitted by golangci-lint, eslint, and
+// most other linters' checkstyle formatters. The source attribute is the rule.
+func parseCheckstyle(data []byte) ([]Finding, error) {
+	var report checkstyleReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parse checkstyle: %w", err)
+	}
+
+	var findings []Finding
+	for _, file := range report.Files {
+		for _, entry := range file.Errors {
+			if entry.Severity == "ignore" {
+				continue
+			}
+			findings = append(findings, Finding{
+				Path:     file.Name,
+				Line:     entry.Line,
+				Severity: ParseSeverity(entry.Severity),
+				Rule:     entry.Source,
+				Message:  entry.Message,
+			})
+		}
+	}
+	return findings, nil
+}
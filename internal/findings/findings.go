@@ -0,0 +1,199 @@
+// Package findings converts static-analysis reports (SARIF, checkstyle XML,
+// reviewdog rdjsonl) into inline review comments.
+package findings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/review"
+)
+
+// Format names a supported report format.
+type Format string
+
+const (
+	FormatSARIF      Format = "sarif"
+	FormatCheckstyle Format = "checkstyle"
+	FormatRDJSONL    Format = "rdjsonl"
+)
+
+// Severity is the normalized level of a finding.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Finding is one diagnostic reported by a tool, anchored to lines of the new
+// version of a file.
+type Finding struct {
+	Path     string   `json:"path"`
+	Line     int      `json:"line"`
+	EndLine  int      `json:"end_line,omitempty"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule,omitempty"`
+	Tool     string   `json:"tool,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// ParseFormat validates a user-supplied format name.
+func ParseFormat(value string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(value))); f {
+	case FormatSARIF, FormatCheckstyle, FormatRDJSONL:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid format %q: must be sarif, checkstyle, or rdjsonl", value)
+	}
+}
+
+// DetectFormat guesses the format from the file name, falling back to the
+// content: XML is checkstyle, a JSON document with "runs" is SARIF, and
+// anything else is treated as rdjsonl.
+func DetectFormat(name string, data []byte) Format {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".sarif"), strings.HasSuffix(lower, ".sarif.json"):
+		return FormatSARIF
+	case strings.HasSuffix(lower, ".xml"):
+		return FormatCheckstyle
+	case strings.HasSuffix(lower, ".jsonl"), strings.HasSuffix(lower, ".rdjsonl"):
+		return FormatRDJSONL
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		return FormatCheckstyle
+	}
+	var doc struct {
+		Runs json.RawMessage `json:"runs"`
+	}
+	if json.Unmarshal(trimmed, &doc) == nil && len(doc.Runs) > 0 {
+		return FormatSARIF
+	}
+	return FormatRDJSONL
+}
+
+// Parse decodes a report in the given format. Findings without a path or line
+// cannot become inline comments and are dropped.
+func Parse(format Format, data []byte) ([]Finding, error) {
+	var (
+		parsed []Finding
+		err    error
+	)
+	switch format {
+	case FormatSARIF:
+		parsed, err = parseSARIF(data)
+	case FormatCheckstyle:
+		parsed, err = parseCheckstyle(data)
+	case FormatRDJSONL:
+		parsed, err = parseRDJSONL(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]Finding, 0, len(parsed))
+	for _, finding := range parsed {
+		finding.Path = cleanPath(finding.Path)
+		finding.Message = strings.TrimSpace(finding.Message)
+		if finding.Path == "" || finding.Line <= 0 || finding.Message == "" {
+			continue
+		}
+		if finding.EndLine <= finding.Line {
+			finding.EndLine = 0
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// Relativize rewrites absolute paths under root to be relative to it, which is
+// how pull request files are named. Paths outside root are left unchanged.
+func Relativize(findings []Finding, root string) {
+	if root == "" {
+		return
+	}
+	root = filepath.ToSlash(filepath.Clean(root))
+	for i := range findings {
+		p := findings[i].Path
+		if !strings.HasPrefix(p, "/") {
+			continue
+		}
+		if rel := strings.TrimPrefix(p, root+"/"); rel != p {
+			findings[i].Path = rel
+		}
+	}
+}
+
+func cleanPath(p string) string {
+	p = strings.TrimSpace(p)
+	p = strings.TrimPrefix(p, "file://")
+	p = filepath.ToSlash(p)
+	if p == "" {
+		return ""
+	}
+	p = path.Clean(p)
+	return strings.TrimPrefix(p, "./")
+}
+
+// ParseSeverity maps tool-specific level names onto error, warning, or note.
+func ParseSeverity(level string) Severity {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "error", "fatal", "critical", "high":
+		return SeverityError
+	case "warning", "warn", "medium":
+		return SeverityWarning
+	default:
+		return SeverityNote
+	}
+}
+
+// Body renders the comment text posted for the finding.
+func (f Finding) Body() string {
+	header := "**" + string(f.Severity) + "**"
+	if f.Rule != "" {
+		header += " `" + f.Rule + "`"
+	}
+	if f.Tool != "" {
+		header += " (" + f.Tool + ")"
+	}
+	return header + "\n\n" + f.Message
+}
+
+// ThreadInput converts the finding into an inline comment on the RIGHT side.
+// Findings spanning several lines become multi-line comments.
+func (f Finding) ThreadInput(reviewID string) review.ThreadInput {
+	input := review.ThreadInput{
+		ReviewID: reviewID,
+		Path:     f.Path,
+		Line:     f.Line,
+		Side:     "RIGHT",
+		Body:     f.Body(),
+	}
+	if f.EndLine > f.Line {
+		startLine := f.Line
+		input.Line = f.EndLine
+		input.StartLine = &startLine
+	}
+	return input
+}
+
+// Sort orders findings by path and line so comments are posted in file order.
+func Sort(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Line < findings[j].Line
+	})
+}
@@ -0,0 +1,77 @@
+package findings
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	//go:embed testdata/semgrep.sarif
+	sampleSARIF []byte
+	//go:embed testdata/eslint.xml
+	sampleCheckstyle []byte
+	//go:embed testdata/golangci.rdjsonl
+	sampleRDJSONL []byte
+)
+
+func TestParseSARIF(t *testing.T) {
+	findings, err := Parse(FormatSARIF, sampleSARIF)
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Path: "internal/store/query.go", Line: 12, EndLine: 14, Severity: SeverityError, Rule: "go.lang.security.audit.sqli", Tool: "semgrep", Message: "Query built with string concatenation."},
+		{Path: "/work/repo/cmd/main.go", Line: 3, Severity: SeverityWarning, Rule: "go.lang.style.naming", Tool: "semgrep", Message: "Rename this identifier"},
+	}, findings)
+}
+
+func TestParseCheckstyle(t *testing.T) {
+	findings, err := Parse(FormatCheckstyle, sampleCheckstyle)
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Path: "/work/repo/web/app.js", Line: 7, Severity: SeverityError, Rule: "eslint.rules.no-unused-vars", Message: "'x' is assigned a value but never used."},
+		{Path: "/work/repo/web/app.js", Line: 9, Severity: SeverityNote, Rule: "eslint.rules.prefer-const", Message: "Prefer const."},
+	}, findings)
+}
+
+func TestParseRDJSONL(t *testing.T) {
+	findings, err := Parse(FormatRDJSONL, sampleRDJSONL)
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{Path: "internal/store/query.go", Line: 20, Severity: SeverityWarning, Rule: "errcheck", Tool: "golangci-lint", Message: "Error return value is not checked"},
+		{Path: "internal/store/query.go", Line: 30, EndLine: 32, Severity: SeverityNote, Rule: "unparam", Tool: "golangci-lint", Message: "unused parameter"},
+	}, findings)
+
+	_, err = Parse(FormatRDJSONL, []byte("{\"message\":\"ok\"}\nnot json\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, FormatSARIF, DetectFormat("results.sarif", nil))
+	assert.Equal(t, FormatCheckstyle, DetectFormat("lint.xml", nil))
+	assert.Equal(t, FormatRDJSONL, DetectFormat("lint.jsonl", nil))
+	assert.Equal(t, FormatSARIF, DetectFormat("-", sampleSARIF))
+	assert.Equal(t, FormatCheckstyle, DetectFormat("-", sampleCheckstyle))
+	assert.Equal(t, FormatRDJSONL, DetectFormat("-", sampleRDJSONL))
+}
+
+func TestRelativize(t *testing.T) {
+	findings := []Finding{{Path: "/work/repo/web/app.js"}, {Path: "/elsewhere/x.go"}, {Path: "a.go"}}
+	Relativize(findings, "/work/repo/")
+	assert.Equal(t, "web/app.js", findings[0].Path)
+	assert.Equal(t, "/elsewhere/x.go", findings[1].Path)
+	assert.Equal(t, "a.go", findings[2].Path)
+}
+
+func TestFindingThreadInput(t *testing.T) {
+	finding := Finding{Path: "a.go", Line: 4, EndLine: 6, Severity: SeverityWarning, Rule: "errcheck", Tool: "golangci-lint", Message: "check it"}
+	input := finding.ThreadInput("PRR_1")
+	assert.Equal(t, "PRR_1", input.ReviewID)
+	assert.Equal(t, 6, input.Line)
+	require.NotNil(t, input.StartLine)
+	assert.Equal(t, 4, *input.StartLine)
+	assert.Equal(t, "RIGHT", input.Side)
+	assert.Equal(t, "**warning** `errcheck` (golangci-lint)\n\ncheck it", input.Body)
+}
@@ -0,0 +1,62 @@
+package findings
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type rdDiagnostic struct {
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+	Location struct {
+		Path  string `json:"path"`
+		Range struct {
+			Start struct {
+				Line int `json:"line"`
+			} `json:"start"`
+			End struct {
+				Line int `json:"line"`
+			} `json:"end"`
+		} `json:"range"`
+	} `json:"location"`
+	Source struct {
+		Name string `json:"name"`
+	} `json:"source"`
+	Code struct {
+		Value string `json:"value"`
+	} `json:"code"`
+}
+
+// parseRDJSONL reads reviewdog's rdjsonl format: one Diagnostic per line.
+func parseRDJSONL(data []byte) ([]Finding, error) {
+	var findings []Finding
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var diagnostic rdDiagnostic
+		if err := json.Unmarshal(line, &diagnostic); err != nil {
+			return nil, fmt.Errorf("parse rdjsonl line %d: %w", lineNo, err)
+		}
+		findings = append(findings, Finding{
+			Path:     diagnostic.Location.Path,
+			Line:     diagnostic.Location.Range.Start.Line,
+			EndLine:  diagnostic.Location.Range.End.Line,
+			Severity: ParseSeverity(diagnostic.Severity),
+			Rule:     diagnostic.Code.Value,
+			Tool:     diagnostic.Source.Name,
+			Message:  diagnostic.Message,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parse rdjsonl: %w", err)
+	}
+	return findings, nil
+}
@@ -0,0 +1,102 @@
+package findings
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type sarifLog struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Name  string      `json:"name"`
+				Rules []sarifRule `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex *int   `json:"ruleIndex"`
+			Level     string `json:"level"`
+			Message   struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int `json:"startLine"`
+						EndLine   int `json:"endLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+type sarifRule struct {
+	ID                   string `json:"id"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+	ShortDescription struct {
+		Text string `json:"text"`
+	} `json:"shortDescription"`
+}
+
+// parseSARIF reads SARIF 2.1 logs. A result's level falls back to its rule's
+// default configuration and then to "warning", as the specification requires.
+func parseSARIF(data []byte) ([]Finding, error) {
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("parse SARIF: %w", err)
+	}
+
+	var findings []Finding
+	for _, run := range log.Runs {
+		rules := run.Tool.Driver.Rules
+		byID := make(map[string]sarifRule, len(rules))
+		for _, rule := range rules {
+			byID[rule.ID] = rule
+		}
+
+		for _, result := range run.Results {
+			if len(result.Locations) == 0 {
+				continue
+			}
+			rule, ok := byID[result.RuleID]
+			if !ok && result.RuleIndex != nil && *result.RuleIndex >= 0 && *result.RuleIndex < len(rules) {
+				rule = rules[*result.RuleIndex]
+			}
+
+			level := result.Level
+			if level == "" {
+				level = rule.DefaultConfiguration.Level
+			}
+			if level == "" {
+				level = "warning"
+			}
+			message := result.Message.Text
+			if message == "" {
+				message = rule.ShortDescription.Text
+			}
+			ruleID := result.RuleID
+			if ruleID == "" {
+				ruleID = rule.ID
+			}
+
+			location := result.Locations[0].PhysicalLocation
+			findings = append(findings, Finding{
+				Path:     location.ArtifactLocation.URI,
+				Line:     location.Region.StartLine,
+				EndLine:  location.Region.EndLine,
+				Severity: ParseSeverity(level),
+				Rule:     ruleID,
+				Tool:     run.Tool.Driver.Name,
+				Message:  message,
+			})
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,260 @@
+package findings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/diff"
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/report"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/review"
+)
+
+// Service posts findings as review comments.
+type Service struct {
+	API ghcli.API
+}
+
+// NewService constructs a findings service.
+func NewService(api ghcli.API) *Service {
+	return &Service{API: api}
+}
+
+// Per-finding outcomes reported by Import.
+const (
+	ImportStatusCreated     = "created"
+	ImportStatusOutsideDiff = "outside_diff"
+	ImportStatusDuplicate   = "duplicate"
+	ImportStatusFailed      = "failed"
+)
+
+// DefaultEvents maps severities to the review event used when submitting.
+var DefaultEvents = map[Severity]string{
+	SeverityError:   "REQUEST_CHANGES",
+	SeverityWarning: "COMMENT",
+	SeverityNote:    "COMMENT",
+}
+
+// eventRank orders events so the strongest one among the imported findings wins.
+var eventRank = map[string]int{"APPROVE": 1, "COMMENT": 2, "REQUEST_CHANGES": 3}
+
+// ImportOptions controls where findings are posted.
+type ImportOptions struct {
+	// ReviewID is the pending review to add comments to. When empty the
+	// viewer's pending review is used, or a new one is opened.
+	ReviewID string
+	// Submit submits the review with the strongest event mapped from the
+	// severities of the created comments.
+	Submit bool
+	// Body is the review body used on submission.
+	Body string
+	// Events maps severities to review events; missing entries fall back to
+	// DefaultEvents.
+	Events map[Severity]string
+}
+
+// ImportCommentResult reports what happened to one finding.
+type ImportCommentResult struct {
+	Path     string   `json:"path"`
+	Line     int      `json:"line"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule,omitempty"`
+	Status   string   `json:"status"`
+	ThreadID string   `json:"thread_id,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// ImportResult summarizes an import run.
+type ImportResult struct {
+	ReviewID    string                `json:"review_id,omitempty"`
+	Event       string                `json:"event,omitempty"`
+	Submitted   bool                  `json:"submitted"`
+	SubmitError string                `json:"submit_error,omitempty"`
+	Comments    []ImportCommentResult `json:"comments"`
+}
+
+// Failed reports whether any comment or the submission failed.
+func (r *ImportResult) Failed() bool {
+	if r.SubmitError != "" {
+		return true
+	}
+	for _, comment := range r.Comments {
+		if comment.Status == ImportStatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseEvents parses severity=EVENT pairs on top of DefaultEvents.
+func ParseEvents(pairs map[string]string) (map[Severity]string, error) {
+	events := make(map[Severity]string, len(DefaultEvents))
+	for severity, event := range DefaultEvents {
+		events[severity] = event
+	}
+	for key, value := range pairs {
+		severity := Severity(strings.ToLower(strings.TrimSpace(key)))
+		if _, ok := DefaultEvents[severity]; !ok {
+			return nil, fmt.Errorf("invalid severity %q: must be error, warning, or note", key)
+		}
+		event := strings.ToUpper(strings.TrimSpace(value))
+		if _, ok := eventRank[event]; !ok {
+			return nil, fmt.Errorf("invalid event %q for %s: must be APPROVE, COMMENT, or REQUEST_CHANGES", value, severity)
+		}
+		events[severity] = event
+	}
+	return events, nil
+}
+
+type plannedComment struct {
+	index int
+	input review.ThreadInput
+}
+
+// Import posts findings that fall inside the pull request diff and are not
+// already present as review threads. Nothing is created, and no review is
+// opened, when every finding is skipped.
+func (s *Service) Import(ctx context.Context, pr resolver.Identity, findings []Finding, opts ImportOptions) (*ImportResult, error) {
+	events := opts.Events
+	if events == nil {
+		events = DefaultEvents
+	}
+
+	index, err := diff.NewService(s.API).Index(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	// Include PENDING reviews: the default target is the viewer's pending
+	// review, so earlier imports that were not submitted yet live there.
+	existing, err := report.NewService(s.API).Fetch(ctx, pr, report.Options{
+		States: []report.State{
+			report.StateApproved,
+			report.StateChangesRequested,
+			report.StateCommented,
+			report.StateDismissed,
+			report.StatePending,
+		},
+		StatesProvided: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch existing threads: %w", err)
+	}
+	seen := make(map[string]struct{})
+	for _, rev := range existing.Reviews {
+		for _, comment := range rev.Comments {
+			if comment.Line != nil {
+				seen[commentKey(comment.Path, *comment.Line, comment.Body)] = struct{}{}
+			}
+		}
+	}
+
+	result := &ImportResult{Comments: make([]ImportCommentResult, len(findings))}
+	var planned []plannedComment
+	for i, finding := range findings {
+		entry := ImportCommentResult{Path: finding.Path, Line: finding.Line, Severity: finding.Severity, Rule: finding.Rule}
+		input, ok := fitFinding(index, finding)
+		switch {
+		case !ok:
+			entry.Status = ImportStatusOutsideDiff
+		default:
+			key := commentKey(input.Path, input.Line, input.Body)
+			if _, dup := seen[key]; dup {
+				entry.Status = ImportStatusDuplicate
+			} else {
+				seen[key] = struct{}{}
+				planned = append(planned, plannedComment{index: i, input: input})
+			}
+		}
+		result.Comments[i] = entry
+	}
+	if len(planned) == 0 {
+		return result, nil
+	}
+
+	reviews := review.NewService(s.API)
+	reviewID, err := s.pendingReview(ctx, reviews, pr, opts.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	result.ReviewID = reviewID
+
+	created := 0
+	for _, plan := range planned {
+		entry := &result.Comments[plan.index]
+		plan.input.ReviewID = reviewID
+		thread, err := reviews.AddThread(ctx, pr, plan.input)
+		if err != nil {
+			entry.Status = ImportStatusFailed
+			entry.Error = err.Error()
+			continue
+		}
+		entry.Status = ImportStatusCreated
+		entry.ThreadID = thread.ID
+		created++
+
+		if event := events[findings[plan.index].Severity]; eventRank[event] > eventRank[result.Event] {
+			result.Event = event
+		}
+	}
+
+	if opts.Submit && created > 0 && !result.Failed() {
+		status, err := reviews.Submit(ctx, pr, review.SubmitInput{ReviewID: reviewID, Event: result.Event, Body: opts.Body})
+		switch {
+		case err != nil:
+			result.SubmitError = err.Error()
+		case !status.Success:
+			result.SubmitError = "review submission failed"
+			if len(status.Errors) > 0 {
+				result.SubmitError = status.Errors[0].Message
+			}
+		default:
+			result.Submitted = true
+		}
+	}
+
+	return result, nil
+}
+
+// pendingReview returns the review to post into: the explicit id, the
+// viewer's existing pending review, or a newly opened one.
+func (s *Service) pendingReview(ctx context.Context, reviews *review.Service, pr resolver.Identity, reviewID string) (string, error) {
+	if reviewID != "" {
+		return reviewID, nil
+	}
+	pending, err := reviews.SolePending(ctx, pr)
+	if err == nil {
+		return pending.ID, nil
+	}
+	if !errors.Is(err, review.ErrNoPendingReview) {
+		return "", err
+	}
+	state, err := reviews.Start(ctx, pr, "")
+	if err != nil {
+		return "", fmt.Errorf("open pending review: %w", err)
+	}
+	return state.ID, nil
+}
+
+// fitFinding anchors a finding on the RIGHT side of the diff. Multi-line
+// findings that straddle hunks fall back to a comment on their first line.
+func fitFinding(index *diff.Index, finding Finding) (review.ThreadInput, bool) {
+	input := finding.ThreadInput("")
+	if input.StartLine != nil {
+		err := index.Check(diff.Anchor{Path: input.Path, Line: input.Line, Side: diff.SideRight, StartLine: *input.StartLine, StartSide: diff.SideRight})
+		if err == nil {
+			return input, true
+		}
+		input.Line, input.StartLine = finding.Line, nil
+	}
+	if err := index.Check(diff.Anchor{Path: input.Path, Line: input.Line, Side: diff.SideRight}); err != nil {
+		return input, false
+	}
+	return input, true
+}
+
+func commentKey(path string, line int, body string) string {
+	return fmt.Sprintf("%s:%d:%s", path, line, strings.TrimSpace(body))
+}
@@ -0,0 +1,197 @@
+package findings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+type obj = map[string]interface{}
+
+type routedAPI struct {
+	t         *testing.T
+	reviews   []obj
+	pending   []obj
+	threads   []obj
+	started   int
+	added     []map[string]interface{}
+	submitted map[string]interface{}
+}
+
+func assign(result interface{}, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (f *routedAPI) REST(_ context.Context, method, path string, params map[string]string, _ interface{}, result interface{}) error {
+	if method != "GET" || path != "repos/octo/demo/pulls/7/files" {
+		return errors.New("unexpected request " + method + " " + path)
+	}
+	if params["page"] != "1" {
+		return assign(result, []obj{})
+	}
+	return assign(result, []obj{
+		{"filename": "a.go", "status": "modified", "patch": "@@ -1,3 +1,5 @@\n a\n+b\n+c\n d\n+e"},
+	})
+}
+
+func (f *routedAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	switch {
+	case strings.Contains(query, "query Report("):
+		assert.Contains(f.t, variables["states"], "PENDING")
+		reviews := f.reviews
+		if reviews == nil {
+			reviews = []obj{{"id": "PRR_old", "state": "COMMENTED", "databaseId": 1, "author": obj{"login": "bot"}}}
+		}
+		return assign(result, obj{"repository": obj{"pullRequest": obj{
+			"reviews":       obj{"nodes": reviews},
+			"reviewThreads": obj{"nodes": f.threads},
+		}}})
+	case strings.Contains(query, "ViewerLogin"):
+		return assign(result, obj{"viewer": obj{"login": "bot"}})
+	case strings.Contains(query, "PendingReviews"):
+		return assign(result, obj{"repository": obj{"pullRequest": obj{"reviews": obj{
+			"nodes":    f.pending,
+			"pageInfo": obj{"hasNextPage": false},
+		}}}})
+	case strings.Contains(query, "headRefOid"):
+		return assign(result, obj{"repository": obj{"pullRequest": obj{"id": "PR_1", "headRefOid": "abc"}}})
+	case strings.Contains(query, "addPullRequestReview("):
+		f.started++
+		return assign(result, obj{"addPullRequestReview": obj{"pullRequestReview": obj{"id": "PRR_new", "state": "PENDING"}}})
+	case strings.Contains(query, "addPullRequestReviewThread"):
+		input := variables["input"].(map[string]interface{})
+		f.added = append(f.added, input)
+		return assign(result, obj{"addPullRequestReviewThread": obj{"thread": obj{"id": "PRRT_" + input["path"].(string), "path": input["path"], "line": input["line"]}}})
+	case strings.Contains(query, "submitPullRequestReview"):
+		f.submitted = variables["input"].(map[string]interface{})
+		return assign(result, obj{"submitPullRequestReview": obj{"pullRequestReview": obj{"id": f.submitted["pullRequestReviewId"]}}})
+	default:
+		f.t.Fatalf("unexpected query: %s", query)
+		return nil
+	}
+}
+
+var testPR = resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+
+func TestImportFiltersDedupesAndSubmits(t *testing.T) {
+	duplicate := Finding{Path: "a.go", Line: 2, Severity: SeverityNote, Rule: "old", Message: "already posted"}
+	api := &routedAPI{t: t, threads: []obj{{
+		"id": "PRRT_x", "path": "a.go", "line": 2,
+		"comments": obj{"nodes": []obj{{
+			"id": "PRRC_x", "databaseId": 10, "body": duplicate.Body(), "createdAt": "2024-06-01T00:00:00Z",
+			"author": obj{"login": "bot"}, "pullRequestReview": obj{"id": "PRR_old", "state": "COMMENTED", "databaseId": 1},
+		}}},
+	}}}
+
+	findings := []Finding{
+		duplicate,
+		{Path: "a.go", Line: 3, Severity: SeverityWarning, Rule: "w", Message: "warn"},
+		{Path: "a.go", Line: 2, EndLine: 3, Severity: SeverityError, Rule: "e", Message: "error"},
+		{Path: "a.go", Line: 40, Severity: SeverityError, Rule: "far", Message: "not in diff"},
+		{Path: "b.go", Line: 1, Severity: SeverityError, Rule: "other", Message: "file not changed"},
+		{Path: "a.go", Line: 3, Severity: SeverityWarning, Rule: "w", Message: "warn"},
+	}
+
+	result, err := NewService(api).Import(context.Background(), testPR, findings, ImportOptions{Submit: true, Body: "lint"})
+	require.NoError(t, err)
+
+	statuses := make([]string, len(result.Comments))
+	for i, comment := range result.Comments {
+		statuses[i] = comment.Status
+	}
+	assert.Equal(t, []string{
+		ImportStatusDuplicate,
+		ImportStatusCreated,
+		ImportStatusCreated,
+		ImportStatusOutsideDiff,
+		ImportStatusOutsideDiff,
+		ImportStatusDuplicate,
+	}, statuses)
+
+	assert.Equal(t, 1, api.started)
+	assert.Equal(t, "PRR_new", result.ReviewID)
+	require.Len(t, api.added, 2)
+	assert.EqualValues(t, 3, api.added[1]["line"])
+	assert.EqualValues(t, 2, api.added[1]["startLine"])
+
+	assert.Equal(t, "REQUEST_CHANGES", result.Event)
+	assert.True(t, result.Submitted)
+	assert.Equal(t, "REQUEST_CHANGES", api.submitted["event"])
+	assert.Equal(t, "lint", api.submitted["body"])
+}
+
+func TestImportUsesPendingReviewAndEventMap(t *testing.T) {
+	api := &routedAPI{t: t, pending: []obj{
+		{"id": "PRR_mine", "databaseId": 5, "state": "PENDING", "updatedAt": "2024-06-01T00:00:00Z", "author": obj{"login": "bot"}},
+	}}
+	events, err := ParseEvents(map[string]string{"error": "comment"})
+	require.NoError(t, err)
+
+	result, err := NewService(api).Import(context.Background(), testPR, []Finding{
+		{Path: "a.go", Line: 5, Severity: SeverityError, Message: "boom"},
+	}, ImportOptions{Events: events})
+	require.NoError(t, err)
+
+	assert.Zero(t, api.started)
+	assert.Equal(t, "PRR_mine", result.ReviewID)
+	assert.Equal(t, "PRR_mine", api.added[0]["pullRequestReviewId"])
+	assert.Equal(t, "COMMENT", result.Event)
+	assert.False(t, result.Submitted)
+	assert.Nil(t, api.submitted)
+}
+
+func TestImportDedupesAgainstPendingReview(t *testing.T) {
+	finding := Finding{Path: "a.go", Line: 2, Severity: SeverityWarning, Rule: "w", Message: "warn"}
+	api := &routedAPI{
+		t:       t,
+		reviews: []obj{{"id": "PRR_mine", "state": "PENDING", "databaseId": 5, "author": obj{"login": "bot"}}},
+		pending: []obj{{"id": "PRR_mine", "databaseId": 5, "state": "PENDING", "updatedAt": "2024-06-01T00:00:00Z", "author": obj{"login": "bot"}}},
+		threads: []obj{{
+			"id": "PRRT_x", "path": "a.go", "line": 2,
+			"comments": obj{"nodes": []obj{{
+				"id": "PRRC_x", "databaseId": 10, "body": finding.Body(), "createdAt": "2024-06-01T00:00:00Z",
+				"author": obj{"login": "bot"}, "pullRequestReview": obj{"id": "PRR_mine", "state": "PENDING", "databaseId": 5},
+			}}},
+		}},
+	}
+
+	result, err := NewService(api).Import(context.Background(), testPR, []Finding{finding}, ImportOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Comments, 1)
+	assert.Equal(t, ImportStatusDuplicate, result.Comments[0].Status)
+	assert.Empty(t, api.added)
+	assert.Zero(t, api.started)
+}
+
+func TestImportSkipsReviewWhenNothingToPost(t *testing.T) {
+	api := &routedAPI{t: t}
+
+	result, err := NewService(api).Import(context.Background(), testPR, []Finding{
+		{Path: "a.go", Line: 40, Severity: SeverityError, Message: "outside"},
+	}, ImportOptions{Submit: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.ReviewID)
+	assert.Zero(t, api.started)
+	assert.Nil(t, api.submitted)
+}
+
+func TestParseEventsRejectsUnknownValues(t *testing.T) {
+	_, err := ParseEvents(map[string]string{"fatal": "COMMENT"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid severity")
+
+	_, err = ParseEvents(map[string]string{"error": "BLOCK"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid event")
+}
@@ -0,0 +1,139 @@
+package ghcli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultHost = "github.com"
+
+// ErrNoToken indicates that no authentication token could be located for a host.
+var ErrNoToken = errors.New("no GitHub token found")
+
+// IsEnterprise reports whether host refers to a GitHub Enterprise Server instance.
+func IsEnterprise(host string) bool {
+	h := normalizeHost(host)
+	return h != defaultHost && !isTenancy(h)
+}
+
+// ResolveToken locates an authentication token for host following the same
+// precedence as `gh`: environment variables first, then hosts.yml, and finally
+// the `gh auth token` helper for credentials stored in the system keyring.
+func ResolveToken(host string) (string, error) {
+	h := normalizeHost(host)
+
+	if token := tokenFromEnv(h); token != "" {
+		return token, nil
+	}
+
+	token, err := tokenFromHostsFile(h)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		return token, nil
+	}
+
+	if token := tokenFromGh(h); token != "" {
+		return token, nil
+	}
+
+	return "", fmt.Errorf("%w for %s: set GH_TOKEN or run `gh auth login`", ErrNoToken, h)
+}
+
+func tokenFromEnv(host string) string {
+	keys := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if IsEnterprise(host) {
+		keys = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, key := range keys {
+		if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+type hostEntry struct {
+	User       string `yaml:"user"`
+	OAuthToken string `yaml:"oauth_token"`
+	Users      map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	} `yaml:"users"`
+}
+
+func tokenFromHostsFile(host string) (string, error) {
+	path := filepath.Join(configDir(), "hosts.yml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var hosts map[string]hostEntry
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return "", fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	for name, entry := range hosts {
+		if normalizeHost(name) != host {
+			continue
+		}
+		if token := strings.TrimSpace(entry.OAuthToken); token != "" {
+			return token, nil
+		}
+		if user, ok := entry.Users[entry.User]; ok {
+			return strings.TrimSpace(user.OAuthToken), nil
+		}
+	}
+	return "", nil
+}
+
+func tokenFromGh(host string) string {
+	cmd := exec.Command("gh", "auth", "token", "--hostname", host)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(stdout.String())
+}
+
+// configDir mirrors the lookup order `gh` uses for its configuration directory.
+func configDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI")
+		}
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gh")
+}
+
+func normalizeHost(host string) string {
+	h := strings.ToLower(strings.TrimSpace(host))
+	if h == "" || h == "api.github.com" {
+		return defaultHost
+	}
+	return h
+}
+
+func isTenancy(host string) bool {
+	return strings.HasSuffix(host, ".ghe.com")
+}
@@ -0,0 +1,79 @@
+package ghcli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTokenPrefersEnvironment(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_TOKEN", "public")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise")
+
+	token, err := ResolveToken("github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "public", token)
+
+	token, err = ResolveToken("ghe.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "enterprise", token)
+}
+
+func TestResolveTokenReadsHostsFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", dir)
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+
+	hosts := `github.com:
+    user: octocat
+    oauth_token: gho_legacy
+ghe.example.com:
+    user: hubot
+    users:
+        hubot:
+            oauth_token: gho_multi
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600))
+
+	token, err := ResolveToken("github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "gho_legacy", token)
+
+	token, err = ResolveToken("GHE.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "gho_multi", token)
+}
+
+func TestParseTransport(t *testing.T) {
+	transport, err := ParseTransport("")
+	require.NoError(t, err)
+	assert.Equal(t, TransportGh, transport)
+
+	transport, err = ParseTransport(" HTTP ")
+	require.NoError(t, err)
+	assert.Equal(t, TransportHTTP, transport)
+
+	_, err = ParseTransport("grpc")
+	require.Error(t, err)
+}
+
+func TestNewSurfacesMissingTokenForHTTPTransport(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("PATH", t.TempDir())
+
+	err := New("github.com", TransportHTTP).GraphQL(context.Background(), "query { viewer { login } }", nil, nil)
+	require.ErrorIs(t, err, ErrNoToken)
+	assert.Contains(t, err.Error(), "http transport")
+
+	assert.IsType(t, &Client{}, New("github.com", TransportGh))
+}
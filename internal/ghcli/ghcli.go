@@ -2,12 +2,14 @@ package ghcli
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Client executes GitHub API requests through the `gh` CLI to reuse
@@ -18,12 +20,13 @@ type Client struct {
 
 // API defines the subset of GitHub API interactions required by the command logic.
 type API interface {
-	REST(method, path string, params map[string]string, body interface{}, result interface{}) error
-	GraphQL(query string, variables map[string]interface{}, result interface{}) error
+	REST(ctx context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error
+	GraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error
 }
 
 // GraphQLErrorEntry captures a single GraphQL error payload.
 type GraphQLErrorEntry struct {
+	Type    string        `json:"type,omitempty"`
 	Message string        `json:"message"`
 	Path    []interface{} `json:"path,omitempty"`
 }
@@ -47,12 +50,15 @@ func (e *GraphQLError) Error() string {
 	return fmt.Sprintf("graphql errors: %s", strings.Join(parts, "; "))
 }
 
-// APIError wraps errors returned by the `gh api` command, exposing the HTTP status code when detected.
+// APIError wraps errors returned by `gh api` or the HTTP transport, exposing the HTTP status code when detected.
 type APIError struct {
 	StatusCode int
 	Message    string
 	Stderr     string
 	Body       string
+	// RetryAfter is the server-requested wait before retrying, derived from the
+	// Retry-After or x-ratelimit-reset headers when the transport exposes them.
+	RetryAfter time.Duration
 	Err        error
 }
 
@@ -87,7 +93,11 @@ func (e *APIError) ContainsLower(target string) bool {
 
 var statusRE = regexp.MustCompile(`HTTP\s+(\d{3})\b`)
 
-func wrapError(err error, stdout []byte, stderr string) error {
+func wrapError(ctx context.Context, err error, stdout []byte, stderr string) error {
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+
 	message := strings.TrimSpace(stderr)
 	if message == "" {
 		message = err.Error()
@@ -110,7 +120,7 @@ func wrapError(err error, stdout []byte, stderr string) error {
 
 // REST invokes the REST API using `gh api`.
 // The result parameter must be a pointer and will be unmarshaled from JSON.
-func (c *Client) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+func (c *Client) REST(ctx context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
 	args := []string{"api"}
 	if host := strings.TrimSpace(c.Host); host != "" {
 		args = append(args, "--hostname", host)
@@ -133,9 +143,9 @@ func (c *Client) REST(method, path string, params map[string]string, body interf
 		args = append(args, "--input", "-")
 	}
 
-	stdout, stderr, err := runGh(args, stdinData)
+	stdout, stderr, err := runGh(ctx, args, stdinData)
 	if err != nil {
-		return wrapError(err, stdout, stderr)
+		return wrapError(ctx, err, stdout, stderr)
 	}
 
 	if result == nil {
@@ -150,7 +160,7 @@ func (c *Client) REST(method, path string, params map[string]string, body interf
 }
 
 // GraphQL issues a GraphQL operation through `gh api graphql`.
-func (c *Client) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	payload := map[string]interface{}{
 		"query": query,
 	}
@@ -169,20 +179,26 @@ func (c *Client) GraphQL(query string, variables map[string]interface{}, result
 	}
 	args = append(args, "--input", "-")
 
-	stdout, stderr, err := runGh(args, data)
+	stdout, stderr, err := runGh(ctx, args, data)
 	if err != nil {
-		return wrapError(err, stdout, stderr)
+		return wrapError(ctx, err, stdout, stderr)
 	}
 
 	if result == nil {
 		return nil
 	}
 
+	return decodeGraphQL(stdout, result)
+}
+
+// decodeGraphQL unmarshals a GraphQL response envelope into result, surfacing
+// any reported errors as a *GraphQLError.
+func decodeGraphQL(data []byte, result interface{}) error {
 	var envelope struct {
 		Data   json.RawMessage   `json:"data"`
 		Errors []json.RawMessage `json:"errors"`
 	}
-	if err := json.Unmarshal(stdout, &envelope); err != nil {
+	if err := json.Unmarshal(data, &envelope); err != nil {
 		return fmt.Errorf("unmarshal graphql response: %w", err)
 	}
 	if len(envelope.Errors) > 0 {
@@ -204,15 +220,25 @@ func (c *Client) GraphQL(query string, variables map[string]interface{}, result
 	}
 
 	if len(envelope.Data) == 0 && result != nil {
-		return json.Unmarshal(stdout, result)
+		return json.Unmarshal(data, result)
 	}
 
 	return nil
 }
 
+// contextError reports why ctx ended, preferring the cancellation cause so callers
+// can distinguish a user interrupt from a deadline. It returns nil while ctx is live.
+func contextError(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(ctx)
+}
+
 // runGh executes the `gh` CLI command with provided arguments and optional stdin data.
-func runGh(args []string, stdin []byte) ([]byte, string, error) {
-	cmd := exec.Command("gh", args...)
+// The process is killed when ctx is cancelled or its deadline passes.
+func runGh(ctx context.Context, args []string, stdin []byte) ([]byte, string, error) {
+	cmd := exec.CommandContext(ctx, "gh", args...)
 	// DEBUG LOG
 	// fmt.Fprintf(os.Stderr, "running gh %s\n", strings.Join(args, " "))
 	if stdin != nil {
@@ -0,0 +1,228 @@
+package ghcli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPClient talks to the GitHub API directly over HTTPS, avoiding the cost of
+// spawning a `gh` process per request. It resolves credentials the same way
+// `gh` does (see ResolveToken).
+type HTTPClient struct {
+	Host  string
+	Token string
+	// BaseURL overrides the API root derived from Host. GraphQL requests are
+	// sent to BaseURL + "/graphql" when set.
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewHTTPClient constructs an HTTPClient for host, resolving its token.
+func NewHTTPClient(host string) (*HTTPClient, error) {
+	token, err := ResolveToken(host)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPClient{Host: host, Token: token}, nil
+}
+
+// REST invokes the REST API over HTTP.
+// Params are sent as query parameters for GET requests or when a body is
+// supplied; otherwise they form the JSON request body, matching `gh api -f`.
+func (c *HTTPClient) REST(ctx context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
+	endpoint, err := url.Parse(c.restBase() + "/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return fmt.Errorf("build request url: %w", err)
+	}
+
+	var payload io.Reader
+	switch {
+	case body != nil:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request body: %w", err)
+		}
+		payload = bytes.NewReader(data)
+	case len(params) > 0 && !strings.EqualFold(method, http.MethodGet):
+		fields := make(map[string]string, len(params))
+		for key, value := range params {
+			fields[key] = value
+		}
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("marshal request body: %w", err)
+		}
+		payload = bytes.NewReader(data)
+		params = nil
+	}
+
+	if len(params) > 0 {
+		query := endpoint.Query()
+		for key, value := range params {
+			query.Set(key, value)
+		}
+		endpoint.RawQuery = query.Encode()
+	}
+
+	data, err := c.do(ctx, method, endpoint.String(), payload)
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// GraphQL issues a GraphQL operation over HTTP.
+func (c *HTTPClient) GraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	payload := map[string]interface{}{
+		"query": query,
+	}
+	if len(variables) > 0 {
+		payload["variables"] = variables
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal graphql payload: %w", err)
+	}
+
+	data, err := c.do(ctx, http.MethodPost, c.graphQLURL(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	return decodeGraphQL(data, result)
+}
+
+func (c *HTTPClient) do(ctx context.Context, method, endpoint string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "gh-pr-review")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, &APIError{Message: err.Error(), Err: err}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: err.Error(), Err: err}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, httpError(resp, data)
+	}
+
+	return data, nil
+}
+
+// httpError converts a non-2xx response into an APIError whose message mirrors
+// what `gh api` prints on stderr, so callers matching on either see the same text.
+func httpError(resp *http.Response, data []byte) *APIError {
+	body := strings.TrimSpace(string(data))
+
+	var parsed struct {
+		Message string `json:"message"`
+	}
+	message := http.StatusText(resp.StatusCode)
+	if err := json.Unmarshal(data, &parsed); err == nil && strings.TrimSpace(parsed.Message) != "" {
+		message = strings.TrimSpace(parsed.Message)
+	}
+	message = fmt.Sprintf("%s (HTTP %d)", message, resp.StatusCode)
+
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    message,
+		Body:       body,
+		RetryAfter: retryAfter(resp.Header, time.Now()),
+		Err:        fmt.Errorf("%s %s: %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.Status),
+	}
+}
+
+// retryAfter extracts the server-requested backoff from rate-limit headers.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if when, err := http.ParseTime(value); err == nil && when.After(now) {
+			return when.Sub(now)
+		}
+	}
+	if strings.TrimSpace(header.Get("X-Ratelimit-Remaining")) == "0" {
+		if reset, err := strconv.ParseInt(strings.TrimSpace(header.Get("X-Ratelimit-Reset")), 10, 64); err == nil {
+			if when := time.Unix(reset, 0); when.After(now) {
+				return when.Sub(now)
+			}
+		}
+	}
+	return 0
+}
+
+func (c *HTTPClient) restBase() string {
+	if base := strings.TrimRight(strings.TrimSpace(c.BaseURL), "/"); base != "" {
+		return base
+	}
+	host := normalizeHost(c.Host)
+	switch {
+	case host == defaultHost:
+		return "https://api.github.com"
+	case isTenancy(host):
+		return "https://api." + host
+	default:
+		return "https://" + host + "/api/v3"
+	}
+}
+
+func (c *HTTPClient) graphQLURL() string {
+	if base := strings.TrimRight(strings.TrimSpace(c.BaseURL), "/"); base != "" {
+		return base + "/graphql"
+	}
+	host := normalizeHost(c.Host)
+	switch {
+	case host == defaultHost:
+		return "https://api.github.com/graphql"
+	case isTenancy(host):
+		return "https://api." + host + "/graphql"
+	default:
+		return "https://" + host + "/api/graphql"
+	}
+}
@@ -0,0 +1,146 @@
+package ghcli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClientRESTQueryParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/repos/octo/demo/pulls/7/reviews", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "2022-11-28", r.Header.Get("X-GitHub-Api-Version"))
+		_, _ = io.WriteString(w, `[{"id":1}]`)
+	}))
+	defer server.Close()
+
+	client := &HTTPClient{Token: "secret", BaseURL: server.URL}
+	var result []struct {
+		ID int `json:"id"`
+	}
+	err := client.REST(context.Background(), "GET", "repos/octo/demo/pulls/7/reviews", map[string]string{"per_page": "100"}, nil, &result)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, 1, result[0].ID)
+}
+
+func TestHTTPClientRESTFieldsBecomeBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Empty(t, r.URL.RawQuery)
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "hello", body["body"])
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &HTTPClient{BaseURL: server.URL}
+	var result map[string]interface{}
+	err := client.REST(context.Background(), "POST", "repos/octo/demo/issues/7/comments", map[string]string{"body": "hello"}, nil, &result)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestHTTPClientRESTErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"message":"Not Found"}`)
+	}))
+	defer server.Close()
+
+	client := &HTTPClient{BaseURL: server.URL}
+	err := client.REST(context.Background(), "GET", "repos/octo/missing", nil, nil, &struct{}{})
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "Not Found (HTTP 404)", apiErr.Message)
+	assert.True(t, apiErr.ContainsLower("not found"))
+}
+
+func TestHTTPClientGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "query { viewer { login } }", payload["query"])
+		_, _ = io.WriteString(w, `{"data":null,"errors":[{"message":"boom"}]}`)
+	}))
+	defer server.Close()
+
+	client := &HTTPClient{BaseURL: server.URL}
+	var result struct{}
+	err := client.GraphQL(context.Background(), "query { viewer { login } }", nil, &result)
+
+	var gqlErr *GraphQLError
+	require.True(t, errors.As(err, &gqlErr))
+	require.Len(t, gqlErr.Errors, 1)
+	assert.Equal(t, "boom", gqlErr.Errors[0].Message)
+}
+
+func TestHTTPClientGraphQLData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":{"viewer":{"login":"octocat"}}}`)
+	}))
+	defer server.Close()
+
+	client := &HTTPClient{BaseURL: server.URL}
+	var result struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	require.NoError(t, client.GraphQL(context.Background(), "query { viewer { login } }", nil, &result))
+	assert.Equal(t, "octocat", result.Viewer.Login)
+}
+
+func TestHTTPClientEndpoints(t *testing.T) {
+	cases := []struct {
+		host    string
+		rest    string
+		graphql string
+	}{
+		{"", "https://api.github.com", "https://api.github.com/graphql"},
+		{"github.com", "https://api.github.com", "https://api.github.com/graphql"},
+		{"ghe.example.com", "https://ghe.example.com/api/v3", "https://ghe.example.com/api/graphql"},
+		{"octo.ghe.com", "https://api.octo.ghe.com", "https://api.octo.ghe.com/graphql"},
+	}
+	for _, tc := range cases {
+		client := &HTTPClient{Host: tc.host}
+		assert.Equal(t, tc.rest, client.restBase(), tc.host)
+		assert.Equal(t, tc.graphql, client.graphQLURL(), tc.host)
+	}
+}
+
+func TestHTTPClientReportsCancellationCause(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cause := errors.New("interrupted")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+
+	client := &HTTPClient{BaseURL: server.URL}
+	err := client.GraphQL(ctx, "query { viewer { login } }", nil, &struct{}{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cause))
+
+	var apiErr *APIError
+	assert.False(t, errors.As(err, &apiErr))
+}
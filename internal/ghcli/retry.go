@@ -0,0 +1,254 @@
+package ghcli
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how RetryingAPI backs off between attempts.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt; zero disables retrying.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry; it doubles on each attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff.
+	MaxDelay time.Duration
+	// MaxWait is the longest server-requested wait (Retry-After, rate-limit
+	// reset) worth honoring; longer waits fail immediately instead.
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by the CLI.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Second,
+		MaxDelay:   30 * time.Second,
+		MaxWait:    2 * time.Minute,
+	}
+}
+
+// DedupeFunc reports whether a mutation whose outcome is unknown already took
+// effect. When it did, the function populates result with the equivalent
+// mutation payload and returns true so the call can succeed without repeating it.
+type DedupeFunc func(ctx context.Context, result interface{}) (bool, error)
+
+type dedupeKey struct{}
+
+// WithDedupe attaches a dedupe check to ctx. RetryingAPI only retries
+// non-idempotent mutations when such a check confirms the failed attempt did not land.
+func WithDedupe(ctx context.Context, check DedupeFunc) context.Context {
+	return context.WithValue(ctx, dedupeKey{}, check)
+}
+
+func dedupeFrom(ctx context.Context) DedupeFunc {
+	check, _ := ctx.Value(dedupeKey{}).(DedupeFunc)
+	return check
+}
+
+// idempotentMutations lists mutations that are safe to repeat verbatim.
+var idempotentMutations = map[string]struct{}{
+	"addReaction":                    {},
+	"minimizeComment":                {},
+	"removeReaction":                 {},
+	"resolveReviewThread":            {},
+	"unminimizeComment":              {},
+	"unresolveReviewThread":          {},
+	"updateIssueComment":             {},
+	"updatePullRequestReview":        {},
+	"updatePullRequestReviewComment": {},
+}
+
+var mutationFieldRE = regexp.MustCompile(`^mutation\b[^{]*\{\s*(\w+)`)
+
+// RetryingAPI decorates an API, retrying transient failures (5xx responses,
+// secondary rate limits, and RATE_LIMITED GraphQL errors) with jittered
+// exponential backoff. Reads and idempotent mutations are retried directly;
+// other mutations are retried only when a DedupeFunc attached to the context
+// confirms the failed attempt had no effect.
+type RetryingAPI struct {
+	API    API
+	Policy RetryPolicy
+
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(n int64) int64
+}
+
+// NewRetryingAPI wraps api with the given retry policy.
+func NewRetryingAPI(api API, policy RetryPolicy) *RetryingAPI {
+	return &RetryingAPI{API: api, Policy: policy}
+}
+
+// REST retries read-only HTTP methods on transient failures.
+func (r *RetryingAPI) REST(ctx context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
+	idempotent := isIdempotentMethod(method)
+	return r.run(ctx, idempotent, result, func() error {
+		return r.API.REST(ctx, method, path, params, body, result)
+	})
+}
+
+// GraphQL retries queries and idempotent mutations on transient failures.
+func (r *RetryingAPI) GraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	idempotent := isIdempotentOperation(query)
+	return r.run(ctx, idempotent, result, func() error {
+		return r.API.GraphQL(ctx, query, variables, result)
+	})
+}
+
+func (r *RetryingAPI) run(ctx context.Context, idempotent bool, result interface{}, call func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = call()
+		if err == nil {
+			return nil
+		}
+		if attempt >= r.Policy.MaxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		wait, retryable := r.backoff(err, attempt)
+		if !retryable {
+			return err
+		}
+
+		if !idempotent {
+			check := dedupeFrom(ctx)
+			if check == nil {
+				return err
+			}
+			landed, checkErr := check(ctx, result)
+			if checkErr != nil {
+				return err
+			}
+			if landed {
+				return nil
+			}
+		}
+
+		if sleepErr := r.wait(ctx, wait); sleepErr != nil {
+			return sleepErr
+		}
+	}
+}
+
+// backoff classifies err and returns the delay before the next attempt.
+func (r *RetryingAPI) backoff(err error, attempt int) (time.Duration, bool) {
+	var serverWait time.Duration
+
+	var apiErr *APIError
+	var gqlErr *GraphQLError
+	switch {
+	case errors.As(err, &apiErr):
+		if !isTransientStatus(apiErr) {
+			return 0, false
+		}
+		serverWait = apiErr.RetryAfter
+	case errors.As(err, &gqlErr):
+		if !isRateLimited(gqlErr) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if serverWait > 0 {
+		if r.Policy.MaxWait > 0 && serverWait > r.Policy.MaxWait {
+			return 0, false
+		}
+		return serverWait, true
+	}
+
+	delay := r.Policy.BaseDelay << attempt
+	if delay <= 0 || (r.Policy.MaxDelay > 0 && delay > r.Policy.MaxDelay) {
+		delay = r.Policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0, true
+	}
+	// Equal jitter: wait between half and the full backoff so concurrent
+	// clients spread out without collapsing to zero delay.
+	half := int64(delay / 2)
+	return time.Duration(half + r.randInt63n(half+1)), true
+}
+
+func (r *RetryingAPI) wait(ctx context.Context, d time.Duration) error {
+	if r.sleep != nil {
+		return r.sleep(ctx, d)
+	}
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *RetryingAPI) randInt63n(n int64) int64 {
+	if r.jitter != nil {
+		return r.jitter(n)
+	}
+	return rand.Int63n(n)
+}
+
+// isTransientStatus reports whether err is a server-side or rate-limit failure.
+// The `gh` transport surfaces GraphQL errors as APIError with the JSON body
+// attached, so RATE_LIMITED is matched textually as well.
+func isTransientStatus(err *APIError) bool {
+	if err.ContainsLower("rate_limited") || err.ContainsLower("secondary rate limit") || err.ContainsLower("abuse detection") {
+		return true
+	}
+	switch {
+	case err.StatusCode >= 500 && err.StatusCode <= 599:
+		return true
+	case err.StatusCode == http.StatusTooManyRequests:
+		return true
+	case err.StatusCode == http.StatusForbidden:
+		return err.ContainsLower("rate limit exceeded")
+	default:
+		return false
+	}
+}
+
+func isRateLimited(err *GraphQLError) bool {
+	for _, entry := range err.Errors {
+		if strings.EqualFold(entry.Type, "RATE_LIMITED") {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdempotentMethod reports whether a REST request only reads data. Writes
+// such as DELETE are excluded: a repeat after a lost response would surface a
+// 404 even though the first attempt succeeded.
+func isIdempotentMethod(method string) bool {
+	switch strings.ToUpper(strings.TrimSpace(method)) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+func isIdempotentOperation(query string) bool {
+	trimmed := strings.TrimSpace(query)
+	if !strings.HasPrefix(trimmed, "mutation") {
+		return true
+	}
+	matches := mutationFieldRE.FindStringSubmatch(trimmed)
+	if len(matches) != 2 {
+		return false
+	}
+	_, ok := idempotentMutations[matches[1]]
+	return ok
+}
@@ -0,0 +1,198 @@
+package ghcli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scriptedAPI struct {
+	restErrs    []error
+	graphqlErrs []error
+	restCalls   int
+	graphqlCall int
+}
+
+func (s *scriptedAPI) REST(_ context.Context, _, _ string, _ map[string]string, _, _ interface{}) error {
+	s.restCalls++
+	if len(s.restErrs) == 0 {
+		return nil
+	}
+	err := s.restErrs[0]
+	s.restErrs = s.restErrs[1:]
+	return err
+}
+
+func (s *scriptedAPI) GraphQL(_ context.Context, _ string, _ map[string]interface{}, _ interface{}) error {
+	s.graphqlCall++
+	if len(s.graphqlErrs) == 0 {
+		return nil
+	}
+	err := s.graphqlErrs[0]
+	s.graphqlErrs = s.graphqlErrs[1:]
+	return err
+}
+
+func newTestRetrying(api API, waits *[]time.Duration) *RetryingAPI {
+	r := NewRetryingAPI(api, RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 4 * time.Second, MaxWait: time.Minute})
+	r.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	r.jitter = func(n int64) int64 { return n - 1 }
+	return r
+}
+
+func TestRetryingAPIRetriesTransientREST(t *testing.T) {
+	api := &scriptedAPI{restErrs: []error{
+		&APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"},
+		&APIError{StatusCode: http.StatusServiceUnavailable, Message: "Unavailable"},
+	}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	require.NoError(t, r.REST(context.Background(), "GET", "repos/octo/demo", nil, nil, nil))
+	assert.Equal(t, 3, api.restCalls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+}
+
+func TestRetryingAPIHonorsRetryAfter(t *testing.T) {
+	api := &scriptedAPI{restErrs: []error{
+		&APIError{StatusCode: http.StatusForbidden, Message: "You have exceeded a secondary rate limit", RetryAfter: 7 * time.Second},
+	}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	require.NoError(t, r.REST(context.Background(), "GET", "user", nil, nil, nil))
+	assert.Equal(t, []time.Duration{7 * time.Second}, waits)
+}
+
+func TestRetryingAPIGivesUpOnLongRateLimitReset(t *testing.T) {
+	api := &scriptedAPI{restErrs: []error{
+		&APIError{StatusCode: http.StatusForbidden, Message: "API rate limit exceeded", RetryAfter: time.Hour},
+	}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	require.Error(t, r.REST(context.Background(), "GET", "user", nil, nil, nil))
+	assert.Equal(t, 1, api.restCalls)
+	assert.Empty(t, waits)
+}
+
+func TestRetryingAPISkipsPermanentErrors(t *testing.T) {
+	api := &scriptedAPI{restErrs: []error{&APIError{StatusCode: http.StatusNotFound, Message: "Not Found"}}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	require.Error(t, r.REST(context.Background(), "GET", "repos/octo/missing", nil, nil, nil))
+	assert.Equal(t, 1, api.restCalls)
+}
+
+func TestRetryingAPIStopsAfterMaxRetries(t *testing.T) {
+	transient := &APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}
+	api := &scriptedAPI{restErrs: []error{transient, transient, transient, transient, transient}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	err := r.REST(context.Background(), "GET", "user", nil, nil, nil)
+	require.Error(t, err)
+	assert.Equal(t, 4, api.restCalls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, waits)
+}
+
+func TestRetryingAPIRetriesRateLimitedQueries(t *testing.T) {
+	api := &scriptedAPI{graphqlErrs: []error{
+		&GraphQLError{Errors: []GraphQLErrorEntry{{Type: "RATE_LIMITED", Message: "API rate limit exceeded"}}},
+	}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	require.NoError(t, r.GraphQL(context.Background(), "query { viewer { login } }", nil, nil))
+	assert.Equal(t, 2, api.graphqlCall)
+}
+
+func TestRetryingAPIDoesNotBlindlyRetryMutations(t *testing.T) {
+	api := &scriptedAPI{graphqlErrs: []error{&APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	mutation := "mutation($input:AddPullRequestReviewThreadInput!){\n  addPullRequestReviewThread(input:$input){ thread { id } }\n}"
+	require.Error(t, r.GraphQL(context.Background(), mutation, nil, nil))
+	assert.Equal(t, 1, api.graphqlCall)
+}
+
+func TestRetryingAPIRetriesMutationWhenDedupeConfirmsMiss(t *testing.T) {
+	api := &scriptedAPI{graphqlErrs: []error{&APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	checks := 0
+	ctx := WithDedupe(context.Background(), func(context.Context, interface{}) (bool, error) {
+		checks++
+		return false, nil
+	})
+	mutation := "mutation { addPullRequestReviewThread(input: {}) { thread { id } } }"
+	require.NoError(t, r.GraphQL(ctx, mutation, nil, nil))
+	assert.Equal(t, 2, api.graphqlCall)
+	assert.Equal(t, 1, checks)
+}
+
+func TestRetryingAPIAcceptsLandedMutation(t *testing.T) {
+	api := &scriptedAPI{graphqlErrs: []error{&APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	ctx := WithDedupe(context.Background(), func(_ context.Context, result interface{}) (bool, error) {
+		*(result.(*string)) = "THREAD1"
+		return true, nil
+	})
+	var result string
+	mutation := "mutation { addPullRequestReviewThread(input: {}) { thread { id } } }"
+	require.NoError(t, r.GraphQL(ctx, mutation, nil, &result))
+	assert.Equal(t, 1, api.graphqlCall)
+	assert.Equal(t, "THREAD1", result)
+}
+
+func TestRetryingAPIRetriesIdempotentMutations(t *testing.T) {
+	api := &scriptedAPI{graphqlErrs: []error{&APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}}}
+	var waits []time.Duration
+	r := newTestRetrying(api, &waits)
+
+	mutation := "mutation ResolveThread($threadId: ID!) {\n  resolveReviewThread(input: {threadId: $threadId}) { thread { id } }\n}"
+	require.NoError(t, r.GraphQL(context.Background(), mutation, nil, nil))
+	assert.Equal(t, 2, api.graphqlCall)
+}
+
+func TestRetryingAPIStopsWhenContextEnds(t *testing.T) {
+	api := &scriptedAPI{restErrs: []error{&APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}}}
+	r := NewRetryingAPI(api, RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour})
+
+	cause := errors.New("interrupted")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	go cancel(cause)
+
+	err := r.REST(ctx, "GET", "user", nil, nil, nil)
+	require.Error(t, err)
+	assert.Equal(t, 1, api.restCalls)
+}
+
+func TestRetryAfterHeaders(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	header := http.Header{}
+	header.Set("Retry-After", "12")
+	assert.Equal(t, 12*time.Second, retryAfter(header, now))
+
+	header = http.Header{}
+	header.Set("X-Ratelimit-Remaining", "0")
+	header.Set("X-Ratelimit-Reset", "1700000030")
+	assert.Equal(t, 30*time.Second, retryAfter(header, now))
+
+	header.Set("X-Ratelimit-Remaining", "5")
+	assert.Equal(t, time.Duration(0), retryAfter(header, now))
+}
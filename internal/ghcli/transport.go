@@ -0,0 +1,59 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Transport names the mechanism used to reach the GitHub API.
+type Transport string
+
+const (
+	// TransportGh shells out to `gh api` for every request.
+	TransportGh Transport = "gh"
+	// TransportHTTP issues requests directly over HTTPS.
+	TransportHTTP Transport = "http"
+)
+
+// TransportEnv is the environment variable consulted for the default transport.
+const TransportEnv = "GH_PR_REVIEW_TRANSPORT"
+
+// ParseTransport validates a transport name. An empty value selects TransportGh.
+func ParseTransport(raw string) (Transport, error) {
+	switch t := Transport(strings.ToLower(strings.TrimSpace(raw))); t {
+	case "":
+		return TransportGh, nil
+	case TransportGh, TransportHTTP:
+		return t, nil
+	default:
+		return "", fmt.Errorf("invalid transport %q: must be gh or http", raw)
+	}
+}
+
+// New returns an API client for host using the requested transport. When the
+// HTTP transport is requested but no token can be resolved, the returned client
+// fails every request with that error rather than quietly switching to `gh`.
+func New(host string, transport Transport) API {
+	if transport != TransportHTTP {
+		return &Client{Host: host}
+	}
+	client, err := NewHTTPClient(host)
+	if err != nil {
+		return unavailableAPI{err: fmt.Errorf("http transport: %w", err)}
+	}
+	return client
+}
+
+// unavailableAPI reports why the requested transport could not be set up.
+type unavailableAPI struct {
+	err error
+}
+
+func (u unavailableAPI) REST(context.Context, string, string, map[string]string, interface{}, interface{}) error {
+	return u.err
+}
+
+func (u unavailableAPI) GraphQL(context.Context, string, map[string]interface{}, interface{}) error {
+	return u.err
+}
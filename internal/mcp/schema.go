@@ -0,0 +1,255 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// field is one argument of a tool, found by flattening the argument struct
+// and its embedded service option structs.
+type field struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// argFields lists the JSON arguments of an argument struct. Fields use their
+// json tag name or, for the untagged service option structs, the snake_case
+// form of the Go name (ReviewID becomes review_id). Go names in skip are left
+// out.
+func argFields(t reflect.Type, skip map[string]bool) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		// Like encoding/json, embedded structs are flattened even when
+		// their type is unexported.
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			for _, inner := range argFields(f.Type, skip) {
+				inner.index = append([]int{i}, inner.index...)
+				fields = append(fields, inner)
+			}
+			continue
+		}
+		if !f.IsExported() || skip[f.Name] {
+			continue
+		}
+		name, _ := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = snakeCase(f.Name)
+		}
+		fields = append(fields, field{name: name, index: []int{i}, typ: f.Type})
+	}
+	return fields
+}
+
+// jsonName returns the json tag name of f and whether it is omitempty.
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "" {
+		return "", false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	return name, strings.Contains(","+options+",", ",omitempty,")
+}
+
+// snakeCase converts a Go identifier such as IncludeCommentNodeID to
+// include_comment_node_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// inputSchema builds the JSON Schema of a tool's arguments.
+func inputSchema(spec *tool) map[string]interface{} {
+	properties := make(map[string]interface{}, len(spec.fields))
+	for _, f := range spec.fields {
+		property := typeSchema(f.typ, nil)
+		if doc := spec.docs[f.name]; doc != "" {
+			property["description"] = doc
+		}
+		if values := spec.enums[f.name]; len(values) > 0 {
+			if property["type"] == "array" {
+				property["items"] = map[string]interface{}{"type": "string", "enum": values}
+			} else {
+				property["enum"] = values
+			}
+		}
+		properties[f.name] = property
+	}
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(spec.required) > 0 {
+		schema["required"] = spec.required
+	}
+	return schema
+}
+
+// typeSchema maps a Go type to JSON Schema, following json tags the way
+// encoding/json does: omitempty fields are optional, "-" fields are dropped,
+// and embedded structs are flattened.
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		if seen == nil {
+			seen = make(map[reflect.Type]bool)
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		properties := make(map[string]interface{})
+		var required []string
+		addStructFields(t, seen, properties, &required)
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func addStructFields(t reflect.Type, seen map[reflect.Type]bool, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, omitempty := jsonName(f)
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			addStructFields(f.Type, seen, properties, required)
+			continue
+		}
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = typeSchema(f.Type, seen)
+		if !omitempty && f.Type.Kind() != reflect.Pointer {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// decodeArgs fills a new argument struct of spec from the tool call
+// arguments, rejecting unknown or missing arguments and values outside an
+// argument's enum. Enum values match case-insensitively.
+func decodeArgs(spec *tool, raw json.RawMessage) (reflect.Value, error) {
+	values := make(map[string]json.RawMessage)
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return reflect.Value{}, fmt.Errorf("arguments must be an object: %w", err)
+		}
+	}
+	for name, value := range values {
+		if string(value) == "null" {
+			delete(values, name)
+		}
+	}
+
+	known := make(map[string]bool, len(spec.fields))
+	for _, f := range spec.fields {
+		known[f.name] = true
+	}
+	for name := range values {
+		if !known[name] {
+			return reflect.Value{}, fmt.Errorf("unknown argument %q", name)
+		}
+	}
+	for _, name := range spec.required {
+		if _, ok := values[name]; !ok {
+			return reflect.Value{}, fmt.Errorf("missing required argument %q", name)
+		}
+	}
+
+	args := reflect.New(spec.args)
+	for _, f := range spec.fields {
+		value, ok := values[f.name]
+		if !ok {
+			continue
+		}
+		target := args.Elem().FieldByIndex(f.index)
+		if err := json.Unmarshal(value, target.Addr().Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid argument %q: %w", f.name, err)
+		}
+		if allowed := spec.enums[f.name]; len(allowed) > 0 {
+			if err := matchEnum(f.name, target, allowed); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+	}
+	return args, nil
+}
+
+// matchEnum checks a string (or pointer to or slice of strings) against
+// allowed and rewrites it to the canonical spelling.
+func matchEnum(name string, v reflect.Value, allowed []string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return matchEnum(name, v.Elem(), allowed)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := matchEnum(name, v.Index(i), allowed); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		value := strings.TrimSpace(v.String())
+		for _, candidate := range allowed {
+			if strings.EqualFold(value, candidate) {
+				v.SetString(candidate)
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid argument %q: %q must be one of %s", name, v.String(), strings.Join(allowed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/review"
+)
+
+func toolNamed(t *testing.T, name string) *tool {
+	t.Helper()
+	for _, candidate := range tools() {
+		if candidate.name == name {
+			return candidate
+		}
+	}
+	t.Fatalf("tool %s not defined", name)
+	return nil
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"ReviewID":             "review_id",
+		"IncludeCommentNodeID": "include_comment_node_id",
+		"OnlyUnresolved":       "only_unresolved",
+		"HTTPServer":           "http_server",
+		"Body":                 "body",
+	} {
+		assert.Equal(t, want, snakeCase(in), in)
+	}
+}
+
+func TestToolSchemasCoverServiceFields(t *testing.T) {
+	for _, spec := range tools() {
+		known := make(map[string]bool)
+		for _, f := range spec.fields {
+			known[f.name] = true
+		}
+		for _, name := range spec.required {
+			assert.True(t, known[name], "%s requires unknown argument %s", spec.name, name)
+		}
+		for name := range spec.enums {
+			assert.True(t, known[name], "%s has enum for unknown argument %s", spec.name, name)
+		}
+		for name := range spec.docs {
+			assert.True(t, known[name], "%s documents unknown argument %s", spec.name, name)
+		}
+		for name := range known {
+			assert.NotEmpty(t, spec.docs[name], "%s argument %s is undocumented", spec.name, name)
+		}
+	}
+
+	schema := inputSchema(toolNamed(t, "review_add_comment"))
+	properties := schema["properties"].(map[string]interface{})
+	for _, name := range []string{"repo", "pr", "url", "review_id", "path", "line", "side", "start_line", "start_side", "body", "file_level"} {
+		assert.Contains(t, properties, name)
+	}
+	assert.Len(t, properties, 11)
+	assert.Equal(t, []string{"path", "body"}, schema["required"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "description": "First line of a multi-line comment"}, properties["start_line"])
+	assert.Equal(t, sides, properties["side"].(map[string]interface{})["enum"])
+
+	view := inputSchema(toolNamed(t, "review_view"))["properties"].(map[string]interface{})
+	assert.NotContains(t, view, "states_provided")
+	assert.Equal(t, map[string]interface{}{"type": "string", "enum": reviewStates}, view["states"].(map[string]interface{})["items"])
+}
+
+func TestOutputSchemaFollowsJSONTags(t *testing.T) {
+	schema := typeSchema(reflect.TypeOf(review.ReviewState{}), nil)
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":           map[string]interface{}{"type": "string"},
+			"state":        map[string]interface{}{"type": "string"},
+			"submitted_at": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"id", "state"},
+	}, schema)
+}
+
+func TestDecodeArgs(t *testing.T) {
+	spec := toolNamed(t, "review_add_comment")
+
+	args, err := decodeArgs(spec, json.RawMessage(`{"url":"https://github.com/octo/demo/pull/7","path":"a.go","line":3,"side":"left","start_line":1,"body":"nit","review_id":null}`))
+	require.NoError(t, err)
+	decoded := args.Interface().(*addCommentArgs)
+	assert.Equal(t, "https://github.com/octo/demo/pull/7", decoded.URL)
+	assert.Equal(t, "a.go", decoded.Path)
+	assert.Equal(t, 3, decoded.Line)
+	assert.Equal(t, "LEFT", decoded.Side)
+	require.NotNil(t, decoded.StartLine)
+	assert.Equal(t, 1, *decoded.StartLine)
+	assert.Empty(t, decoded.ReviewID)
+
+	_, err = decodeArgs(spec, json.RawMessage(`{"path":"a.go","body":"x","colour":"red"}`))
+	assert.EqualError(t, err, `unknown argument "colour"`)
+
+	_, err = decodeArgs(spec, json.RawMessage(`{"path":"a.go"}`))
+	assert.EqualError(t, err, `missing required argument "body"`)
+
+	_, err = decodeArgs(spec, json.RawMessage(`{"path":"a.go","body":"x","side":"up"}`))
+	assert.EqualError(t, err, `invalid argument "side": "up" must be one of LEFT, RIGHT`)
+
+	_, err = decodeArgs(spec, json.RawMessage(`{"path":"a.go","body":"x","line":"3"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid argument "line"`)
+}
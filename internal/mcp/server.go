@@ -0,0 +1,242 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+// ServerName identifies the server in the initialize handshake.
+const ServerName = "gh-pr-review"
+
+// supportedVersions lists the protocol revisions the server speaks, newest
+// first.
+var supportedVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// maxMessageBytes bounds a single message; review bodies can be large.
+const maxMessageBytes = 16 << 20
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// Server answers Model Context Protocol requests over newline-delimited
+// JSON-RPC, exposing the review services as tools.
+type Server struct {
+	apiFor  func(host string) ghcli.API
+	version string
+	tools   map[string]*tool
+}
+
+// NewServer constructs a server whose tools reach GitHub through apiFor.
+func NewServer(apiFor func(host string) ghcli.API, version string) *Server {
+	s := &Server{apiFor: apiFor, version: version, tools: make(map[string]*tool)}
+	for _, t := range tools() {
+		s.tools[t.name] = t
+	}
+	return s
+}
+
+// Serve reads requests from in and writes responses to out, one JSON message
+// per line, until in is exhausted or ctx ends. Requests are handled in order.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	lines := make(chan []byte)
+	scanErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxMessageBytes)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	encoder := json.NewEncoder(out)
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case err := <-scanErr:
+			return err
+		case line := <-lines:
+			if len(strings.TrimSpace(string(line))) == 0 {
+				continue
+			}
+			if resp := s.handle(ctx, line); resp != nil {
+				if err := encoder.Encode(resp); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// handle answers one message; notifications get no response.
+func (s *Server) handle(ctx context.Context, line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	resp := &response{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: codeInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}
+		return resp
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		resp.Error = rpcErr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
+
+func (s *Server) initialize(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	version := supportedVersions[0]
+	for _, supported := range supportedVersions {
+		if p.ProtocolVersion == supported {
+			version = supported
+		}
+	}
+	return map[string]interface{}{
+		"protocolVersion": version,
+		"capabilities":    map[string]interface{}{"tools": map[string]interface{}{"listChanged": false}},
+		"serverInfo":      map[string]interface{}{"name": ServerName, "version": s.version},
+		"instructions": "Tools mirror the gh pr-review commands. Every tool takes the pull request as url, " +
+			"or repo plus pr, and falls back to the current branch's pull request. Use GraphQL node IDs: " +
+			"PRR_ for reviews, PRRT_ for threads, PRRC_ for review comments.",
+	}, nil
+}
+
+func (s *Server) listTools() interface{} {
+	names := make([]string, 0, len(s.tools))
+	for name := range s.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		t := s.tools[name]
+		entry := map[string]interface{}{
+			"name":        t.name,
+			"description": t.description,
+			"inputSchema": inputSchema(t),
+		}
+		if t.output != nil {
+			entry["outputSchema"] = typeSchema(t.output, nil)
+		}
+		list = append(list, entry)
+	}
+	return map[string]interface{}{"tools": list}
+}
+
+// callTool runs a tool. Problems with the arguments are protocol errors;
+// failures from GitHub or the services are returned as a tool result with
+// isError set so the model can read and correct them.
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, err
+	}
+	t, ok := s.tools[p.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool %q", p.Name)
+	}
+	args, err := decodeArgs(t, p.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := t.call(ctx, s, args.Interface())
+	if err != nil {
+		return toolResult{Content: []textContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	text, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return toolResult{Content: []textContent{{Type: "text", Text: string(text)}}, StructuredContent: result}, nil
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content           []textContent `json:"content"`
+	StructuredContent interface{}   `json:"structuredContent,omitempty"`
+	IsError           bool          `json:"isError,omitempty"`
+}
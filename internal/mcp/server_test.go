@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+type fakeAPI struct {
+	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
+}
+
+func (f *fakeAPI) REST(context.Context, string, string, map[string]string, interface{}, interface{}) error {
+	return errors.New("unexpected REST call")
+}
+
+func (f *fakeAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	if f.graphqlFunc == nil {
+		return errors.New("unexpected GraphQL call")
+	}
+	return f.graphqlFunc(query, variables, result)
+}
+
+// session sends each message as one line and returns the decoded responses.
+func session(t *testing.T, api ghcli.API, messages ...string) []map[string]interface{} {
+	t.Helper()
+	var hosts []string
+	server := NewServer(func(host string) ghcli.API {
+		hosts = append(hosts, host)
+		return api
+	}, "test")
+
+	var out bytes.Buffer
+	require.NoError(t, server.Serve(context.Background(), strings.NewReader(strings.Join(messages, "\n")+"\n"), &out))
+	for _, host := range hosts {
+		assert.Equal(t, "github.com", host)
+	}
+
+	var responses []map[string]interface{}
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &resp), scanner.Text())
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestServeHandshakeAndListTools(t *testing.T) {
+	responses := session(t, &fakeAPI{},
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+	)
+	require.Len(t, responses, 3, "notifications get no response")
+
+	init := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, "2025-03-26", init["protocolVersion"])
+	assert.Equal(t, map[string]interface{}{"name": ServerName, "version": "test"}, init["serverInfo"])
+
+	list := responses[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	var names []string
+	for _, entry := range list {
+		tool := entry.(map[string]interface{})
+		names = append(names, tool["name"].(string))
+		assert.Equal(t, "object", tool["inputSchema"].(map[string]interface{})["type"])
+		assert.Equal(t, "object", tool["outputSchema"].(map[string]interface{})["type"])
+	}
+	assert.Equal(t, []string{
+		"comments_reply",
+		"review_add_comment",
+		"review_delete_comment",
+		"review_edit_comment",
+		"review_preview",
+		"review_start",
+		"review_submit",
+		"review_view",
+		"threads_list",
+		"threads_resolve",
+		"threads_unresolve",
+	}, names)
+
+	assert.Equal(t, float64(3), responses[2]["id"])
+	assert.Equal(t, map[string]interface{}{}, responses[2]["result"])
+}
+
+func TestServeCallsTool(t *testing.T) {
+	api := &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Contains(t, query, "updatePullRequestReviewComment")
+		input := variables["input"].(map[string]interface{})
+		assert.Equal(t, "PRRC_1", input["pullRequestReviewCommentId"])
+		assert.Equal(t, "Body with \"quotes\" and\nnewlines", input["body"])
+		return nil
+	}}
+
+	responses := session(t, api,
+		`{"jsonrpc":"2.0","id":"a","method":"tools/call","params":{"name":"review_edit_comment","arguments":{"url":"https://github.com/octo/demo/pull/7","comment_id":"PRRC_1","body":"Body with \"quotes\" and\nnewlines"}}}`,
+	)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "a", responses[0]["id"])
+	result := responses[0]["result"].(map[string]interface{})
+	assert.NotContains(t, result, "isError")
+	assert.Equal(t, map[string]interface{}{"status": "Comment updated successfully"}, result["structuredContent"])
+	content := result["content"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "text", content["type"])
+	assert.JSONEq(t, `{"status":"Comment updated successfully"}`, content["text"].(string))
+}
+
+func TestServeReportsToolFailuresAsResults(t *testing.T) {
+	responses := session(t, &fakeAPI{},
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"review_delete_comment","arguments":{"url":"https://github.com/octo/demo/pull/7","comment_id":"IC_1"}}}`,
+	)
+	result := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, true, result["isError"])
+	assert.NotContains(t, result, "structuredContent")
+	content := result["content"].([]interface{})[0].(map[string]interface{})
+	assert.Contains(t, content["text"], `invalid comment id "IC_1"`)
+}
+
+func TestServeProtocolErrors(t *testing.T) {
+	responses := session(t, &fakeAPI{},
+		`{not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"nope"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"threads_resolve","arguments":{"url":"https://github.com/octo/demo/pull/7"}}}`,
+		`{"id":4,"method":"ping"}`,
+	)
+	require.Len(t, responses, 5)
+
+	code := func(i int) float64 {
+		return responses[i]["error"].(map[string]interface{})["code"].(float64)
+	}
+	assert.Nil(t, responses[0]["id"])
+	assert.Equal(t, float64(codeParseError), code(0))
+	assert.Equal(t, float64(codeMethodNotFound), code(1))
+	assert.Equal(t, float64(codeInvalidParams), code(2))
+	assert.Equal(t, float64(codeInvalidParams), code(3))
+	assert.Contains(t, responses[3]["error"].(map[string]interface{})["message"], `missing required argument "thread_id"`)
+	assert.Equal(t, float64(codeInvalidRequest), code(4))
+}
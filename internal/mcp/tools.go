@@ -0,0 +1,370 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/comments"
+	"github.com/agynio/gh-pr-review/internal/preview"
+	"github.com/agynio/gh-pr-review/internal/report"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/review"
+	"github.com/agynio/gh-pr-review/internal/threads"
+)
+
+// tool is one MCP tool. Its input schema is derived from the argument struct,
+// which embeds the service's own option struct, and its output schema from
+// the service's result type.
+type tool struct {
+	name        string
+	description string
+	args        reflect.Type
+	fields      []field
+	required    []string
+	docs        map[string]string
+	enums       map[string][]string
+	output      reflect.Type
+	call        func(ctx context.Context, s *Server, args interface{}) (interface{}, error)
+}
+
+// argSpec annotates the arguments derived from a struct.
+type argSpec struct {
+	required []string
+	docs     map[string]string
+	enums    map[string][]string
+	// skip lists Go field names that are set by the tool, not the caller.
+	skip []string
+}
+
+// pullRequestArgs selects the pull request, like the CLI's selector argument
+// and -R flag. Every argument struct embeds it.
+type pullRequestArgs struct {
+	Repo string `json:"repo"`
+	PR   int    `json:"pr"`
+	URL  string `json:"url"`
+}
+
+func (p *pullRequestArgs) pullRequest() pullRequestArgs { return *p }
+
+var pullRequestDocs = map[string]string{
+	"repo": "Repository in owner/repo format; inferred from the working directory when omitted",
+	"pr":   "Pull request number",
+	"url":  "Pull request URL, instead of repo and pr",
+}
+
+// define builds a tool whose call receives the decoded arguments and the
+// resolved pull request.
+func define[A any, R any](name, description string, spec argSpec, call func(ctx context.Context, s *Server, pr resolver.Identity, args *A) (R, error)) *tool {
+	argsType := reflect.TypeOf((*A)(nil)).Elem()
+	skip := make(map[string]bool, len(spec.skip))
+	for _, name := range spec.skip {
+		skip[name] = true
+	}
+	docs := make(map[string]string, len(pullRequestDocs)+len(spec.docs))
+	for k, v := range pullRequestDocs {
+		docs[k] = v
+	}
+	for k, v := range spec.docs {
+		docs[k] = v
+	}
+	output := reflect.TypeOf((*R)(nil)).Elem()
+	if output.Kind() == reflect.Pointer {
+		output = output.Elem()
+	}
+
+	t := &tool{
+		name:        name,
+		description: description,
+		args:        argsType,
+		fields:      argFields(argsType, skip),
+		required:    spec.required,
+		docs:        docs,
+		enums:       spec.enums,
+		output:      output,
+	}
+	t.call = func(ctx context.Context, s *Server, raw interface{}) (interface{}, error) {
+		args := raw.(*A)
+		selector := any(args).(interface{ pullRequest() pullRequestArgs }).pullRequest()
+		pr, err := s.resolve(ctx, selector)
+		if err != nil {
+			return nil, err
+		}
+		return call(ctx, s, pr, args)
+	}
+	return t
+}
+
+func (s *Server) resolve(ctx context.Context, p pullRequestArgs) (resolver.Identity, error) {
+	selector, err := resolver.NormalizeSelector(p.URL, p.PR)
+	if err != nil {
+		return resolver.Identity{}, err
+	}
+	host := os.Getenv("GH_HOST")
+	if selector == "" {
+		return resolver.ResolveCurrentBranch(ctx, p.Repo, host, s.apiFor)
+	}
+	return resolver.Resolve(selector, p.Repo, host)
+}
+
+// statusResult mirrors the CLI output of commands that return no data.
+type statusResult struct {
+	Status string `json:"status"`
+}
+
+// threadList wraps threads.List so the tool result is an object.
+type threadList struct {
+	Threads []threads.Thread `json:"threads"`
+}
+
+type startArgs struct {
+	pullRequestArgs
+	Commit string `json:"commit"`
+}
+
+type addCommentArgs struct {
+	pullRequestArgs
+	review.ThreadInput
+}
+
+type submitArgs struct {
+	pullRequestArgs
+	review.SubmitInput
+}
+
+type editCommentArgs struct {
+	pullRequestArgs
+	review.UpdateCommentInput
+}
+
+type deleteCommentArgs struct {
+	pullRequestArgs
+	review.DeleteCommentInput
+}
+
+type listThreadsArgs struct {
+	pullRequestArgs
+	threads.ListOptions
+}
+
+type threadActionArgs struct {
+	pullRequestArgs
+	threads.ActionOptions
+}
+
+type replyArgs struct {
+	pullRequestArgs
+	comments.ReplyOptions
+}
+
+type viewArgs struct {
+	pullRequestArgs
+	report.Options
+}
+
+type previewArgs struct {
+	pullRequestArgs
+	preview.PreviewOptions
+}
+
+var (
+	sides        = []string{"LEFT", "RIGHT"}
+	submitEvents = []string{"APPROVE", "COMMENT", "REQUEST_CHANGES"}
+	reviewStates = []string{
+		string(report.StateApproved),
+		string(report.StateChangesRequested),
+		string(report.StateCommented),
+		string(report.StateDismissed),
+		string(report.StatePending),
+	}
+)
+
+// pendingReviewID defaults an empty review ID to the viewer's pending review.
+func pendingReviewID(ctx context.Context, service *review.Service, pr resolver.Identity, reviewID string) (string, error) {
+	if strings.TrimSpace(reviewID) != "" {
+		return reviewID, nil
+	}
+	pending, err := service.SolePending(ctx, pr)
+	if err != nil {
+		return "", fmt.Errorf("review_id not given: %w", err)
+	}
+	return pending.ID, nil
+}
+
+func tools() []*tool {
+	return []*tool{
+		define("review_start", "Open a pending review on the pull request and return its PRR_ node ID.",
+			argSpec{docs: map[string]string{"commit": "Commit SHA to review; defaults to the pull request head"}},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *startArgs) (*review.ReviewState, error) {
+				return review.NewService(s.apiFor(pr.Host)).Start(ctx, pr, strings.TrimSpace(args.Commit))
+			}),
+
+		define("review_add_comment", "Add an inline comment thread to a pending review.",
+			argSpec{
+				required: []string{"path", "body"},
+				docs: map[string]string{
+					"review_id":  "Pending review node ID (PRR_...); defaults to your pending review",
+					"path":       "File path relative to the repository root",
+					"line":       "Line to comment on, on the given side; omit for file_level",
+					"side":       "Diff side of line; defaults to RIGHT",
+					"start_line": "First line of a multi-line comment",
+					"start_side": "Diff side of start_line",
+					"body":       "Comment body (Markdown)",
+					"file_level": "Comment on the whole file instead of a line",
+				},
+				enums: map[string][]string{"side": sides, "start_side": sides},
+			},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *addCommentArgs) (*review.ReviewThread, error) {
+				service := review.NewService(s.apiFor(pr.Host))
+				input := args.ThreadInput
+				reviewID, err := pendingReviewID(ctx, service, pr, input.ReviewID)
+				if err != nil {
+					return nil, err
+				}
+				input.ReviewID = reviewID
+				if !input.FileLevel && input.Side == "" {
+					input.Side = "RIGHT"
+				}
+				return service.AddThread(ctx, pr, input)
+			}),
+
+		define("review_submit", "Submit a pending review.",
+			argSpec{
+				docs: map[string]string{
+					"review_id": "Pending review node ID (PRR_...); defaults to your pending review",
+					"event":     "Submission event; defaults to COMMENT",
+					"body":      "Review summary body (Markdown)",
+				},
+				enums: map[string][]string{"event": submitEvents},
+			},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *submitArgs) (statusResult, error) {
+				service := review.NewService(s.apiFor(pr.Host))
+				input := args.SubmitInput
+				reviewID, err := pendingReviewID(ctx, service, pr, input.ReviewID)
+				if err != nil {
+					return statusResult{}, err
+				}
+				input.ReviewID = reviewID
+				if input.Event == "" {
+					input.Event = "COMMENT"
+				}
+				status, err := service.Submit(ctx, pr, input)
+				if err != nil {
+					return statusResult{}, err
+				}
+				if !status.Success {
+					messages := make([]string, 0, len(status.Errors))
+					for _, entry := range status.Errors {
+						messages = append(messages, entry.Message)
+					}
+					return statusResult{}, fmt.Errorf("review submission failed: %s", strings.Join(messages, "; "))
+				}
+				return statusResult{Status: "Review submitted successfully"}, nil
+			}),
+
+		define("review_edit_comment", "Replace the body of a review comment.",
+			argSpec{
+				required: []string{"comment_id", "body"},
+				docs: map[string]string{
+					"comment_id": "Review comment node ID (PRRC_...)",
+					"body":       "New comment body (Markdown)",
+				},
+			},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *editCommentArgs) (statusResult, error) {
+				if err := review.NewService(s.apiFor(pr.Host)).UpdateComment(ctx, pr, args.UpdateCommentInput); err != nil {
+					return statusResult{}, err
+				}
+				return statusResult{Status: "Comment updated successfully"}, nil
+			}),
+
+		define("review_delete_comment", "Delete a comment from a pending review.",
+			argSpec{
+				required: []string{"comment_id"},
+				docs:     map[string]string{"comment_id": "Review comment node ID (PRRC_...)"},
+			},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *deleteCommentArgs) (statusResult, error) {
+				if err := review.NewService(s.apiFor(pr.Host)).DeleteComment(ctx, pr, args.DeleteCommentInput); err != nil {
+					return statusResult{}, err
+				}
+				return statusResult{Status: "Comment deleted successfully"}, nil
+			}),
+
+		define("review_view", "Read the reviews, inline comment threads, and replies of the pull request.",
+			argSpec{
+				docs: map[string]string{
+					"reviewer":                "Only include reviews by this login",
+					"states":                  "Only include reviews in these states",
+					"require_unresolved":      "Keep only unresolved threads",
+					"require_not_outdated":    "Drop outdated threads",
+					"tail_replies":            "Keep only the last n replies per thread (0 = all)",
+					"include_comment_node_id": "Add comment node IDs (PRRC_...) to comments and replies",
+					"include_reactions":       "Add reaction counts to comments and replies",
+					"hide_minimized":          "Drop minimized replies and threads whose first comment is minimized",
+				},
+				enums: map[string][]string{"states": reviewStates},
+				skip:  []string{"StatesProvided"},
+			},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *viewArgs) (report.Report, error) {
+				opts := args.Options
+				opts.StatesProvided = len(opts.States) > 0
+				if opts.TailReplies < 0 {
+					return report.Report{}, errors.New("tail_replies must be non-negative")
+				}
+				return report.NewService(s.apiFor(pr.Host)).Fetch(ctx, pr, opts)
+			}),
+
+		define("review_preview", "Show a pending review's comments with the code they are attached to.",
+			argSpec{docs: map[string]string{
+				"review_id": "Pending review node ID (PRR_...); defaults to your pending review",
+				"thread_id": "Only show the comment of this thread (PRRT_...)",
+			}},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *previewArgs) (*preview.PreviewResult, error) {
+				return preview.NewService(s.apiFor(pr.Host)).Preview(ctx, pr, args.PreviewOptions)
+			}),
+
+		define("threads_list", "List the review threads of the pull request.",
+			argSpec{docs: map[string]string{
+				"only_unresolved": "Only list unresolved threads",
+				"mine_only":       "Only list threads involving or resolvable by you",
+			}},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *listThreadsArgs) (threadList, error) {
+				list, err := threads.NewService(s.apiFor(pr.Host)).List(ctx, pr, args.ListOptions)
+				if err != nil {
+					return threadList{}, err
+				}
+				if list == nil {
+					list = []threads.Thread{}
+				}
+				return threadList{Threads: list}, nil
+			}),
+
+		define("threads_resolve", "Resolve a review thread.",
+			argSpec{required: []string{"thread_id"}, docs: map[string]string{"thread_id": "Review thread node ID (PRRT_...)"}},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *threadActionArgs) (threads.ActionResult, error) {
+				return threads.NewService(s.apiFor(pr.Host)).Resolve(ctx, pr, args.ActionOptions)
+			}),
+
+		define("threads_unresolve", "Reopen a resolved review thread.",
+			argSpec{required: []string{"thread_id"}, docs: map[string]string{"thread_id": "Review thread node ID (PRRT_...)"}},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *threadActionArgs) (threads.ActionResult, error) {
+				return threads.NewService(s.apiFor(pr.Host)).Unresolve(ctx, pr, args.ActionOptions)
+			}),
+
+		define("comments_reply", "Reply to a review thread.",
+			argSpec{
+				required: []string{"thread_id", "body"},
+				docs: map[string]string{
+					"thread_id": "Review thread node ID (PRRT_...)",
+					"review_id": "Pending review node ID (PRR_...) to add the reply to instead of posting it immediately",
+					"body":      "Reply body (Markdown)",
+					"quote_id":  "Comment node ID to quote above the reply",
+				},
+			},
+			func(ctx context.Context, s *Server, pr resolver.Identity, args *replyArgs) (comments.Reply, error) {
+				return comments.NewService(s.apiFor(pr.Host)).Reply(ctx, pr, args.ReplyOptions)
+			}),
+	}
+}
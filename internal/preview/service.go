@@ -1,11 +1,12 @@
 package preview
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 
+	"github.com/agynio/gh-pr-review/internal/diff"
 	"github.com/agynio/gh-pr-review/internal/ghcli"
 	"github.com/agynio/gh-pr-review/internal/resolver"
 )
@@ -42,23 +43,40 @@ type PreviewResult struct {
 	Comments      []CommentPreview `json:"comments"`
 }
 
-// Preview fetches the current user's pending review with code context.
-// If threadID is non-empty, only the comment from the matching thread is returned.
-func (s *Service) Preview(pr resolver.Identity, threadID string) (*PreviewResult, error) {
-	// Get current viewer
-	viewer, err := s.currentViewer()
-	if err != nil {
-		return nil, err
+// PreviewOptions selects what Preview returns.
+type PreviewOptions struct {
+	// ReviewID targets a specific pending review (PRR_...). When empty the
+	// current user's pending review is used.
+	ReviewID string
+	// ThreadID restricts the result to the comment from one thread.
+	ThreadID string
+}
+
+// Preview fetches a pending review with code context, defaulting to the
+// current user's. If opts.ThreadID is non-empty, only the comment from the
+// matching thread is returned.
+func (s *Service) Preview(ctx context.Context, pr resolver.Identity, opts PreviewOptions) (*PreviewResult, error) {
+	threadID := opts.ThreadID
+	match := pendingReviewMatcher{reviewID: opts.ReviewID}
+	if match.reviewID == "" {
+		viewer, err := s.currentViewer(ctx)
+		if err != nil {
+			return nil, err
+		}
+		match.viewer = viewer
 	}
 
-	// Fetch review threads and find pending review for viewer
-	review, threads, err := s.fetchPendingReviewThreads(pr, viewer)
+	// Fetch review threads and find the matching pending review
+	review, threads, err := s.fetchPendingReviewThreads(ctx, pr, match)
 	if err != nil {
 		return nil, err
 	}
 
 	if review == nil {
-		return nil, fmt.Errorf("no pending review found for %s", viewer)
+		if match.reviewID != "" {
+			return nil, fmt.Errorf("no pending comments found for review %s", match.reviewID)
+		}
+		return nil, fmt.Errorf("no pending review found for %s", match.viewer)
 	}
 
 	if len(threads) == 0 {
@@ -72,7 +90,7 @@ func (s *Service) Preview(pr resolver.Identity, threadID string) (*PreviewResult
 	}
 
 	// Fetch PR file patches for context resolution
-	patches, err := s.fetchFilePatches(pr)
+	patches, err := s.fetchFilePatches(ctx, pr)
 	if err != nil {
 		// Non-fatal: we can still return the preview without code context
 		patches = make(map[string]string)
@@ -111,8 +129,7 @@ func (s *Service) Preview(pr resolver.Identity, threadID string) (*PreviewResult
 
 		// Extract code context from patch if available
 		if _, ok := patches[thread.Path]; ok && !thread.IsOutdated {
-			context := s.extractCodeContext(thread)
-			preview.CodeContext = context
+			preview.CodeContext = s.extractCodeContext(thread)
 		}
 
 		comments = append(comments, preview)
@@ -144,6 +161,23 @@ func (s *Service) Preview(pr resolver.Identity, threadID string) (*PreviewResult
 	return result, nil
 }
 
+// pendingReviewMatcher selects pending review comments either by review id or
+// by the review author's login.
+type pendingReviewMatcher struct {
+	reviewID string
+	viewer   string
+}
+
+func (m pendingReviewMatcher) matches(reviewID, author, state string) bool {
+	if state != "PENDING" {
+		return false
+	}
+	if m.reviewID != "" {
+		return reviewID == m.reviewID
+	}
+	return strings.EqualFold(author, m.viewer)
+}
+
 // reviewInfo holds basic review information.
 type reviewInfo struct {
 	ID         string
@@ -174,7 +208,7 @@ type commentInfo struct {
 	Author     string
 }
 
-func (s *Service) currentViewer() (string, error) {
+func (s *Service) currentViewer(ctx context.Context) (string, error) {
 	const query = `query { viewer { login } }`
 
 	var response struct {
@@ -183,7 +217,7 @@ func (s *Service) currentViewer() (string, error) {
 		} `json:"viewer"`
 	}
 
-	if err := s.API.GraphQL(query, nil, &response); err != nil {
+	if err := s.API.GraphQL(ctx, query, nil, &response); err != nil {
 		return "", err
 	}
 
@@ -195,7 +229,7 @@ func (s *Service) currentViewer() (string, error) {
 	return login, nil
 }
 
-func (s *Service) fetchPendingReviewThreads(pr resolver.Identity, viewer string) (*reviewInfo, []threadInfo, error) {
+func (s *Service) fetchPendingReviewThreads(ctx context.Context, pr resolver.Identity, match pendingReviewMatcher) (*reviewInfo, []threadInfo, error) {
 	variables := map[string]interface{}{
 		"owner":    pr.Owner,
 		"name":     pr.Repo,
@@ -246,7 +280,7 @@ func (s *Service) fetchPendingReviewThreads(pr resolver.Identity, viewer string)
 		} `json:"repository"`
 	}
 
-	if err := s.API.GraphQL(reviewThreadsQuery, variables, &response); err != nil {
+	if err := s.API.GraphQL(ctx, reviewThreadsQuery, variables, &response); err != nil {
 		return nil, nil, err
 	}
 
@@ -255,7 +289,7 @@ func (s *Service) fetchPendingReviewThreads(pr resolver.Identity, viewer string)
 		return nil, nil, fmt.Errorf("pull request %s/%s#%d not found", pr.Owner, pr.Repo, pr.Number)
 	}
 
-	// Find threads belonging to the current viewer's pending review
+	// Find threads belonging to the matching pending review
 	var pendingReview *reviewInfo
 	var threads []threadInfo
 
@@ -272,8 +306,8 @@ func (s *Service) fetchPendingReviewThreads(pr resolver.Identity, viewer string)
 				author = review.Author.Login
 			}
 
-			// Only process threads from the current viewer's pending review
-			if !strings.EqualFold(author, viewer) || review.State != "PENDING" {
+			// Only process threads from the matching pending review
+			if !match.matches(review.ID, author, review.State) {
 				continue
 			}
 
@@ -323,21 +357,15 @@ func (s *Service) fetchPendingReviewThreads(pr resolver.Identity, viewer string)
 }
 
 // fetchFilePatches retrieves file patches for the PR via REST API.
-func (s *Service) fetchFilePatches(pr resolver.Identity) (map[string]string, error) {
-	path := fmt.Sprintf("repos/%s/%s/pulls/%d/files", pr.Owner, pr.Repo, pr.Number)
-
-	var files []struct {
-		Filename string `json:"filename"`
-		Patch    string `json:"patch"`
-	}
-
-	if err := s.API.REST("GET", path, nil, nil, &files); err != nil {
+func (s *Service) fetchFilePatches(ctx context.Context, pr resolver.Identity) (map[string]string, error) {
+	files, err := diff.NewService(s.API).Files(ctx, pr)
+	if err != nil {
 		return nil, err
 	}
 
 	patches := make(map[string]string, len(files))
 	for _, f := range files {
-		patches[f.Filename] = f.Patch
+		patches[f.Path] = f.Patch
 	}
 
 	return patches, nil
@@ -357,22 +385,20 @@ func (s *Service) extractCodeContext(thread threadInfo) []string {
 	}
 
 	// Determine target line range based on side
-	targetLine := thread.Line
-	startLine := targetLine
 	if thread.DiffSide == "LEFT" {
-		targetLine = thread.OriginalLine
-		startLine = targetLine
-		if thread.OriginalStartLine > 0 && thread.OriginalStartLine < targetLine {
-			startLine = thread.OriginalStartLine
-		}
-	} else {
-		if thread.StartLine > 0 && thread.StartLine < targetLine {
-			startLine = thread.StartLine
-		}
+		return CodeContext(diffHunk, thread.DiffSide, thread.OriginalStartLine, thread.OriginalLine)
 	}
+	return CodeContext(diffHunk, thread.DiffSide, thread.StartLine, thread.Line)
+}
 
-	// Parse diffHunk to extract relevant lines
-	return parseDiffHunk(diffHunk, startLine, targetLine, thread.DiffSide)
+// CodeContext returns lines startLine..line of a comment's diff hunk on side,
+// each prefixed with its line number. A startLine outside the range selects
+// just line.
+func CodeContext(diffHunk, side string, startLine, line int) []string {
+	if startLine <= 0 || startLine >= line {
+		startLine = line
+	}
+	return parseDiffHunk(diffHunk, startLine, line, side)
 }
 
 // parseDiffHunk parses a diff hunk and extracts lines for the given range.
@@ -393,7 +419,7 @@ func parseDiffHunk(diffHunk string, startLine, targetLine int, side string) []st
 		if strings.HasPrefix(line, "@@") {
 			inHunk = true
 			// Extract starting line numbers using a more robust approach
-			oldStart, newStart := parseHunkHeader(line)
+			oldStart, newStart := diff.ParseHunkHeader(line)
 			oldLine = oldStart
 			newLine = newStart
 			continue
@@ -460,41 +486,3 @@ func parseDiffHunk(diffHunk string, startLine, targetLine int, side string) []st
 
 	return result
 }
-
-// parseHunkHeader extracts the starting line numbers from a hunk header.
-// Handles formats like "@@ -1,5 +1,6 @@" or "@@ -1 +1 @@"
-func parseHunkHeader(header string) (oldStart, newStart int) {
-	// Remove the @@ markers
-	content := strings.TrimSpace(strings.TrimPrefix(strings.TrimSuffix(header, "@@"), "@@"))
-
-	// Parse the old file info
-	oldIdx := strings.Index(content, "-")
-	if oldIdx == -1 {
-		return 0, 0
-	}
-	content = content[oldIdx+1:]
-
-	// Find the comma or space for old file
-	oldEnd := strings.IndexAny(content, ", ")
-	if oldEnd == -1 {
-		return 0, 0
-	}
-	oldStart, _ = strconv.Atoi(content[:oldEnd])
-
-	// Parse the new file info
-	newIdx := strings.Index(content, "+")
-	if newIdx == -1 {
-		return 0, 0
-	}
-	content = content[newIdx+1:]
-
-	// Find the comma or space for new file
-	newEnd := strings.IndexAny(content, ", ")
-	if newEnd == -1 {
-		newStart, _ = strconv.Atoi(content)
-	} else {
-		newStart, _ = strconv.Atoi(content[:newEnd])
-	}
-
-	return oldStart, newStart
-}
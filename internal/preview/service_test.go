@@ -221,3 +221,21 @@ func TestThreadInfo_LineSelection(t *testing.T) {
 		t.Errorf("expected StartLine=15, got %d", rightThread.StartLine)
 	}
 }
+
+func TestPendingReviewMatcher(t *testing.T) {
+	byViewer := pendingReviewMatcher{viewer: "casey"}
+	if !byViewer.matches("PRR_a", "Casey", "PENDING") {
+		t.Error("expected viewer match to be case-insensitive")
+	}
+	if byViewer.matches("PRR_a", "casey", "COMMENTED") {
+		t.Error("expected submitted reviews to be skipped")
+	}
+
+	byID := pendingReviewMatcher{reviewID: "PRR_b"}
+	if !byID.matches("PRR_b", "octocat", "PENDING") {
+		t.Error("expected review id match regardless of author")
+	}
+	if byID.matches("PRR_a", "octocat", "PENDING") {
+		t.Error("expected other reviews to be skipped")
+	}
+}
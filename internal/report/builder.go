@@ -74,11 +74,17 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 				}
 				continue
 			}
+			if filters.HideMinimized && comment.IsMinimized {
+				continue
+			}
 			replies = append(replies, comment)
 		}
 		if parent == nil || parent.ReviewDatabaseID == nil {
 			continue
 		}
+		if filters.HideMinimized && parent.IsMinimized {
+			continue
+		}
 
 		reviewIdx, ok := reviewIndexByID[*parent.ReviewDatabaseID]
 		if !ok {
@@ -102,10 +108,15 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 				commentNodeID = &replyID
 			}
 			reportReplies[i] = ThreadReply{
-				CommentNodeID: commentNodeID,
-				AuthorLogin:   reply.AuthorLogin,
-				Body:          reply.Body,
-				CreatedAt:     createdAt,
+				CommentNodeID:   commentNodeID,
+				AuthorLogin:     reply.AuthorLogin,
+				Body:            reply.Body,
+				CreatedAt:       createdAt,
+				IsMinimized:     reply.IsMinimized,
+				MinimizedReason: reply.MinimizedReason,
+			}
+			if filters.IncludeReactions {
+				reportReplies[i].Reactions = reply.Reactions
 			}
 		}
 
@@ -116,21 +127,27 @@ func BuildReport(reviews []Review, threads []Thread, filters FilterOptions) Repo
 			commentNodeID = &id
 		}
 		reportComment := ReportComment{
-			ThreadID:       thread.ID,
-			CommentNodeID:  commentNodeID,
-			Path:           thread.Path,
-			Line:           thread.Line,
-			AuthorLogin:    parent.AuthorLogin,
-			Body:           parent.Body,
-			CreatedAt:      createdAt,
-			IsResolved:     thread.IsResolved,
-			IsOutdated:     thread.IsOutdated,
-			ThreadComments: reportReplies,
+			ThreadID:        thread.ID,
+			CommentNodeID:   commentNodeID,
+			Path:            thread.Path,
+			Line:            thread.Line,
+			SubjectType:     thread.SubjectType,
+			AuthorLogin:     parent.AuthorLogin,
+			Body:            parent.Body,
+			CreatedAt:       createdAt,
+			IsResolved:      thread.IsResolved,
+			IsOutdated:      thread.IsOutdated,
+			IsMinimized:     parent.IsMinimized,
+			MinimizedReason: parent.MinimizedReason,
+			ThreadComments:  reportReplies,
 		}
 
 		if len(reportReplies) == 0 {
 			reportComment.ThreadComments = []ThreadReply{}
 		}
+		if filters.IncludeReactions {
+			reportComment.Reactions = parent.Reactions
+		}
 
 		review := &reportReviews[reviewIdx]
 		review.Comments = append(review.Comments, reportComment)
@@ -71,11 +71,12 @@ func TestBuildReportAggregatesThreads(t *testing.T) {
 	}
 
 	threadNoReplies := report.Thread{
-		ID:         "T2",
-		Path:       "main.go",
-		Line:       nil,
-		IsResolved: true,
-		IsOutdated: false,
+		ID:          "T2",
+		Path:        "main.go",
+		Line:        nil,
+		SubjectType: "FILE",
+		IsResolved:  true,
+		IsOutdated:  false,
 		Comments: []report.ThreadComment{
 			{
 				NodeID:             "C401",
@@ -132,6 +133,9 @@ func TestBuildReportAggregatesThreads(t *testing.T) {
 	if len(noReplyComment.ThreadComments) != 0 {
 		t.Fatalf("expected no replies for comment 401, got %d", len(noReplyComment.ThreadComments))
 	}
+	if noReplyComment.SubjectType != "FILE" {
+		t.Fatalf("expected subject_type FILE for T2, got %q", noReplyComment.SubjectType)
+	}
 
 	second := result.Reviews[1]
 	if second.Body != nil {
@@ -239,6 +243,50 @@ func TestBuildReportFilterOptions(t *testing.T) {
 	}
 }
 
+func TestBuildReportHideMinimized(t *testing.T) {
+	reviews := []report.Review{{ID: "R1", State: report.StateCommented, AuthorLogin: "alice", DatabaseID: 1}}
+	at := func(minute int) time.Time { return time.Date(2025, 12, 3, 0, minute, 0, 0, time.UTC) }
+	threads := []report.Thread{
+		{
+			ID:   "T1",
+			Path: "file.go",
+			Comments: []report.ThreadComment{
+				{NodeID: "C10", DatabaseID: 10, Body: "Parent", CreatedAt: at(0), AuthorLogin: "alice", ReviewDatabaseID: intPtr(1)},
+				{NodeID: "C11", DatabaseID: 11, Body: "Spam", CreatedAt: at(1), AuthorLogin: "bot", ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(10), IsMinimized: true, MinimizedReason: "SPAM"},
+				{NodeID: "C12", DatabaseID: 12, Body: "Reply", CreatedAt: at(2), AuthorLogin: "bob", ReviewDatabaseID: intPtr(1), ReplyToDatabaseID: intPtr(10)},
+			},
+		},
+		{
+			ID:   "T2",
+			Path: "file.go",
+			Comments: []report.ThreadComment{
+				{NodeID: "C20", DatabaseID: 20, Body: "Off topic", CreatedAt: at(3), AuthorLogin: "alice", ReviewDatabaseID: intPtr(1), IsMinimized: true, MinimizedReason: "OFF_TOPIC"},
+			},
+		},
+	}
+
+	result := report.BuildReport(reviews, threads, report.FilterOptions{})
+	comments := result.Reviews[0].Comments
+	if len(comments) != 2 {
+		t.Fatalf("expected minimized comments kept by default, got %d threads", len(comments))
+	}
+	if hidden := mustFindComment(comments, "T2"); !hidden.IsMinimized || hidden.MinimizedReason != "OFF_TOPIC" {
+		t.Fatalf("expected T2 marked minimized, got %+v", hidden)
+	}
+	if reply := mustFindComment(comments, "T1").ThreadComments[0]; !reply.IsMinimized || reply.MinimizedReason != "SPAM" {
+		t.Fatalf("expected spam reply marked minimized, got %+v", reply)
+	}
+
+	result = report.BuildReport(reviews, threads, report.FilterOptions{HideMinimized: true})
+	comments = result.Reviews[0].Comments
+	if len(comments) != 1 || comments[0].ThreadID != "T1" {
+		t.Fatalf("expected only T1 with --hide-minimized, got %+v", comments)
+	}
+	if replies := comments[0].ThreadComments; len(replies) != 1 || replies[0].Body != "Reply" {
+		t.Fatalf("expected minimized reply dropped, got %+v", replies)
+	}
+}
+
 func intPtr(v int) *int {
 	return &v
 }
@@ -21,6 +21,8 @@ type FilterOptions struct {
 	RequireNotOutdated   bool
 	TailReplies          int
 	IncludeCommentNodeID bool
+	IncludeReactions     bool
+	HideMinimized        bool
 }
 
 // Review models a pull request review fetched from GraphQL.
@@ -33,14 +35,18 @@ type Review struct {
 	DatabaseID  int
 }
 
-// Thread captures a review thread and its constituent comments.
+// Thread captures a review thread and its constituent comments. SubjectType
+// is LINE for line comments and FILE for comments on the whole file.
 type Thread struct {
-	ID         string
-	Path       string
-	Line       *int
-	IsResolved bool
-	IsOutdated bool
-	Comments   []ThreadComment
+	ID          string
+	Path        string
+	Line        *int
+	StartLine   *int
+	DiffSide    string
+	SubjectType string
+	IsResolved  bool
+	IsOutdated  bool
+	Comments    []ThreadComment
 }
 
 // ThreadComment represents a single comment node within a thread.
@@ -53,11 +59,24 @@ type ThreadComment struct {
 	ReviewDatabaseID   *int
 	ReplyToDatabaseID  *int
 	ReplyToCommentNode *string
+	Reactions          []Reaction
+	IsMinimized        bool
+	MinimizedReason    string
+}
+
+// Reaction aggregates one kind of emoji reaction on a comment.
+type Reaction struct {
+	Content          string `json:"content"`
+	Count            int    `json:"count"`
+	ViewerHasReacted bool   `json:"viewer_has_reacted"`
 }
 
 // Report is the serialized output structure for the report command.
+// Truncated is set when pagination stopped before every review, thread, or
+// comment was fetched, signalling that the report is incomplete.
 type Report struct {
-	Reviews []ReportReview `json:"reviews"`
+	Reviews   []ReportReview `json:"reviews"`
+	Truncated bool           `json:"truncated,omitempty"`
 }
 
 // ReportReview aggregates review data and associated thread comments.
@@ -72,22 +91,29 @@ type ReportReview struct {
 
 // ReportComment contains the shaped parent comment for a thread.
 type ReportComment struct {
-	ThreadID       string        `json:"thread_id"`
-	CommentNodeID  *string       `json:"comment_node_id,omitempty"`
-	Path           string        `json:"path"`
-	Line           *int          `json:"line,omitempty"`
-	AuthorLogin    string        `json:"author_login"`
-	Body           string        `json:"body"`
-	CreatedAt      string        `json:"created_at"`
-	IsResolved     bool          `json:"is_resolved"`
-	IsOutdated     bool          `json:"is_outdated"`
-	ThreadComments []ThreadReply `json:"thread_comments"`
+	ThreadID        string        `json:"thread_id"`
+	CommentNodeID   *string       `json:"comment_node_id,omitempty"`
+	Path            string        `json:"path"`
+	Line            *int          `json:"line,omitempty"`
+	SubjectType     string        `json:"subject_type,omitempty"`
+	AuthorLogin     string        `json:"author_login"`
+	Body            string        `json:"body"`
+	CreatedAt       string        `json:"created_at"`
+	IsResolved      bool          `json:"is_resolved"`
+	IsOutdated      bool          `json:"is_outdated"`
+	IsMinimized     bool          `json:"is_minimized,omitempty"`
+	MinimizedReason string        `json:"minimized_reason,omitempty"`
+	Reactions       []Reaction    `json:"reactions,omitempty"`
+	ThreadComments  []ThreadReply `json:"thread_comments"`
 }
 
 // ThreadReply captures a reply within a thread.
 type ThreadReply struct {
-	CommentNodeID *string `json:"comment_node_id,omitempty"`
-	AuthorLogin   string  `json:"author_login"`
-	Body          string  `json:"body"`
-	CreatedAt     string  `json:"created_at"`
+	CommentNodeID   *string    `json:"comment_node_id,omitempty"`
+	AuthorLogin     string     `json:"author_login"`
+	Body            string     `json:"body"`
+	CreatedAt       string     `json:"created_at"`
+	IsMinimized     bool       `json:"is_minimized,omitempty"`
+	MinimizedReason string     `json:"minimized_reason,omitempty"`
+	Reactions       []Reaction `json:"reactions,omitempty"`
 }
@@ -7,7 +7,8 @@ const reportQuery = `query Report(
   $states: [PullRequestReviewState!],
   $firstReviews: Int,
   $firstThreads: Int,
-  $firstComments: Int
+  $firstComments: Int,
+  $withReactions: Boolean = false
 ) {
   repository(owner: $owner, name: $name) {
     pullRequest(number: $number) {
@@ -20,12 +21,16 @@ const reportQuery = `query Report(
           databaseId
           author { login }
         }
+        pageInfo { hasNextPage endCursor }
       }
       reviewThreads(first: $firstThreads) {
         nodes {
           id
           path
           line
+          startLine
+          diffSide
+          subjectType
           isResolved
           isOutdated
           comments(first: $firstComments) {
@@ -40,13 +45,137 @@ const reportQuery = `query Report(
                 state
                 databaseId
               }
+              isMinimized
+              minimizedReason
               replyTo {
                 id
                 databaseId
               }
+              reactionGroups @include(if: $withReactions) {
+                content
+                viewerHasReacted
+                reactors { totalCount }
+              }
+            }
+            pageInfo { hasNextPage endCursor }
+          }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+// reviewsPageQuery fetches subsequent pages of reviews once reportQuery reports more.
+const reviewsPageQuery = `query ReportReviews(
+  $owner: String!,
+  $name: String!,
+  $number: Int!,
+  $states: [PullRequestReviewState!],
+  $first: Int,
+  $after: String
+) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviews(first: $first, after: $after, states: $states) {
+        nodes {
+          id
+          state
+          body
+          submittedAt
+          databaseId
+          author { login }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+// threadsPageQuery fetches subsequent pages of review threads.
+const threadsPageQuery = `query ReportThreads(
+  $owner: String!,
+  $name: String!,
+  $number: Int!,
+  $first: Int,
+  $after: String,
+  $firstComments: Int,
+  $withReactions: Boolean = false
+) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: $first, after: $after) {
+        nodes {
+          id
+          path
+          line
+          startLine
+          diffSide
+          subjectType
+          isResolved
+          isOutdated
+          comments(first: $firstComments) {
+            nodes {
+              id
+              databaseId
+              body
+              createdAt
+              author { login }
+              pullRequestReview {
+                id
+                state
+                databaseId
+              }
+              isMinimized
+              minimizedReason
+              replyTo {
+                id
+                databaseId
+              }
+              reactionGroups @include(if: $withReactions) {
+                content
+                viewerHasReacted
+                reactors { totalCount }
+              }
             }
+            pageInfo { hasNextPage endCursor }
+          }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+// threadCommentsPageQuery fetches subsequent pages of comments for a single thread.
+const threadCommentsPageQuery = `query ReportThreadComments($id: ID!, $first: Int, $after: String, $withReactions: Boolean = false) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      comments(first: $first, after: $after) {
+        nodes {
+          id
+          databaseId
+          body
+          createdAt
+          author { login }
+          pullRequestReview {
+            id
+            state
+            databaseId
+          }
+          isMinimized
+          minimizedReason
+          replyTo {
+            id
+            databaseId
+          }
+          reactionGroups @include(if: $withReactions) {
+            content
+            viewerHasReacted
+            reactors { totalCount }
           }
         }
+        pageInfo { hasNextPage endCursor }
       }
     }
   }
@@ -0,0 +1,235 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// RenderOptions controls the human-readable renderers.
+type RenderOptions struct {
+	// Color enables ANSI styling in RenderText.
+	Color bool
+}
+
+// fileGroup collects the threads, across all reviews, that touch one path.
+type fileGroup struct {
+	Path    string
+	Threads []ReportComment
+}
+
+// groupByFile regroups review threads by path, ordered by path and then line.
+// Threads without a line (outdated or file-level) sort last within a file.
+func groupByFile(report Report) []fileGroup {
+	byPath := make(map[string]*fileGroup)
+	var paths []string
+	for _, review := range report.Reviews {
+		for _, comment := range review.Comments {
+			group, ok := byPath[comment.Path]
+			if !ok {
+				group = &fileGroup{Path: comment.Path}
+				byPath[comment.Path] = group
+				paths = append(paths, comment.Path)
+			}
+			group.Threads = append(group.Threads, comment)
+		}
+	}
+	sort.Strings(paths)
+
+	groups := make([]fileGroup, 0, len(paths))
+	for _, path := range paths {
+		group := byPath[path]
+		sort.SliceStable(group.Threads, func(i, j int) bool {
+			left, right := group.Threads[i].Line, group.Threads[j].Line
+			switch {
+			case left == nil:
+				return false
+			case right == nil:
+				return true
+			default:
+				return *left < *right
+			}
+		})
+		groups = append(groups, *group)
+	}
+	return groups
+}
+
+func lineLabel(line *int) string {
+	if line == nil {
+		return "file"
+	}
+	return fmt.Sprintf("L%d", *line)
+}
+
+// RenderMarkdown writes the report as GitHub-flavored Markdown suitable for
+// pasting into a pull request description or comment.
+func RenderMarkdown(w io.Writer, report Report) error {
+	out := bufio.NewWriter(w)
+
+	if len(report.Reviews) == 0 {
+		fmt.Fprintln(out, "_No reviews._")
+		return out.Flush()
+	}
+
+	fmt.Fprintln(out, "## Reviews")
+	fmt.Fprintln(out)
+	for _, review := range report.Reviews {
+		fmt.Fprintf(out, "- **@%s** — %s", review.AuthorLogin, stateLabel(review.State))
+		if review.SubmittedAt != nil {
+			fmt.Fprintf(out, " (%s)", *review.SubmittedAt)
+		}
+		fmt.Fprintln(out)
+		if review.Body != nil && strings.TrimSpace(*review.Body) != "" {
+			fmt.Fprintln(out)
+			writeQuoted(out, "  > ", *review.Body)
+			fmt.Fprintln(out)
+		}
+	}
+
+	for _, group := range groupByFile(report) {
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "### `%s`\n", group.Path)
+		for _, comment := range group.Threads {
+			fmt.Fprintln(out)
+			fmt.Fprintf(out, "#### %s · @%s", lineLabel(comment.Line), comment.AuthorLogin)
+			for _, badge := range badges(comment) {
+				fmt.Fprintf(out, " · %s", badge)
+			}
+			fmt.Fprintln(out)
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, strings.TrimRight(comment.Body, "\n"))
+			for _, reply := range comment.ThreadComments {
+				fmt.Fprintln(out)
+				fmt.Fprintf(out, "> **@%s** (%s):\n", reply.AuthorLogin, reply.CreatedAt)
+				writeQuoted(out, "> ", reply.Body)
+			}
+		}
+	}
+
+	if report.Truncated {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "_Report truncated: not every review, thread, or comment was fetched._")
+	}
+
+	return out.Flush()
+}
+
+// RenderText writes the report as indented plain text for terminals, with
+// threads grouped by file and replies nested under their parent comment.
+func RenderText(w io.Writer, report Report, opts RenderOptions) error {
+	out := bufio.NewWriter(w)
+	style := palette{enabled: opts.Color}
+
+	if len(report.Reviews) == 0 {
+		fmt.Fprintln(out, "No reviews.")
+		return out.Flush()
+	}
+
+	fmt.Fprintln(out, style.bold("Reviews"))
+	for _, review := range report.Reviews {
+		fmt.Fprintf(out, "  %s  %s", style.cyan("@"+review.AuthorLogin), style.state(review.State))
+		if review.SubmittedAt != nil {
+			fmt.Fprintf(out, "  %s", style.dim(*review.SubmittedAt))
+		}
+		fmt.Fprintln(out)
+		if review.Body != nil && strings.TrimSpace(*review.Body) != "" {
+			writeQuoted(out, "    ", *review.Body)
+		}
+	}
+
+	for _, group := range groupByFile(report) {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, style.bold(group.Path))
+		for _, comment := range group.Threads {
+			fmt.Fprintf(out, "  %s  %s", style.bold(lineLabel(comment.Line)), style.cyan("@"+comment.AuthorLogin))
+			for _, badge := range badges(comment) {
+				fmt.Fprintf(out, "  %s", style.badge(badge))
+			}
+			fmt.Fprintf(out, "  %s\n", style.dim(comment.ThreadID))
+			writeQuoted(out, "    ", comment.Body)
+			for _, reply := range comment.ThreadComments {
+				fmt.Fprintf(out, "      ↳ %s  %s\n", style.cyan("@"+reply.AuthorLogin), style.dim(reply.CreatedAt))
+				writeQuoted(out, "        ", reply.Body)
+			}
+		}
+	}
+
+	if report.Truncated {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, style.yellow("Report truncated: not every review, thread, or comment was fetched."))
+	}
+
+	return out.Flush()
+}
+
+func badges(comment ReportComment) []string {
+	labels := []string{"unresolved"}
+	if comment.IsResolved {
+		labels[0] = "resolved"
+	}
+	if comment.IsOutdated {
+		labels = append(labels, "outdated")
+	}
+	if comment.IsMinimized {
+		labels = append(labels, "minimized")
+	}
+	return labels
+}
+
+func stateLabel(state State) string {
+	return strings.ReplaceAll(strings.ToLower(string(state)), "_", " ")
+}
+
+func writeQuoted(out io.Writer, prefix, body string) {
+	for _, line := range strings.Split(strings.TrimRight(body, "\n"), "\n") {
+		fmt.Fprintln(out, strings.TrimRight(prefix+line, " "))
+	}
+}
+
+// palette applies ANSI escape sequences when enabled.
+type palette struct {
+	enabled bool
+}
+
+func (p palette) wrap(code, text string) string {
+	if !p.enabled {
+		return text
+	}
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
+
+func (p palette) bold(text string) string   { return p.wrap("1", text) }
+func (p palette) dim(text string) string    { return p.wrap("2", text) }
+func (p palette) cyan(text string) string   { return p.wrap("36", text) }
+func (p palette) yellow(text string) string { return p.wrap("33", text) }
+
+func (p palette) state(state State) string {
+	label := string(state)
+	switch state {
+	case StateApproved:
+		return p.wrap("32", label)
+	case StateChangesRequested:
+		return p.wrap("31", label)
+	case StatePending:
+		return p.wrap("33", label)
+	default:
+		return label
+	}
+}
+
+func (p palette) badge(label string) string {
+	text := "[" + label + "]"
+	switch label {
+	case "resolved":
+		return p.wrap("32", text)
+	case "unresolved":
+		return p.wrap("31", text)
+	case "outdated":
+		return p.wrap("33", text)
+	default:
+		return text
+	}
+}
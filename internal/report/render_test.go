@@ -0,0 +1,160 @@
+package report_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func sampleRenderReport() report.Report {
+	body := "Needs a couple of fixes.\n\nSee inline notes."
+	submitted := "2025-12-03T10:00:00Z"
+	line12, line40 := 12, 40
+	return report.Report{
+		Reviews: []report.ReportReview{
+			{
+				ID:          "R1",
+				State:       report.StateChangesRequested,
+				Body:        &body,
+				SubmittedAt: &submitted,
+				AuthorLogin: "alice",
+				Comments: []report.ReportComment{
+					{
+						ThreadID:    "PRRT_2",
+						Path:        "main.go",
+						Line:        &line40,
+						AuthorLogin: "alice",
+						Body:        "Handle the error.",
+						IsOutdated:  true,
+						ThreadComments: []report.ThreadReply{
+							{AuthorLogin: "bob", Body: "Done.\nPushed a fix.", CreatedAt: "2025-12-03T11:00:00Z"},
+						},
+					},
+					{
+						ThreadID:    "PRRT_1",
+						Path:        "README.md",
+						AuthorLogin: "alice",
+						Body:        "Typo.",
+						IsResolved:  true,
+					},
+				},
+			},
+			{
+				ID:          "R2",
+				State:       report.StateCommented,
+				AuthorLogin: "carol",
+				Comments: []report.ReportComment{
+					{ThreadID: "PRRT_3", Path: "main.go", Line: &line12, AuthorLogin: "carol", Body: "Nit."},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.RenderMarkdown(&buf, sampleRenderReport()); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	want := "## Reviews\n" +
+		"\n" +
+		"- **@alice** — changes requested (2025-12-03T10:00:00Z)\n" +
+		"\n" +
+		"  > Needs a couple of fixes.\n" +
+		"  >\n" +
+		"  > See inline notes.\n" +
+		"\n" +
+		"- **@carol** — commented\n" +
+		"\n" +
+		"### `README.md`\n" +
+		"\n" +
+		"#### file · @alice · resolved\n" +
+		"\n" +
+		"Typo.\n" +
+		"\n" +
+		"### `main.go`\n" +
+		"\n" +
+		"#### L12 · @carol · unresolved\n" +
+		"\n" +
+		"Nit.\n" +
+		"\n" +
+		"#### L40 · @alice · unresolved · outdated\n" +
+		"\n" +
+		"Handle the error.\n" +
+		"\n" +
+		"> **@bob** (2025-12-03T11:00:00Z):\n" +
+		"> Done.\n" +
+		"> Pushed a fix.\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.RenderText(&buf, sampleRenderReport(), report.RenderOptions{}); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	want := "Reviews\n" +
+		"  @alice  CHANGES_REQUESTED  2025-12-03T10:00:00Z\n" +
+		"    Needs a couple of fixes.\n" +
+		"\n" +
+		"    See inline notes.\n" +
+		"  @carol  COMMENTED\n" +
+		"\n" +
+		"README.md\n" +
+		"  file  @alice  [resolved]  PRRT_1\n" +
+		"    Typo.\n" +
+		"\n" +
+		"main.go\n" +
+		"  L12  @carol  [unresolved]  PRRT_3\n" +
+		"    Nit.\n" +
+		"  L40  @alice  [unresolved]  [outdated]  PRRT_2\n" +
+		"    Handle the error.\n" +
+		"      ↳ @bob  2025-12-03T11:00:00Z\n" +
+		"        Done.\n" +
+		"        Pushed a fix.\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected text:\n%s\nwant:\n%s", got, want)
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Fatal("expected no ANSI escapes without color")
+	}
+}
+
+func TestRenderTextColorAndTruncation(t *testing.T) {
+	rep := sampleRenderReport()
+	rep.Truncated = true
+
+	var buf bytes.Buffer
+	if err := report.RenderText(&buf, rep, report.RenderOptions{Color: true}); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "\x1b[31mCHANGES_REQUESTED\x1b[0m") {
+		t.Fatalf("expected colored state, got %q", out)
+	}
+	if !strings.Contains(out, "\x1b[32m[resolved]\x1b[0m") {
+		t.Fatalf("expected colored badge, got %q", out)
+	}
+	if !strings.Contains(out, "Report truncated") {
+		t.Fatalf("expected truncation notice, got %q", out)
+	}
+}
+
+func TestRenderEmptyReport(t *testing.T) {
+	var md, text bytes.Buffer
+	if err := report.RenderMarkdown(&md, report.Report{}); err != nil {
+		t.Fatalf("render markdown: %v", err)
+	}
+	if err := report.RenderText(&text, report.Report{}, report.RenderOptions{}); err != nil {
+		t.Fatalf("render text: %v", err)
+	}
+	if md.String() != "_No reviews._\n" || text.String() != "No reviews.\n" {
+		t.Fatalf("unexpected empty output: %q / %q", md.String(), text.String())
+	}
+}
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -14,6 +15,10 @@ const (
 	defaultFirstReviews  = 100
 	defaultFirstThreads  = 100
 	defaultFirstComments = 100
+
+	// maxPages bounds how many pages are fetched per connection so a runaway
+	// cursor cannot loop forever; hitting it marks the report as truncated.
+	maxPages = 50
 )
 
 // Service fetches and shapes pull request review reports.
@@ -30,6 +35,8 @@ type Options struct {
 	RequireNotOutdated   bool
 	TailReplies          int
 	IncludeCommentNodeID bool
+	IncludeReactions     bool
+	HideMinimized        bool
 }
 
 // NewService constructs a report service using the provided GraphQL API client.
@@ -37,8 +44,134 @@ func NewService(api ghcli.API) *Service {
 	return &Service{API: api}
 }
 
-// Fetch generates a review report for the given pull request.
-func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type reviewNode struct {
+	ID          string  `json:"id"`
+	State       string  `json:"state"`
+	Body        *string `json:"body"`
+	SubmittedAt *string `json:"submittedAt"`
+	DatabaseID  *int    `json:"databaseId"`
+	Author      *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+}
+
+type reviewConnection struct {
+	Nodes    []reviewNode `json:"nodes"`
+	PageInfo pageInfo     `json:"pageInfo"`
+}
+
+type commentNode struct {
+	ID         string `json:"id"`
+	DatabaseID int    `json:"databaseId"`
+	Body       string `json:"body"`
+	CreatedAt  string `json:"createdAt"`
+	Author     *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	PullRequestReview *struct {
+		DatabaseID *int   `json:"databaseId"`
+		State      string `json:"state"`
+		ID         string `json:"id"`
+	} `json:"pullRequestReview"`
+	ReplyTo *struct {
+		ID         string `json:"id"`
+		DatabaseID int    `json:"databaseId"`
+	} `json:"replyTo"`
+	IsMinimized     bool                `json:"isMinimized"`
+	MinimizedReason string              `json:"minimizedReason"`
+	ReactionGroups  []reactionGroupNode `json:"reactionGroups"`
+}
+
+type reactionGroupNode struct {
+	Content          string `json:"content"`
+	ViewerHasReacted bool   `json:"viewerHasReacted"`
+	Reactors         struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"reactors"`
+}
+
+// minimizedReason normalizes GitHub's lowercase reason (e.g. "off-topic") to
+// the classifier spelling used when minimizing (e.g. "OFF_TOPIC").
+func minimizedReason(reason string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(reason), "-", "_"))
+}
+
+// reactions keeps the groups that have at least one reaction.
+func reactions(groups []reactionGroupNode) []Reaction {
+	var result []Reaction
+	for _, group := range groups {
+		if group.Reactors.TotalCount == 0 {
+			continue
+		}
+		result = append(result, Reaction{
+			Content:          group.Content,
+			Count:            group.Reactors.TotalCount,
+			ViewerHasReacted: group.ViewerHasReacted,
+		})
+	}
+	return result
+}
+
+type commentConnection struct {
+	Nodes    []commentNode `json:"nodes"`
+	PageInfo pageInfo      `json:"pageInfo"`
+}
+
+type threadNode struct {
+	ID          string            `json:"id"`
+	Path        string            `json:"path"`
+	Line        *int              `json:"line"`
+	StartLine   *int              `json:"startLine"`
+	DiffSide    string            `json:"diffSide"`
+	SubjectType string            `json:"subjectType"`
+	IsResolved  bool              `json:"isResolved"`
+	IsOutdated  bool              `json:"isOutdated"`
+	Comments    commentConnection `json:"comments"`
+}
+
+type threadConnection struct {
+	Nodes    []threadNode `json:"nodes"`
+	PageInfo pageInfo     `json:"pageInfo"`
+}
+
+// Fetch generates a review report for the given pull request, following
+// pagination cursors for reviews, threads, and per-thread comments.
+func (s *Service) Fetch(ctx context.Context, pr resolver.Identity, opts Options) (Report, error) {
+	reviews, threads, truncated, err := s.fetchAll(ctx, pr, opts)
+	if err != nil {
+		return Report{}, err
+	}
+
+	filters := FilterOptions{
+		Reviewer:             opts.Reviewer,
+		States:               opts.States,
+		RequireUnresolved:    opts.RequireUnresolved,
+		RequireNotOutdated:   opts.RequireNotOutdated,
+		TailReplies:          opts.TailReplies,
+		IncludeCommentNodeID: opts.IncludeCommentNodeID,
+		IncludeReactions:     opts.IncludeReactions,
+		HideMinimized:        opts.HideMinimized,
+	}
+
+	report := BuildReport(reviews, threads, filters)
+	report.Truncated = truncated
+	return report, nil
+}
+
+// Threads returns every review thread of the pull request with all of its
+// comments, unshaped. The boolean reports whether pagination stopped early.
+func (s *Service) Threads(ctx context.Context, pr resolver.Identity) ([]Thread, bool, error) {
+	_, threads, truncated, err := s.fetchAll(ctx, pr, Options{})
+	return threads, truncated, err
+}
+
+// fetchAll retrieves reviews and threads, following every pagination cursor.
+func (s *Service) fetchAll(ctx context.Context, pr resolver.Identity, opts Options) ([]Review, []Thread, bool, error) {
 	variables := map[string]interface{}{
 		"owner":         pr.Owner,
 		"name":          pr.Repo,
@@ -47,8 +180,12 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 		"firstThreads":  defaultFirstThreads,
 		"firstComments": defaultFirstComments,
 	}
+	if opts.IncludeReactions {
+		variables["withReactions"] = true
+	}
+	var states []string
 	if opts.StatesProvided {
-		states := make([]string, len(opts.States))
+		states = make([]string, len(opts.States))
 		for i, st := range opts.States {
 			states[i] = string(st)
 		}
@@ -58,72 +195,43 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 	var response struct {
 		Repository *struct {
 			PullRequest *struct {
-				Reviews struct {
-					Nodes []struct {
-						ID          string  `json:"id"`
-						State       string  `json:"state"`
-						Body        *string `json:"body"`
-						SubmittedAt *string `json:"submittedAt"`
-						DatabaseID  *int    `json:"databaseId"`
-						Author      *struct {
-							Login string `json:"login"`
-						} `json:"author"`
-					} `json:"nodes"`
-				} `json:"reviews"`
-				ReviewThreads struct {
-					Nodes []struct {
-						ID         string `json:"id"`
-						Path       string `json:"path"`
-						Line       *int   `json:"line"`
-						IsResolved bool   `json:"isResolved"`
-						IsOutdated bool   `json:"isOutdated"`
-						Comments   struct {
-							Nodes []struct {
-								ID         string `json:"id"`
-								DatabaseID int    `json:"databaseId"`
-								Body       string `json:"body"`
-								CreatedAt  string `json:"createdAt"`
-								Author     *struct {
-									Login string `json:"login"`
-								} `json:"author"`
-								PullRequestReview *struct {
-									DatabaseID *int   `json:"databaseId"`
-									State      string `json:"state"`
-									ID         string `json:"id"`
-								} `json:"pullRequestReview"`
-								ReplyTo *struct {
-									ID         string `json:"id"`
-									DatabaseID int    `json:"databaseId"`
-								} `json:"replyTo"`
-							} `json:"nodes"`
-						} `json:"comments"`
-					} `json:"nodes"`
-				} `json:"reviewThreads"`
+				Reviews       reviewConnection `json:"reviews"`
+				ReviewThreads threadConnection `json:"reviewThreads"`
 			} `json:"pullRequest"`
 		} `json:"repository"`
 	}
 
-	if err := s.API.GraphQL(reportQuery, variables, &response); err != nil {
-		return Report{}, err
+	if err := s.API.GraphQL(ctx, reportQuery, variables, &response); err != nil {
+		return nil, nil, false, err
 	}
 
 	if response.Repository == nil || response.Repository.PullRequest == nil {
-		return Report{}, errors.New("pull request not found or inaccessible")
+		return nil, nil, false, errors.New("pull request not found or inaccessible")
 	}
 
 	prData := response.Repository.PullRequest
-	reviews := make([]Review, 0, len(prData.Reviews.Nodes))
 
-	for _, node := range prData.Reviews.Nodes {
+	reviewNodes, reviewsTruncated, err := s.remainingReviews(ctx, pr, states, prData.Reviews)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	threadNodes, threadsTruncated, err := s.remainingThreads(ctx, pr, prData.ReviewThreads, opts.IncludeReactions)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	truncated := reviewsTruncated || threadsTruncated
+
+	reviews := make([]Review, 0, len(reviewNodes))
+	for _, node := range reviewNodes {
 		if node.DatabaseID == nil {
-			return Report{}, errors.New("review missing databaseId")
+			return nil, nil, false, errors.New("review missing databaseId")
 		}
 		if node.Author == nil || node.Author.Login == "" {
-			return Report{}, errors.New("review missing author login")
+			return nil, nil, false, errors.New("review missing author login")
 		}
 		state, ok := parseState(node.State)
 		if !ok {
-			return Report{}, fmt.Errorf("unknown review state %q", node.State)
+			return nil, nil, false, fmt.Errorf("unknown review state %q", node.State)
 		}
 		review := Review{
 			ID:          node.ID,
@@ -135,34 +243,43 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 		if node.SubmittedAt != nil && strings.TrimSpace(*node.SubmittedAt) != "" {
 			parsed, err := time.Parse(time.RFC3339, *node.SubmittedAt)
 			if err != nil {
-				return Report{}, fmt.Errorf("parse review submittedAt: %w", err)
+				return nil, nil, false, fmt.Errorf("parse review submittedAt: %w", err)
 			}
 			review.SubmittedAt = &parsed
 		}
 		reviews = append(reviews, review)
 	}
 
-	threads := make([]Thread, 0, len(prData.ReviewThreads.Nodes))
-	for _, node := range prData.ReviewThreads.Nodes {
+	threads := make([]Thread, 0, len(threadNodes))
+	for _, node := range threadNodes {
+		commentNodes, commentsTruncated, err := s.remainingComments(ctx, node.ID, node.Comments, opts.IncludeReactions)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		truncated = truncated || commentsTruncated
+
 		thread := Thread{
-			ID:         node.ID,
-			Path:       node.Path,
-			Line:       node.Line,
-			IsResolved: node.IsResolved,
-			IsOutdated: node.IsOutdated,
-			Comments:   make([]ThreadComment, 0, len(node.Comments.Nodes)),
+			ID:          node.ID,
+			Path:        node.Path,
+			Line:        node.Line,
+			StartLine:   node.StartLine,
+			DiffSide:    node.DiffSide,
+			SubjectType: node.SubjectType,
+			IsResolved:  node.IsResolved,
+			IsOutdated:  node.IsOutdated,
+			Comments:    make([]ThreadComment, 0, len(commentNodes)),
 		}
 
-		for _, comment := range node.Comments.Nodes {
+		for _, comment := range commentNodes {
 			if comment.ID == "" {
-				return Report{}, errors.New("comment missing id")
+				return nil, nil, false, errors.New("comment missing id")
 			}
 			if comment.Author == nil || comment.Author.Login == "" {
-				return Report{}, errors.New("comment missing author login")
+				return nil, nil, false, errors.New("comment missing author login")
 			}
 			createdAt, err := time.Parse(time.RFC3339, comment.CreatedAt)
 			if err != nil {
-				return Report{}, fmt.Errorf("parse comment createdAt: %w", err)
+				return nil, nil, false, fmt.Errorf("parse comment createdAt: %w", err)
 			}
 			var reviewDatabaseID *int
 			if comment.PullRequestReview != nil {
@@ -188,22 +305,136 @@ func (s *Service) Fetch(pr resolver.Identity, opts Options) (Report, error) {
 				ReviewDatabaseID:   reviewDatabaseID,
 				ReplyToDatabaseID:  replyTo,
 				ReplyToCommentNode: replyToNode,
+				Reactions:          reactions(comment.ReactionGroups),
+				IsMinimized:        comment.IsMinimized,
+				MinimizedReason:    minimizedReason(comment.MinimizedReason),
 			})
 		}
 
 		threads = append(threads, thread)
 	}
 
-	filters := FilterOptions{
-		Reviewer:             opts.Reviewer,
-		States:               opts.States,
-		RequireUnresolved:    opts.RequireUnresolved,
-		RequireNotOutdated:   opts.RequireNotOutdated,
-		TailReplies:          opts.TailReplies,
-		IncludeCommentNodeID: opts.IncludeCommentNodeID,
+	return reviews, threads, truncated, nil
+}
+
+// remainingReviews follows the reviews cursor from the first page, returning
+// every review node and whether pagination stopped early.
+func (s *Service) remainingReviews(ctx context.Context, pr resolver.Identity, states []string, first reviewConnection) ([]reviewNode, bool, error) {
+	nodes := first.Nodes
+	page := first.PageInfo
+	for pages := 1; page.HasNextPage; pages++ {
+		if pages >= maxPages || strings.TrimSpace(page.EndCursor) == "" {
+			return nodes, true, nil
+		}
+
+		variables := map[string]interface{}{
+			"owner":  pr.Owner,
+			"name":   pr.Repo,
+			"number": pr.Number,
+			"first":  defaultFirstReviews,
+			"after":  page.EndCursor,
+		}
+		if states != nil {
+			variables["states"] = states
+		}
+
+		var response struct {
+			Repository *struct {
+				PullRequest *struct {
+					Reviews reviewConnection `json:"reviews"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(ctx, reviewsPageQuery, variables, &response); err != nil {
+			return nil, false, err
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil {
+			return nil, false, errors.New("pull request not found or inaccessible")
+		}
+
+		reviews := response.Repository.PullRequest.Reviews
+		nodes = append(nodes, reviews.Nodes...)
+		page = reviews.PageInfo
+	}
+	return nodes, false, nil
+}
+
+// remainingThreads follows the reviewThreads cursor from the first page.
+func (s *Service) remainingThreads(ctx context.Context, pr resolver.Identity, first threadConnection, withReactions bool) ([]threadNode, bool, error) {
+	nodes := first.Nodes
+	page := first.PageInfo
+	for pages := 1; page.HasNextPage; pages++ {
+		if pages >= maxPages || strings.TrimSpace(page.EndCursor) == "" {
+			return nodes, true, nil
+		}
+
+		variables := map[string]interface{}{
+			"owner":         pr.Owner,
+			"name":          pr.Repo,
+			"number":        pr.Number,
+			"first":         defaultFirstThreads,
+			"after":         page.EndCursor,
+			"firstComments": defaultFirstComments,
+		}
+		if withReactions {
+			variables["withReactions"] = true
+		}
+
+		var response struct {
+			Repository *struct {
+				PullRequest *struct {
+					ReviewThreads threadConnection `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(ctx, threadsPageQuery, variables, &response); err != nil {
+			return nil, false, err
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil {
+			return nil, false, errors.New("pull request not found or inaccessible")
+		}
+
+		threads := response.Repository.PullRequest.ReviewThreads
+		nodes = append(nodes, threads.Nodes...)
+		page = threads.PageInfo
 	}
+	return nodes, false, nil
+}
+
+// remainingComments follows a thread's comments cursor from the first page.
+func (s *Service) remainingComments(ctx context.Context, threadID string, first commentConnection, withReactions bool) ([]commentNode, bool, error) {
+	nodes := first.Nodes
+	page := first.PageInfo
+	for pages := 1; page.HasNextPage; pages++ {
+		if pages >= maxPages || strings.TrimSpace(page.EndCursor) == "" {
+			return nodes, true, nil
+		}
+
+		variables := map[string]interface{}{
+			"id":    threadID,
+			"first": defaultFirstComments,
+			"after": page.EndCursor,
+		}
+		if withReactions {
+			variables["withReactions"] = true
+		}
 
-	return BuildReport(reviews, threads, filters), nil
+		var response struct {
+			Node *struct {
+				Comments commentConnection `json:"comments"`
+			} `json:"node"`
+		}
+		if err := s.API.GraphQL(ctx, threadCommentsPageQuery, variables, &response); err != nil {
+			return nil, false, err
+		}
+		if response.Node == nil {
+			return nil, false, fmt.Errorf("thread %s not found", threadID)
+		}
+
+		nodes = append(nodes, response.Node.Comments.Nodes...)
+		page = response.Node.Comments.PageInfo
+	}
+	return nodes, false, nil
 }
 
 func parseState(raw string) (State, bool) {
@@ -216,6 +447,8 @@ func parseState(raw string) (State, bool) {
 		return StateCommented, true
 	case string(StateDismissed):
 		return StateDismissed, true
+	case string(StatePending):
+		return StatePending, true
 	default:
 		return "", false
 	}
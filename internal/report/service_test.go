@@ -1,7 +1,9 @@
 package report
 
 import (
+	"context"
 	"encoding/json"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -18,7 +20,7 @@ func TestServiceFetchShapesReport(t *testing.T) {
 	svc := NewService(fake)
 
 	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
-	result, err := svc.Fetch(identity, Options{
+	result, err := svc.Fetch(context.Background(), identity, Options{
 		Reviewer:           "alice",
 		States:             []State{StateApproved, StateCommented},
 		StatesProvided:     true,
@@ -71,7 +73,7 @@ func TestServiceFetchIncludesCommentNodeID(t *testing.T) {
 	svc := NewService(fake)
 
 	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
-	result, err := svc.Fetch(identity, Options{IncludeCommentNodeID: true})
+	result, err := svc.Fetch(context.Background(), identity, Options{IncludeCommentNodeID: true})
 	if err != nil {
 		t.Fatalf("fetch report with comment node ids: %v", err)
 	}
@@ -95,6 +97,40 @@ func TestServiceFetchIncludesCommentNodeID(t *testing.T) {
 	}
 }
 
+func TestServiceFetchIncludesReactions(t *testing.T) {
+	fake := &stubAPI{t: t, payload: reportResponseFixture}
+	svc := NewService(fake)
+
+	identity := resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}
+	result, err := svc.Fetch(context.Background(), identity, Options{IncludeReactions: true})
+	if err != nil {
+		t.Fatalf("fetch report with reactions: %v", err)
+	}
+	if fake.lastVariables["withReactions"] != true {
+		t.Fatalf("expected withReactions variable, variables: %#v", fake.lastVariables)
+	}
+
+	comment := result.Reviews[0].Comments[0]
+	if len(comment.Reactions) != 1 {
+		t.Fatalf("expected empty reaction groups dropped, got %#v", comment.Reactions)
+	}
+	if want := (Reaction{Content: "THUMBS_UP", Count: 2, ViewerHasReacted: true}); comment.Reactions[0] != want {
+		t.Fatalf("expected %#v, got %#v", want, comment.Reactions[0])
+	}
+	reply := comment.ThreadComments[len(comment.ThreadComments)-1]
+	if len(reply.Reactions) != 1 || reply.Reactions[0].Content != "EYES" {
+		t.Fatalf("expected reply reactions, got %#v", reply.Reactions)
+	}
+
+	result, err = svc.Fetch(context.Background(), identity, Options{})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if reactions := result.Reviews[0].Comments[0].Reactions; reactions != nil {
+		t.Fatalf("expected reactions omitted by default, got %#v", reactions)
+	}
+}
+
 func TestServiceFetchErrorsOnMissingReviewDBID(t *testing.T) {
 	broken := map[string]any{}
 	if err := json.Unmarshal(reportResponseFixture, &broken); err != nil {
@@ -115,7 +151,7 @@ func TestServiceFetchErrorsOnMissingReviewDBID(t *testing.T) {
 	fake := &stubAPI{t: t, payload: modified}
 	svc := NewService(fake)
 
-	_, err = svc.Fetch(resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
+	_, err = svc.Fetch(context.Background(), resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
 	if err == nil {
 		t.Fatal("expected error for missing databaseId")
 	}
@@ -124,6 +160,144 @@ func TestServiceFetchErrorsOnMissingReviewDBID(t *testing.T) {
 	}
 }
 
+func TestServiceFetchFollowsPagination(t *testing.T) {
+	comment := func(id string, dbID int, body string, replyTo interface{}) map[string]any {
+		return map[string]any{
+			"id":                id,
+			"databaseId":        dbID,
+			"body":              body,
+			"createdAt":         "2025-12-03T10:0" + strconv.Itoa(dbID%10) + ":00Z",
+			"author":            map[string]any{"login": "alice"},
+			"pullRequestReview": map[string]any{"id": "R1", "state": "COMMENTED", "databaseId": 101},
+			"replyTo":           replyTo,
+		}
+	}
+	thread := func(id string, comments []map[string]any, hasMore bool) map[string]any {
+		return map[string]any{
+			"id":         id,
+			"path":       "main.go",
+			"line":       1,
+			"isResolved": false,
+			"isOutdated": false,
+			"comments": map[string]any{
+				"nodes":    comments,
+				"pageInfo": map[string]any{"hasNextPage": hasMore, "endCursor": id + "-comments"},
+			},
+		}
+	}
+
+	fake := &routedAPI{t: t, routes: map[string]func(map[string]interface{}) any{
+		reportQuery: func(map[string]interface{}) any {
+			return map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+				"reviews": map[string]any{
+					"nodes":    []any{},
+					"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "reviews-1"},
+				},
+				"reviewThreads": map[string]any{
+					"nodes":    []any{thread("T1", []map[string]any{comment("C1", 1, "parent one", nil)}, true)},
+					"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "threads-1"},
+				},
+			}}}
+		},
+		reviewsPageQuery: func(vars map[string]interface{}) any {
+			if vars["after"] != "reviews-1" {
+				t.Fatalf("unexpected reviews cursor %v", vars["after"])
+			}
+			return map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+				"reviews": map[string]any{
+					"nodes": []any{map[string]any{
+						"id": "R1", "state": "COMMENTED", "databaseId": 101,
+						"submittedAt": "2025-12-03T10:00:00Z", "author": map[string]any{"login": "alice"},
+					}},
+					"pageInfo": map[string]any{"hasNextPage": false},
+				},
+			}}}
+		},
+		threadsPageQuery: func(vars map[string]interface{}) any {
+			if vars["after"] != "threads-1" {
+				t.Fatalf("unexpected threads cursor %v", vars["after"])
+			}
+			return map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+				"reviewThreads": map[string]any{
+					"nodes":    []any{thread("T2", []map[string]any{comment("C3", 3, "parent two", nil)}, false)},
+					"pageInfo": map[string]any{"hasNextPage": false},
+				},
+			}}}
+		},
+		threadCommentsPageQuery: func(vars map[string]interface{}) any {
+			if vars["id"] != "T1" || vars["after"] != "T1-comments" {
+				t.Fatalf("unexpected comments page request %v", vars)
+			}
+			return map[string]any{"node": map[string]any{"comments": map[string]any{
+				"nodes":    []any{comment("C2", 2, "late reply", map[string]any{"id": "C1", "databaseId": 1})},
+				"pageInfo": map[string]any{"hasNextPage": false},
+			}}}
+		},
+	}}
+
+	result, err := NewService(fake).Fetch(context.Background(), resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
+	if err != nil {
+		t.Fatalf("fetch paginated report: %v", err)
+	}
+	if result.Truncated {
+		t.Fatal("expected complete report")
+	}
+	if len(result.Reviews) != 1 || len(result.Reviews[0].Comments) != 2 {
+		t.Fatalf("expected 1 review with 2 threads, got %+v", result.Reviews)
+	}
+	first := result.Reviews[0].Comments[0]
+	if first.ThreadID != "T1" || len(first.ThreadComments) != 1 || first.ThreadComments[0].Body != "late reply" {
+		t.Fatalf("expected T1 with paginated reply, got %+v", first)
+	}
+	if result.Reviews[0].Comments[1].ThreadID != "T2" {
+		t.Fatalf("expected second thread T2, got %s", result.Reviews[0].Comments[1].ThreadID)
+	}
+}
+
+func TestServiceFetchMarksTruncatedOnMissingCursor(t *testing.T) {
+	fake := &routedAPI{t: t, routes: map[string]func(map[string]interface{}) any{
+		reportQuery: func(map[string]interface{}) any {
+			return map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+				"reviews": map[string]any{"nodes": []any{}},
+				"reviewThreads": map[string]any{
+					"nodes":    []any{},
+					"pageInfo": map[string]any{"hasNextPage": true, "endCursor": ""},
+				},
+			}}}
+		},
+	}}
+
+	result, err := NewService(fake).Fetch(context.Background(), resolver.Identity{Owner: "agyn", Repo: "sandbox", Number: 51}, Options{})
+	if err != nil {
+		t.Fatalf("fetch report: %v", err)
+	}
+	if !result.Truncated {
+		t.Fatal("expected report marked truncated")
+	}
+}
+
+type routedAPI struct {
+	t      *testing.T
+	routes map[string]func(map[string]interface{}) any
+}
+
+func (r *routedAPI) REST(context.Context, string, string, map[string]string, interface{}, interface{}) error {
+	r.t.Fatalf("unexpected REST call in report service test")
+	return nil
+}
+
+func (r *routedAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	route, ok := r.routes[query]
+	if !ok {
+		r.t.Fatalf("unexpected query: %s", query)
+	}
+	data, err := json.Marshal(route(variables))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
 type stubAPI struct {
 	t             *testing.T
 	payload       []byte
@@ -131,12 +305,12 @@ type stubAPI struct {
 	lastVariables map[string]interface{}
 }
 
-func (s *stubAPI) REST(string, string, map[string]string, interface{}, interface{}) error {
+func (s *stubAPI) REST(context.Context, string, string, map[string]string, interface{}, interface{}) error {
 	s.t.Fatalf("unexpected REST call in report service test")
 	return nil
 }
 
-func (s *stubAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+func (s *stubAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	s.lastQuery = query
 	s.lastVariables = variables
 	if query != reportQuery {
@@ -0,0 +1,290 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+// runGit executes git with args in the current directory and returns trimmed stdout.
+var runGit = func(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// remote is a git remote pointing at a GitHub repository.
+type remote struct {
+	Name     string
+	Owner    string
+	Repo     string
+	Host     string
+	Resolved string
+}
+
+// remotePriority mirrors gh's preference when no default repository is set.
+var remotePriority = map[string]int{"upstream": 0, "github": 1, "origin": 2}
+
+// inferRepo determines the base repository from the git checkout in the
+// current directory. GH_REPO takes precedence, then the remote chosen with
+// `gh repo set-default`, then the upstream/github/origin remotes in that order.
+// When hostFilter is non-empty only remotes on that host are considered.
+func inferRepo(hostFilter string) (owner, repo, host string, err error) {
+	if value := strings.TrimSpace(os.Getenv("GH_REPO")); value != "" {
+		return parseRepoOverride(value, hostFilter)
+	}
+
+	remotes, err := gitRemotes()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	filtered := remotes[:0]
+	for _, r := range remotes {
+		if hostFilter == "" || r.Host == hostFilter {
+			filtered = append(filtered, r)
+		}
+	}
+	if len(filtered) == 0 {
+		return "", "", "", errors.New("no GitHub remotes found in the current git repository; pass --repo owner/repo")
+	}
+
+	for _, r := range filtered {
+		switch resolved := strings.TrimSpace(r.Resolved); {
+		case resolved == "":
+			continue
+		case resolved == "base":
+			return r.Owner, r.Repo, r.Host, nil
+		default:
+			o, n, splitErr := splitRepo(resolved)
+			if splitErr != nil {
+				return "", "", "", fmt.Errorf("invalid gh-resolved value %q on remote %s: %w", resolved, r.Name, splitErr)
+			}
+			return o, n, r.Host, nil
+		}
+	}
+
+	chosen := filtered[0]
+	return chosen.Owner, chosen.Repo, chosen.Host, nil
+}
+
+func parseRepoOverride(value, hostFilter string) (string, string, string, error) {
+	host := hostFilter
+	parts := strings.Split(value, "/")
+	if len(parts) == 3 {
+		host = sanitizeHost(parts[0])
+		parts = parts[1:]
+	}
+	owner, repo, err := splitRepo(strings.Join(parts, "/"))
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid GH_REPO %q: %w", value, err)
+	}
+	return owner, repo, sanitizeHost(host), nil
+}
+
+// gitRemotes lists remotes with GitHub-style URLs, ordered by gh's priority.
+func gitRemotes() ([]remote, error) {
+	out, err := runGit("config", "--get-regexp", `^remote\..*\.(url|gh-resolved)$`)
+	if err != nil {
+		if _, statErr := runGit("rev-parse", "--git-dir"); statErr != nil {
+			return nil, errors.New("not a git repository; pass --repo owner/repo or a pull request URL")
+		}
+		return nil, nil
+	}
+
+	byName := make(map[string]*remote)
+	var order []string
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		trimmed := strings.TrimPrefix(key, "remote.")
+		dot := strings.LastIndex(trimmed, ".")
+		if dot <= 0 {
+			continue
+		}
+		name, field := trimmed[:dot], trimmed[dot+1:]
+		entry, seen := byName[name]
+		if !seen {
+			entry = &remote{Name: name}
+			byName[name] = entry
+			order = append(order, name)
+		}
+		switch field {
+		case "url":
+			if entry.Host != "" {
+				continue
+			}
+			if owner, repo, host, parseErr := parseRemoteURL(value); parseErr == nil {
+				entry.Owner, entry.Repo, entry.Host = owner, repo, host
+			}
+		case "gh-resolved":
+			entry.Resolved = value
+		}
+	}
+
+	remotes := make([]remote, 0, len(order))
+	for _, name := range order {
+		if entry := byName[name]; entry.Host != "" {
+			remotes = append(remotes, *entry)
+		}
+	}
+	sort.SliceStable(remotes, func(i, j int) bool {
+		return priority(remotes[i].Name) < priority(remotes[j].Name)
+	})
+	return remotes, nil
+}
+
+func priority(name string) int {
+	if p, ok := remotePriority[name]; ok {
+		return p
+	}
+	return len(remotePriority)
+}
+
+// parseRemoteURL extracts owner, repo and host from SSH, scp-style, and HTTPS remote URLs.
+func parseRemoteURL(raw string) (string, string, string, error) {
+	raw = strings.TrimSpace(raw)
+	var host, path string
+
+	switch {
+	case strings.Contains(raw, "://"):
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", "", "", err
+		}
+		host, path = u.Host, u.Path
+	case strings.Contains(raw, ":"):
+		hostPart, pathPart, _ := strings.Cut(raw, ":")
+		if at := strings.LastIndex(hostPart, "@"); at >= 0 {
+			hostPart = hostPart[at+1:]
+		}
+		host, path = hostPart, pathPart
+	default:
+		return "", "", "", fmt.Errorf("unsupported remote url %q", raw)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	owner, repo, err := splitRepo(path)
+	if err != nil {
+		return "", "", "", fmt.Errorf("remote url %q: %w", raw, err)
+	}
+
+	host = sanitizeHost(host)
+	if host == "ssh.github.com" {
+		host = "github.com"
+	}
+	return owner, repo, host, nil
+}
+
+// RepoRoot returns the top-level directory of the git checkout containing the
+// current directory, which repository paths are relative to.
+func RepoRoot() (string, error) {
+	root, err := runGit("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	if root == "" {
+		return "", errors.New("git rev-parse --show-toplevel returned no directory")
+	}
+	return root, nil
+}
+
+// currentBranch returns the checked-out branch and the name of the branch it
+// tracks on its push remote, along with that remote's owner when known.
+func currentBranch() (branch, headBranch, headOwner string, err error) {
+	branch, err = runGit("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil || branch == "" {
+		return "", "", "", errors.New("could not determine the current branch (detached HEAD?); pass a pull request number or URL")
+	}
+
+	headBranch = branch
+	if merge, mergeErr := runGit("config", "--get", "branch."+branch+".merge"); mergeErr == nil && merge != "" {
+		headBranch = strings.TrimPrefix(merge, "refs/heads/")
+	}
+	if remoteName, remoteErr := runGit("config", "--get", "branch."+branch+".remote"); remoteErr == nil && remoteName != "" {
+		if remoteURL, urlErr := runGit("config", "--get", "remote."+remoteName+".url"); urlErr == nil {
+			if owner, _, _, parseErr := parseRemoteURL(remoteURL); parseErr == nil {
+				headOwner = owner
+			}
+		}
+	}
+	return branch, headBranch, headOwner, nil
+}
+
+// ResolveCurrentBranch finds the open pull request whose head is the current
+// git branch, similar to `gh pr view` without arguments. The repository comes
+// from repoFlag when set, otherwise from the checkout's remotes. apiFor builds
+// an API client for the resolved host.
+func ResolveCurrentBranch(ctx context.Context, repoFlag, host string, apiFor func(host string) ghcli.API) (Identity, error) {
+	base, err := resolveRepo(repoFlag, host)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	branch, headBranch, headOwner, err := currentBranch()
+	if err != nil {
+		return Identity{}, err
+	}
+	if headOwner == "" {
+		headOwner = base.Owner
+	}
+
+	var pulls []struct {
+		Number int `json:"number"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/pulls", base.Owner, base.Repo)
+	params := map[string]string{
+		"head":  headOwner + ":" + headBranch,
+		"state": "open",
+	}
+	if err := apiFor(base.Host).REST(ctx, "GET", path, params, nil, &pulls); err != nil {
+		return Identity{}, fmt.Errorf("look up pull request for branch %q: %w", branch, err)
+	}
+	if len(pulls) == 0 || pulls[0].Number <= 0 {
+		return Identity{}, fmt.Errorf("no open pull request found for branch %q in %s/%s", branch, base.Owner, base.Repo)
+	}
+
+	base.Number = pulls[0].Number
+	return base, nil
+}
+
+// resolveRepo returns the repository identity (without a number) from
+// repoFlag or, when empty, from the git checkout.
+func resolveRepo(repoFlag, host string) (Identity, error) {
+	hostFilter := ""
+	if strings.TrimSpace(host) != "" {
+		hostFilter = sanitizeHost(host)
+	}
+
+	if repoFlag = strings.TrimSpace(repoFlag); repoFlag != "" {
+		owner, repo, err := splitRepo(repoFlag)
+		if err != nil {
+			return Identity{}, fmt.Errorf("--repo must be owner/repo: %w", err)
+		}
+		return Identity{Owner: owner, Repo: repo, Host: sanitizeHost(host)}, nil
+	}
+
+	owner, repo, inferredHost, err := inferRepo(hostFilter)
+	if err != nil {
+		return Identity{}, err
+	}
+	return Identity{Owner: owner, Repo: repo, Host: inferredHost}, nil
+}
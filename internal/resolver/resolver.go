@@ -22,7 +22,9 @@ type Identity struct {
 	Number int
 }
 
-// NormalizeSelector ensures that either an explicit selector or --pr flag is present and mutually consistent.
+// NormalizeSelector ensures that an explicit selector and --pr flag are mutually consistent.
+// It returns an empty selector when neither is given; callers then fall back to
+// the pull request for the current branch (see ResolveCurrentBranch).
 func NormalizeSelector(selector string, prFlag int) (string, error) {
 	selector = strings.TrimSpace(selector)
 
@@ -36,7 +38,7 @@ func NormalizeSelector(selector string, prFlag int) (string, error) {
 	}
 
 	if selector == "" {
-		return "", errors.New("must specify a pull request via --pr or selector")
+		return "", nil
 	}
 
 	if isNumeric(selector) {
@@ -51,10 +53,10 @@ func NormalizeSelector(selector string, prFlag int) (string, error) {
 }
 
 // Resolve interprets a selector, optional repo flag, and host (GH_HOST) into a concrete pull request identity.
+// Numeric selectors without a repo flag use the repository inferred from the
+// git remotes of the current directory.
 func Resolve(selector, repoFlag, host string) (Identity, error) {
 	selector = strings.TrimSpace(selector)
-	repoFlag = strings.TrimSpace(repoFlag)
-	host = sanitizeHost(host)
 
 	if selector == "" {
 		return Identity{}, errors.New("empty selector")
@@ -65,11 +67,12 @@ func Resolve(selector, repoFlag, host string) (Identity, error) {
 	}
 
 	if n, err := strconv.Atoi(selector); err == nil && n > 0 {
-		owner, repo, err := splitRepo(repoFlag)
+		id, err := resolveRepo(repoFlag, host)
 		if err != nil {
-			return Identity{}, fmt.Errorf("--repo must be owner/repo when using numeric selectors: %w", err)
+			return Identity{}, err
 		}
-		return Identity{Owner: owner, Repo: repo, Host: host, Number: n}, nil
+		id.Number = n
+		return id, nil
 	}
 
 	return Identity{}, fmt.Errorf("invalid pull request selector: %q", selector)
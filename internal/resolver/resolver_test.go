@@ -1,10 +1,15 @@
 package resolver
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
 )
 
 func TestNormalizeSelector(t *testing.T) {
@@ -16,6 +21,10 @@ func TestNormalizeSelector(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "7", selector)
 
+	selector, err = NormalizeSelector("", 0)
+	require.NoError(t, err)
+	assert.Empty(t, selector)
+
 	selector, err = NormalizeSelector("", 42)
 	require.NoError(t, err)
 	assert.Equal(t, "42", selector)
@@ -47,6 +56,8 @@ func TestResolveURLHostPrecedence(t *testing.T) {
 }
 
 func TestResolveNumberRequiresRepo(t *testing.T) {
+	stubGit(t, map[string]string{})
+
 	_, err := Resolve("7", "", "")
 	require.Error(t, err)
 
@@ -54,3 +65,159 @@ func TestResolveNumberRequiresRepo(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 7}, id)
 }
+
+// stubGit replaces runGit with canned output keyed by the joined arguments.
+// Unknown commands fail, mimicking git's non-zero exit for missing config keys.
+func stubGit(t *testing.T, outputs map[string]string) {
+	t.Helper()
+	t.Setenv("GH_REPO", "")
+	original := runGit
+	runGit = func(args ...string) (string, error) {
+		if out, ok := outputs[strings.Join(args, " ")]; ok {
+			return out, nil
+		}
+		return "", errors.New("exit status 1")
+	}
+	t.Cleanup(func() { runGit = original })
+}
+
+const remotesKey = `config --get-regexp ^remote\..*\.(url|gh-resolved)$`
+
+func TestResolveNumberInfersRepoFromRemotes(t *testing.T) {
+	stubGit(t, map[string]string{
+		remotesKey: "remote.origin.url git@github.com:me/demo.git\n" +
+			"remote.upstream.url https://github.com/octo/demo.git",
+	})
+
+	id, err := Resolve("7", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 7}, id)
+}
+
+func TestResolveNumberRespectsSetDefault(t *testing.T) {
+	stubGit(t, map[string]string{
+		remotesKey: "remote.origin.url ssh://git@github.com/me/demo\n" +
+			"remote.origin.gh-resolved base\n" +
+			"remote.upstream.url https://github.com/octo/demo.git",
+	})
+
+	id, err := Resolve("7", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{Owner: "me", Repo: "demo", Host: "github.com", Number: 7}, id)
+}
+
+func TestResolveNumberFiltersRemotesByHost(t *testing.T) {
+	stubGit(t, map[string]string{
+		remotesKey: "remote.origin.url https://github.com/octo/demo.git\n" +
+			"remote.enterprise.url git@ghe.example.com:corp/demo.git",
+	})
+
+	id, err := Resolve("7", "", "ghe.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{Owner: "corp", Repo: "demo", Host: "ghe.example.com", Number: 7}, id)
+}
+
+func TestResolveNumberUsesGHRepo(t *testing.T) {
+	stubGit(t, map[string]string{})
+	t.Setenv("GH_REPO", "ghe.example.com/corp/demo")
+
+	id, err := Resolve("7", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{Owner: "corp", Repo: "demo", Host: "ghe.example.com", Number: 7}, id)
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	cases := map[string][3]string{
+		"git@github.com:octo/demo.git":                {"octo", "demo", "github.com"},
+		"https://github.com/octo/demo":                {"octo", "demo", "github.com"},
+		"ssh://git@ssh.github.com:443/octo/demo.git":  {"octo", "demo", "github.com"},
+		"https://user@GHE.example.com/corp/tool.git/": {"corp", "tool", "ghe.example.com"},
+	}
+	for raw, want := range cases {
+		owner, repo, host, err := parseRemoteURL(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, want, [3]string{owner, repo, host}, raw)
+	}
+
+	_, _, _, err := parseRemoteURL("/srv/git/demo.git")
+	require.Error(t, err)
+}
+
+type pullsAPI struct {
+	path   string
+	params map[string]string
+	pulls  []map[string]int
+}
+
+func (f *pullsAPI) REST(_ context.Context, method, path string, params map[string]string, _ interface{}, result interface{}) error {
+	f.path = method + " " + path
+	f.params = params
+	if target, ok := result.(*[]struct {
+		Number int `json:"number"`
+	}); ok {
+		for _, pull := range f.pulls {
+			*target = append(*target, struct {
+				Number int `json:"number"`
+			}{Number: pull["number"]})
+		}
+	}
+	return nil
+}
+
+func (f *pullsAPI) GraphQL(context.Context, string, map[string]interface{}, interface{}) error {
+	return errors.New("unexpected graphql call")
+}
+
+func TestResolveCurrentBranch(t *testing.T) {
+	stubGit(t, map[string]string{
+		remotesKey: "remote.origin.url git@github.com:me/demo.git\n" +
+			"remote.upstream.url https://github.com/octo/demo.git",
+		"symbolic-ref --quiet --short HEAD":  "feature",
+		"config --get branch.feature.merge":  "refs/heads/feature-remote",
+		"config --get branch.feature.remote": "origin",
+		"config --get remote.origin.url":     "git@github.com:me/demo.git",
+	})
+
+	api := &pullsAPI{pulls: []map[string]int{{"number": 21}}}
+	var host string
+	id, err := ResolveCurrentBranch(context.Background(), "", "", func(h string) ghcli.API {
+		host = h
+		return api
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Identity{Owner: "octo", Repo: "demo", Host: "github.com", Number: 21}, id)
+	assert.Equal(t, "github.com", host)
+	assert.Equal(t, "GET repos/octo/demo/pulls", api.path)
+	assert.Equal(t, map[string]string{"head": "me:feature-remote", "state": "open"}, api.params)
+}
+
+func TestResolveCurrentBranchNoPullRequest(t *testing.T) {
+	stubGit(t, map[string]string{
+		"symbolic-ref --quiet --short HEAD": "feature",
+	})
+
+	api := &pullsAPI{}
+	_, err := ResolveCurrentBranch(context.Background(), "octo/demo", "", func(string) ghcli.API { return api })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no open pull request found for branch "feature" in octo/demo`)
+	assert.Equal(t, map[string]string{"head": "octo:feature", "state": "open"}, api.params)
+}
+
+func TestResolveCurrentBranchDetachedHead(t *testing.T) {
+	stubGit(t, map[string]string{})
+
+	_, err := ResolveCurrentBranch(context.Background(), "octo/demo", "", func(string) ghcli.API { return &pullsAPI{} })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "current branch")
+}
+
+func TestRepoRoot(t *testing.T) {
+	stubGit(t, map[string]string{"rev-parse --show-toplevel": "/work/demo"})
+	root, err := RepoRoot()
+	require.NoError(t, err)
+	assert.Equal(t, "/work/demo", root)
+
+	stubGit(t, map[string]string{})
+	_, err = RepoRoot()
+	require.Error(t, err)
+}
@@ -0,0 +1,333 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// Manifest describes a complete review to create in one pass: the inline
+// comments to attach and, optionally, the event used to submit it.
+type Manifest struct {
+	Body     string            `json:"body,omitempty" yaml:"body,omitempty"`
+	Event    string            `json:"event,omitempty" yaml:"event,omitempty"`
+	Commit   string            `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Comments []ManifestComment `json:"comments" yaml:"comments"`
+}
+
+// ManifestComment mirrors ThreadInput for a single inline comment in a Manifest.
+type ManifestComment struct {
+	Path      string `json:"path" yaml:"path"`
+	Line      int    `json:"line" yaml:"line"`
+	Side      string `json:"side,omitempty" yaml:"side,omitempty"`
+	StartLine int    `json:"start_line,omitempty" yaml:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty" yaml:"start_side,omitempty"`
+	Body      string `json:"body" yaml:"body"`
+	// Suggestion, when set, replaces the commented lines via a suggested-change
+	// block appended to Body. An empty string suggests deleting the lines.
+	Suggestion *string `json:"suggestion,omitempty" yaml:"suggestion,omitempty"`
+	// FileLevel comments on the whole file; line, sides, start_line, and
+	// suggestion must be omitted.
+	FileLevel bool `json:"file_level,omitempty" yaml:"file_level,omitempty"`
+}
+
+// ApplyOptions controls how Apply handles failures.
+type ApplyOptions struct {
+	// Rollback deletes the pending review when any comment or the submission fails.
+	Rollback bool
+}
+
+// Comment statuses reported by Apply.
+const (
+	ApplyStatusCreated = "created"
+	ApplyStatusFailed  = "failed"
+	ApplyStatusSkipped = "skipped"
+)
+
+// ApplyCommentResult reports the outcome for one manifest comment.
+type ApplyCommentResult struct {
+	Index    int    `json:"index"`
+	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"`
+	Status   string `json:"status"`
+	ThreadID string `json:"thread_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ApplyResult summarizes an Apply run.
+type ApplyResult struct {
+	ReviewID    string               `json:"review_id,omitempty"`
+	State       string               `json:"state"`
+	Submitted   bool                 `json:"submitted"`
+	RolledBack  bool                 `json:"rolled_back,omitempty"`
+	SubmitError string               `json:"submit_error,omitempty"`
+	Comments    []ApplyCommentResult `json:"comments"`
+}
+
+// Failed reports whether any part of the run did not succeed.
+func (r *ApplyResult) Failed() bool {
+	if r.SubmitError != "" || r.RolledBack {
+		return true
+	}
+	for _, comment := range r.Comments {
+		if comment.Status != ApplyStatusCreated {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseManifest decodes a JSON or YAML manifest. Unknown fields are rejected
+// so typos such as "startLine" surface instead of being silently ignored.
+func ParseManifest(data []byte) (*Manifest, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errors.New("manifest is empty")
+	}
+
+	var manifest Manifest
+	if trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("parse manifest json: %w", err)
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(trimmed))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("parse manifest yaml: %w", err)
+		}
+	}
+	return &manifest, nil
+}
+
+// Normalize validates the manifest in place, upper-casing the event and sides
+// and defaulting sides to RIGHT. All problems are reported together.
+func (m *Manifest) Normalize() error {
+	var problems []string
+
+	m.Event = strings.ToUpper(strings.TrimSpace(m.Event))
+	switch m.Event {
+	case "", "APPROVE", "COMMENT", "REQUEST_CHANGES":
+	default:
+		problems = append(problems, fmt.Sprintf("invalid event %q: must be APPROVE, COMMENT, or REQUEST_CHANGES", m.Event))
+	}
+	if m.Event == "REQUEST_CHANGES" && strings.TrimSpace(m.Body) == "" && len(m.Comments) == 0 {
+		problems = append(problems, "REQUEST_CHANGES requires a body or at least one comment")
+	}
+
+	for i := range m.Comments {
+		comment := &m.Comments[i]
+		prefix := fmt.Sprintf("comments[%d]", i)
+
+		comment.Path = strings.TrimSpace(comment.Path)
+		if comment.Path == "" {
+			problems = append(problems, prefix+": path is required")
+		}
+		if strings.TrimSpace(comment.Body) == "" && comment.Suggestion == nil {
+			problems = append(problems, prefix+": body is required")
+		}
+		if comment.FileLevel {
+			if comment.Line != 0 || strings.TrimSpace(comment.Side) != "" || comment.StartLine != 0 || strings.TrimSpace(comment.StartSide) != "" || comment.Suggestion != nil {
+				problems = append(problems, prefix+": file_level comments take no line, side, start_line, start_side, or suggestion")
+			}
+			continue
+		}
+		if comment.Line <= 0 {
+			problems = append(problems, prefix+": line must be positive")
+		}
+
+		comment.Side = strings.ToUpper(strings.TrimSpace(comment.Side))
+		if comment.Side == "" {
+			comment.Side = "RIGHT"
+		}
+		if comment.Side != "LEFT" && comment.Side != "RIGHT" {
+			problems = append(problems, fmt.Sprintf("%s: invalid side %q: must be LEFT or RIGHT", prefix, comment.Side))
+		}
+
+		comment.StartSide = strings.ToUpper(strings.TrimSpace(comment.StartSide))
+		if comment.StartSide != "" && comment.StartSide != "LEFT" && comment.StartSide != "RIGHT" {
+			problems = append(problems, fmt.Sprintf("%s: invalid start_side %q: must be LEFT or RIGHT", prefix, comment.StartSide))
+		}
+		if comment.StartLine < 0 {
+			problems = append(problems, prefix+": start_line must be positive")
+		}
+		if comment.StartLine > 0 && comment.Line > 0 && comment.StartLine >= comment.Line {
+			problems = append(problems, prefix+": start_line must be less than line")
+		}
+		if comment.StartSide != "" && comment.StartLine == 0 {
+			problems = append(problems, prefix+": start_side requires start_line")
+		}
+		if comment.Suggestion != nil {
+			if err := CheckSuggestionSides(comment.Side, comment.StartSide); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", prefix, err))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid manifest:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func (c ManifestComment) threadInput(reviewID string) ThreadInput {
+	if c.FileLevel {
+		return ThreadInput{ReviewID: reviewID, Path: c.Path, Body: c.Body, FileLevel: true}
+	}
+	input := ThreadInput{
+		ReviewID: reviewID,
+		Path:     c.Path,
+		Line:     c.Line,
+		Side:     c.Side,
+		Body:     c.Body,
+	}
+	if c.Suggestion != nil {
+		// Trimmed here rather than in Normalize, which may run more than once.
+		input.Body = SuggestionBody(c.Body, TrimSuggestion(*c.Suggestion))
+	}
+	if c.StartLine > 0 {
+		startLine := c.StartLine
+		input.StartLine = &startLine
+	}
+	if c.StartSide != "" {
+		startSide := c.StartSide
+		input.StartSide = &startSide
+	}
+	return input
+}
+
+// Apply validates the manifest, opens a pending review, adds every comment,
+// and submits the review when the manifest names an event. Per-comment
+// outcomes are always returned; the error is reserved for failures before any
+// comment could be attempted (validation, opening the review).
+//
+// Without Rollback, a failed comment does not stop the run, but the review is
+// left pending rather than submitted so it can be fixed up. With Rollback, the
+// first failure deletes the pending review and the remaining comments are skipped.
+func (s *Service) Apply(ctx context.Context, pr resolver.Identity, manifest *Manifest, opts ApplyOptions) (*ApplyResult, error) {
+	if manifest == nil {
+		return nil, errors.New("manifest is required")
+	}
+	if err := manifest.Normalize(); err != nil {
+		return nil, err
+	}
+
+	// The body is set up front so it is kept when the review stays pending.
+	state, err := s.start(ctx, pr, manifest.Commit, manifest.Body)
+	if err != nil {
+		return nil, fmt.Errorf("open pending review: %w", err)
+	}
+
+	result := &ApplyResult{
+		ReviewID: state.ID,
+		State:    state.State,
+		Comments: make([]ApplyCommentResult, 0, len(manifest.Comments)),
+	}
+
+	failed := false
+	for i, comment := range manifest.Comments {
+		entry := ApplyCommentResult{Index: i, Path: comment.Path, Line: comment.Line}
+		if failed && opts.Rollback {
+			entry.Status = ApplyStatusSkipped
+			result.Comments = append(result.Comments, entry)
+			continue
+		}
+
+		thread, err := s.AddThread(ctx, pr, comment.threadInput(state.ID))
+		if err != nil {
+			failed = true
+			entry.Status = ApplyStatusFailed
+			entry.Error = err.Error()
+		} else {
+			entry.Status = ApplyStatusCreated
+			entry.ThreadID = thread.ID
+		}
+		result.Comments = append(result.Comments, entry)
+	}
+
+	if !failed && manifest.Event != "" {
+		status, err := s.Submit(ctx, pr, SubmitInput{ReviewID: state.ID, Event: manifest.Event, Body: manifest.Body})
+		switch {
+		case err != nil:
+			failed = true
+			result.SubmitError = err.Error()
+		case !status.Success:
+			failed = true
+			result.SubmitError = submitErrorMessage(status.Errors)
+		default:
+			result.Submitted = true
+			result.State = submittedState(manifest.Event)
+		}
+	}
+
+	if failed && opts.Rollback {
+		if err := s.DeleteReview(ctx, pr, state.ID); err != nil {
+			return result, fmt.Errorf("roll back pending review %s: %w", state.ID, err)
+		}
+		result.RolledBack = true
+		result.ReviewID = ""
+		result.State = "DELETED"
+	}
+
+	return result, nil
+}
+
+// DeleteReview deletes a pending review together with its comments.
+func (s *Service) DeleteReview(ctx context.Context, _ resolver.Identity, reviewID string) error {
+	reviewID = strings.TrimSpace(reviewID)
+	if reviewID == "" {
+		return errors.New("review id is required")
+	}
+	if !strings.HasPrefix(reviewID, "PRR_") {
+		return fmt.Errorf("invalid review id %q: must be a GraphQL node id (PRR_...)", reviewID)
+	}
+
+	const mutation = `mutation($input:DeletePullRequestReviewInput!){
+  deletePullRequestReview(input:$input){
+    pullRequestReview { id }
+  }
+}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestReviewId": reviewID,
+		},
+	}
+
+	var resp struct{}
+	return s.API.GraphQL(ctx, mutation, variables, &resp)
+}
+
+func submitErrorMessage(entries []ghcli.GraphQLErrorEntry) string {
+	messages := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if msg := strings.TrimSpace(entry.Message); msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+	if len(messages) == 0 {
+		return "review submission failed"
+	}
+	return strings.Join(messages, "; ")
+}
+
+func submittedState(event string) string {
+	switch event {
+	case "APPROVE":
+		return "APPROVED"
+	case "REQUEST_CHANGES":
+		return "CHANGES_REQUESTED"
+	default:
+		return "COMMENTED"
+	}
+}
@@ -0,0 +1,224 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+func TestParseManifestYAMLAndJSON(t *testing.T) {
+	yamlManifest, err := ParseManifest([]byte(`
+body: Looks mostly good
+event: comment
+comments:
+  - path: a.go
+    line: 10
+    body: nit
+  - path: b.go
+    line: 20
+    start_line: 18
+    side: left
+    body: multi
+`))
+	require.NoError(t, err)
+	require.NoError(t, yamlManifest.Normalize())
+	assert.Equal(t, "COMMENT", yamlManifest.Event)
+	require.Len(t, yamlManifest.Comments, 2)
+	assert.Equal(t, "RIGHT", yamlManifest.Comments[0].Side)
+	assert.Equal(t, "LEFT", yamlManifest.Comments[1].Side)
+	assert.Equal(t, 18, yamlManifest.Comments[1].StartLine)
+
+	jsonManifest, err := ParseManifest([]byte(`{"comments":[{"path":"a.go","line":3,"body":"x"}]}`))
+	require.NoError(t, err)
+	require.Len(t, jsonManifest.Comments, 1)
+
+	_, err = ParseManifest([]byte(`{"comments":[{"path":"a.go","line":3,"body":"x","startLine":1}]}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "startLine")
+
+	_, err = ParseManifest([]byte("   "))
+	require.Error(t, err)
+}
+
+func TestManifestNormalizeReportsAllProblems(t *testing.T) {
+	manifest := &Manifest{
+		Event: "merge",
+		Comments: []ManifestComment{
+			{Path: "", Line: 0, Body: "x"},
+			{Path: "a.go", Line: 5, StartLine: 7, Side: "up", Body: " "},
+		},
+	}
+
+	err := manifest.Normalize()
+	require.Error(t, err)
+	msg := err.Error()
+	assert.Contains(t, msg, `invalid event "MERGE"`)
+	assert.Contains(t, msg, "comments[0]: path is required")
+	assert.Contains(t, msg, "comments[0]: line must be positive")
+	assert.Contains(t, msg, "comments[1]: body is required")
+	assert.Contains(t, msg, `comments[1]: invalid side "UP"`)
+	assert.Contains(t, msg, "comments[1]: start_line must be less than line")
+}
+
+func TestManifestNormalizeFileLevel(t *testing.T) {
+	manifest := &Manifest{Comments: []ManifestComment{
+		{Path: "a.go", Body: "split this file", FileLevel: true},
+		{Path: "b.go", Line: 3, Body: "x", FileLevel: true},
+	}}
+
+	err := manifest.Normalize()
+	require.Error(t, err)
+	assert.Equal(t, "invalid manifest:\n  comments[1]: file_level comments take no line, side, start_line, start_side, or suggestion", err.Error())
+
+	manifest.Comments = manifest.Comments[:1]
+	require.NoError(t, manifest.Normalize())
+	assert.Empty(t, manifest.Comments[0].Side)
+	assert.Equal(t, ThreadInput{ReviewID: "PRR_x", Path: "a.go", Body: "split this file", FileLevel: true}, manifest.Comments[0].threadInput("PRR_x"))
+}
+
+type obj = map[string]interface{}
+
+// applyAPI scripts the GraphQL calls made by Apply.
+func applyAPI(t *testing.T, failPath string, calls *[]string) *fakeAPI {
+	t.Helper()
+	return &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "headRefOid"):
+			*calls = append(*calls, "pull")
+			return assign(result, obj{"repository": obj{"pullRequest": obj{"id": "PR_node", "headRefOid": "abc"}}})
+		case strings.Contains(query, "addPullRequestReviewThread"):
+			input := variables["input"].(map[string]interface{})
+			path := input["path"].(string)
+			*calls = append(*calls, "thread:"+path)
+			if path == failPath {
+				return errors.New("path not in diff")
+			}
+			return assign(result, obj{"addPullRequestReviewThread": obj{"thread": obj{"id": "PRRT_" + path, "path": path}}})
+		case strings.Contains(query, "addPullRequestReview("):
+			call := "start"
+			if body, ok := variables["input"].(map[string]interface{})["body"]; ok {
+				call += ":" + body.(string)
+			}
+			*calls = append(*calls, call)
+			return assign(result, obj{"addPullRequestReview": obj{"pullRequestReview": obj{"id": "PRR_new", "state": "PENDING"}}})
+		case strings.Contains(query, "submitPullRequestReview"):
+			input := variables["input"].(map[string]interface{})
+			*calls = append(*calls, "submit:"+input["event"].(string))
+			return nil
+		case strings.Contains(query, "deletePullRequestReview"):
+			input := variables["input"].(map[string]interface{})
+			*calls = append(*calls, "delete:"+input["pullRequestReviewId"].(string))
+			return nil
+		}
+		return errors.New("unexpected query")
+	}}
+}
+
+func TestServiceApplySubmits(t *testing.T) {
+	var calls []string
+	svc := NewService(applyAPI(t, "", &calls))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	result, err := svc.Apply(context.Background(), pr, &Manifest{
+		Event:    "approve",
+		Comments: []ManifestComment{{Path: "a.go", Line: 1, Body: "x"}, {Path: "b.go", Line: 2, Body: "y"}},
+	}, ApplyOptions{})
+	require.NoError(t, err)
+	assert.False(t, result.Failed())
+	assert.True(t, result.Submitted)
+	assert.Equal(t, "APPROVED", result.State)
+	assert.Equal(t, "PRR_new", result.ReviewID)
+	require.Len(t, result.Comments, 2)
+	assert.Equal(t, ApplyStatusCreated, result.Comments[1].Status)
+	assert.Equal(t, "PRRT_b.go", result.Comments[1].ThreadID)
+	assert.Equal(t, []string{"pull", "start", "thread:a.go", "thread:b.go", "submit:APPROVE"}, calls)
+}
+
+func TestServiceApplyKeepsPendingReviewOnFailure(t *testing.T) {
+	var calls []string
+	svc := NewService(applyAPI(t, "a.go", &calls))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	result, err := svc.Apply(context.Background(), pr, &Manifest{
+		Event:    "COMMENT",
+		Comments: []ManifestComment{{Path: "a.go", Line: 1, Body: "x"}, {Path: "b.go", Line: 2, Body: "y"}},
+	}, ApplyOptions{})
+	require.NoError(t, err)
+	assert.True(t, result.Failed())
+	assert.False(t, result.Submitted)
+	assert.Equal(t, "PENDING", result.State)
+	assert.Equal(t, ApplyStatusFailed, result.Comments[0].Status)
+	assert.Equal(t, "path not in diff", result.Comments[0].Error)
+	assert.Equal(t, ApplyStatusCreated, result.Comments[1].Status)
+	assert.Equal(t, []string{"pull", "start", "thread:a.go", "thread:b.go"}, calls)
+}
+
+func TestServiceApplyKeepsBodyWithoutEvent(t *testing.T) {
+	var calls []string
+	svc := NewService(applyAPI(t, "", &calls))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	result, err := svc.Apply(context.Background(), pr, &Manifest{
+		Body:     "Overall looks good.",
+		Comments: []ManifestComment{{Path: "a.go", Line: 1, Body: "x"}},
+	}, ApplyOptions{})
+	require.NoError(t, err)
+	assert.False(t, result.Submitted)
+	assert.Equal(t, "PENDING", result.State)
+	assert.Equal(t, []string{"pull", "start:Overall looks good.", "thread:a.go"}, calls)
+}
+
+func TestServiceApplyKeepsTrailingBlankLineInSuggestion(t *testing.T) {
+	var calls []string
+	api := applyAPI(t, "", &calls)
+	answer := api.graphqlFunc
+	var body string
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "addPullRequestReviewThread") {
+			body = variables["input"].(map[string]interface{})["body"].(string)
+		}
+		return answer(query, variables, result)
+	}
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	suggestion := "x := 1\n\n"
+	manifest := &Manifest{Comments: []ManifestComment{{Path: "a.go", Line: 2, StartLine: 1, Suggestion: &suggestion}}}
+	require.NoError(t, manifest.Normalize())
+
+	_, err := NewService(api).Apply(context.Background(), pr, manifest, ApplyOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "```suggestion\nx := 1\n\n```", body)
+}
+
+func TestServiceApplyRollsBack(t *testing.T) {
+	var calls []string
+	svc := NewService(applyAPI(t, "a.go", &calls))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+	result, err := svc.Apply(context.Background(), pr, &Manifest{
+		Event:    "COMMENT",
+		Comments: []ManifestComment{{Path: "a.go", Line: 1, Body: "x"}, {Path: "b.go", Line: 2, Body: "y"}},
+	}, ApplyOptions{Rollback: true})
+	require.NoError(t, err)
+	assert.True(t, result.RolledBack)
+	assert.Empty(t, result.ReviewID)
+	assert.Equal(t, ApplyStatusSkipped, result.Comments[1].Status)
+	assert.Equal(t, []string{"pull", "start", "thread:a.go", "delete:PRR_new"}, calls)
+}
+
+func TestServiceApplyValidatesBeforeCreating(t *testing.T) {
+	var calls []string
+	svc := NewService(applyAPI(t, "", &calls))
+
+	_, err := svc.Apply(context.Background(), resolver.Identity{}, &Manifest{
+		Comments: []ManifestComment{{Path: "a.go", Line: 1}},
+	}, ApplyOptions{})
+	require.Error(t, err)
+	assert.Empty(t, calls)
+}
@@ -0,0 +1,142 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+)
+
+const pendingReviewThreadsQuery = `query PendingReviewThreads($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReview {
+      pullRequest {
+        reviewThreads(last: 100) {
+          nodes {
+            id
+            path
+            subjectType
+            isOutdated
+            line
+            comments(first: 1) {
+              nodes {
+                body
+                pullRequestReview { id }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const reviewStateQuery = `query ReviewState($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReview { id state }
+  }
+}`
+
+// threadLanded builds a dedupe check for addPullRequestReviewThread that looks
+// for a thread in the pending review matching the requested path, line and body.
+// A zero line matches file-level threads only.
+func (s *Service) threadLanded(reviewID, path string, line int, body string) ghcli.DedupeFunc {
+	return func(ctx context.Context, result interface{}) (bool, error) {
+		var resp struct {
+			Node *struct {
+				PullRequest *struct {
+					ReviewThreads struct {
+						Nodes []struct {
+							ID          string `json:"id"`
+							Path        string `json:"path"`
+							SubjectType string `json:"subjectType"`
+							IsOutdated  bool   `json:"isOutdated"`
+							Line        *int   `json:"line"`
+							Comments    struct {
+								Nodes []struct {
+									Body              string `json:"body"`
+									PullRequestReview *struct {
+										ID string `json:"id"`
+									} `json:"pullRequestReview"`
+								} `json:"nodes"`
+							} `json:"comments"`
+						} `json:"nodes"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"node"`
+		}
+		variables := map[string]interface{}{"id": reviewID}
+		if err := s.API.GraphQL(ctx, pendingReviewThreadsQuery, variables, &resp); err != nil {
+			return false, err
+		}
+		if resp.Node == nil || resp.Node.PullRequest == nil {
+			return false, nil
+		}
+
+		for _, thread := range resp.Node.PullRequest.ReviewThreads.Nodes {
+			if thread.Path != path || len(thread.Comments.Nodes) == 0 {
+				continue
+			}
+			if line == 0 {
+				if thread.SubjectType != "FILE" {
+					continue
+				}
+			} else if thread.Line == nil || *thread.Line != line {
+				continue
+			}
+			first := thread.Comments.Nodes[0]
+			if first.PullRequestReview == nil || first.PullRequestReview.ID != reviewID {
+				continue
+			}
+			if strings.TrimSpace(first.Body) != body {
+				continue
+			}
+			payload := map[string]interface{}{
+				"addPullRequestReviewThread": map[string]interface{}{
+					"thread": map[string]interface{}{
+						"id":          thread.ID,
+						"path":        thread.Path,
+						"subjectType": thread.SubjectType,
+						"isOutdated":  thread.IsOutdated,
+						"line":        thread.Line,
+					},
+				},
+			}
+			return true, assignResult(result, payload)
+		}
+		return false, nil
+	}
+}
+
+// reviewSubmitted builds a dedupe check for submitPullRequestReview that treats
+// the submission as landed once the review has left the PENDING state.
+func (s *Service) reviewSubmitted(reviewID string) ghcli.DedupeFunc {
+	return func(ctx context.Context, _ interface{}) (bool, error) {
+		var resp struct {
+			Node *struct {
+				State string `json:"state"`
+			} `json:"node"`
+		}
+		variables := map[string]interface{}{"id": reviewID}
+		if err := s.API.GraphQL(ctx, reviewStateQuery, variables, &resp); err != nil {
+			return false, err
+		}
+		if resp.Node == nil {
+			return false, nil
+		}
+		state := strings.ToUpper(strings.TrimSpace(resp.Node.State))
+		return state != "" && state != "PENDING", nil
+	}
+}
+
+func assignResult(result interface{}, payload interface{}) error {
+	if result == nil {
+		return nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
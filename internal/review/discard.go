@@ -0,0 +1,82 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// DiscardInput selects the pending review to delete.
+type DiscardInput struct {
+	// ReviewID is the GraphQL node id of the review. When empty, the
+	// authenticated viewer's pending review on the pull request is used.
+	ReviewID string
+	// Force allows discarding a review that still holds comments.
+	Force bool
+}
+
+// DiscardResult reports the review that was deleted.
+type DiscardResult struct {
+	ID           string `json:"id"`
+	State        string `json:"state"`
+	CommentCount int    `json:"comment_count"`
+}
+
+const reviewDetailsQuery = `query ReviewDetails($id: ID!) {
+  node(id: $id) {
+    ... on PullRequestReview {
+      id
+      state
+      comments { totalCount }
+    }
+  }
+}`
+
+// Discard deletes a pending review together with its comments. Reviews that
+// still contain comments are only deleted when input.Force is set.
+func (s *Service) Discard(ctx context.Context, pr resolver.Identity, input DiscardInput) (*DiscardResult, error) {
+	reviewID := strings.TrimSpace(input.ReviewID)
+	if reviewID == "" {
+		pending, err := s.SolePending(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		reviewID = pending.ID
+	}
+	if !strings.HasPrefix(reviewID, "PRR_") {
+		return nil, fmt.Errorf("invalid review id %q: must be a GraphQL node id (PRR_...)", reviewID)
+	}
+
+	var resp struct {
+		Node *struct {
+			ID       string `json:"id"`
+			State    string `json:"state"`
+			Comments struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"comments"`
+		} `json:"node"`
+	}
+	if err := s.API.GraphQL(ctx, reviewDetailsQuery, map[string]interface{}{"id": reviewID}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Node == nil || strings.TrimSpace(resp.Node.ID) == "" {
+		return nil, fmt.Errorf("review %s not found", reviewID)
+	}
+
+	state := strings.ToUpper(strings.TrimSpace(resp.Node.State))
+	if state != "PENDING" {
+		return nil, fmt.Errorf("review %s is %s; only pending reviews can be discarded", reviewID, state)
+	}
+	count := resp.Node.Comments.TotalCount
+	if count > 0 && !input.Force {
+		return nil, fmt.Errorf("pending review %s still has %d comment(s); pass --force to discard it", reviewID, count)
+	}
+
+	if err := s.DeleteReview(ctx, pr, reviewID); err != nil {
+		return nil, err
+	}
+
+	return &DiscardResult{ID: reviewID, State: "DELETED", CommentCount: count}, nil
+}
@@ -0,0 +1,97 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+func discardGraphQL(t *testing.T, state string, comments int, deleted *[]string) func(string, map[string]interface{}, interface{}) error {
+	return func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ReviewDetails"):
+			return assignEnvelope(result, obj{"data": obj{"node": obj{
+				"id":       variables["id"],
+				"state":    state,
+				"comments": obj{"totalCount": comments},
+			}}})
+		case strings.Contains(query, "deletePullRequestReview"):
+			input, ok := variables["input"].(map[string]interface{})
+			require.True(t, ok)
+			*deleted = append(*deleted, input["pullRequestReviewId"].(string))
+			return assignEnvelope(result, obj{"data": obj{"deletePullRequestReview": obj{"pullRequestReview": obj{"id": input["pullRequestReviewId"]}}}})
+		default:
+			return errors.New("unexpected query")
+		}
+	}
+}
+
+func TestDiscardExplicitReview(t *testing.T) {
+	var deleted []string
+	api := &fakeAPI{graphqlFunc: discardGraphQL(t, "PENDING", 0, &deleted)}
+
+	result, err := NewService(api).Discard(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, DiscardInput{ReviewID: "PRR_kwM1"})
+	require.NoError(t, err)
+	assert.Equal(t, &DiscardResult{ID: "PRR_kwM1", State: "DELETED"}, result)
+	assert.Equal(t, []string{"PRR_kwM1"}, deleted)
+}
+
+func TestDiscardRequiresForceWhenCommentsRemain(t *testing.T) {
+	var deleted []string
+	api := &fakeAPI{graphqlFunc: discardGraphQL(t, "PENDING", 3, &deleted)}
+	svc := NewService(api)
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+
+	_, err := svc.Discard(context.Background(), pr, DiscardInput{ReviewID: "PRR_kwM1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "still has 3 comment(s); pass --force")
+	assert.Empty(t, deleted)
+
+	result, err := svc.Discard(context.Background(), pr, DiscardInput{ReviewID: "PRR_kwM1", Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.CommentCount)
+	assert.Equal(t, []string{"PRR_kwM1"}, deleted)
+}
+
+func TestDiscardRejectsSubmittedReview(t *testing.T) {
+	var deleted []string
+	api := &fakeAPI{graphqlFunc: discardGraphQL(t, "COMMENTED", 0, &deleted)}
+
+	_, err := NewService(api).Discard(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, DiscardInput{ReviewID: "PRR_kwM1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only pending reviews can be discarded")
+	assert.Empty(t, deleted)
+}
+
+func TestDiscardLocatesViewerPendingReview(t *testing.T) {
+	var deleted []string
+	discard := discardGraphQL(t, "PENDING", 0, &deleted)
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "ViewerLogin"):
+			return assignEnvelope(result, obj{"data": obj{"viewer": obj{"login": "casey"}}})
+		case strings.Contains(query, "PendingReviews"):
+			return assignEnvelope(result, obj{"data": obj{"repository": obj{"pullRequest": obj{"reviews": obj{
+				"nodes": []obj{
+					{"id": "PRR_other", "databaseId": 4, "state": "PENDING", "updatedAt": "2024-01-02T00:00:00Z", "author": obj{"login": "octocat"}},
+					{"id": "PRR_mine", "databaseId": 5, "state": "PENDING", "updatedAt": "2024-01-01T00:00:00Z", "author": obj{"login": "casey"}},
+				},
+				"pageInfo": obj{"hasNextPage": false},
+			}}}}})
+		default:
+			return discard(query, variables, result)
+		}
+	}
+
+	result, err := NewService(api).Discard(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, DiscardInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "PRR_mine", result.ID)
+	assert.Equal(t, []string{"PRR_mine"}, deleted)
+}
@@ -1,6 +1,7 @@
 package review
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -20,6 +21,7 @@ type LatestOptions struct {
 // ReviewSummary captures a subset of review metadata returned to callers.
 type ReviewSummary struct {
 	ID                int64       `json:"id"`
+	NodeID            string      `json:"node_id,omitempty"`
 	User              *ReviewUser `json:"user,omitempty"`
 	SubmittedAt       *string     `json:"submitted_at,omitempty"`
 	State             string      `json:"state,omitempty"`
@@ -34,10 +36,10 @@ type ReviewUser struct {
 }
 
 // LatestSubmitted locates the most recent submitted review for the requested reviewer.
-func (s *Service) LatestSubmitted(pr resolver.Identity, opts LatestOptions) (*ReviewSummary, error) {
+func (s *Service) LatestSubmitted(ctx context.Context, pr resolver.Identity, opts LatestOptions) (*ReviewSummary, error) {
 	reviewer := strings.TrimSpace(opts.Reviewer)
 	if reviewer == "" {
-		login, err := s.currentLogin()
+		login, err := s.currentLogin(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("resolve authenticated user: %w", err)
 		}
@@ -62,7 +64,7 @@ func (s *Service) LatestSubmitted(pr resolver.Identity, opts LatestOptions) (*Re
 			"page":     strconv.Itoa(current),
 		}
 		path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", pr.Owner, pr.Repo, pr.Number)
-		if err := s.API.REST("GET", path, params, nil, &chunk); err != nil {
+		if err := s.API.REST(ctx, "GET", path, params, nil, &chunk); err != nil {
 			return nil, err
 		}
 
@@ -94,6 +96,7 @@ func (s *Service) LatestSubmitted(pr resolver.Identity, opts LatestOptions) (*Re
 
 	result := ReviewSummary{
 		ID:                latest.ID,
+		NodeID:            strings.TrimSpace(latest.NodeID),
 		State:             latest.State,
 		AuthorAssociation: strings.TrimSpace(latest.AuthorAssociation),
 		HTMLURL:           strings.TrimSpace(latest.HTMLURL),
@@ -133,11 +136,11 @@ func clampPerPage(value int) int {
 	}
 }
 
-func (s *Service) currentLogin() (string, error) {
+func (s *Service) currentLogin(ctx context.Context) (string, error) {
 	var user struct {
 		Login string `json:"login"`
 	}
-	if err := s.API.REST("GET", "user", nil, nil, &user); err != nil {
+	if err := s.API.REST(ctx, "GET", "user", nil, nil, &user); err != nil {
 		return "", err
 	}
 	login := strings.TrimSpace(user.Login)
@@ -1,6 +1,7 @@
 package review
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -60,7 +61,7 @@ func TestLatestSubmittedDefaultsToAuthenticatedReviewer(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	summary, err := svc.LatestSubmitted(pr, LatestOptions{})
+	summary, err := svc.LatestSubmitted(context.Background(), pr, LatestOptions{})
 	require.NoError(t, err)
 	require.NotNil(t, summary)
 	assert.Equal(t, int64(200), summary.ID)
@@ -98,7 +99,7 @@ func TestLatestSubmittedWithReviewerOverride(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	summary, err := svc.LatestSubmitted(pr, LatestOptions{Reviewer: "octocat", PerPage: 50, Page: 1})
+	summary, err := svc.LatestSubmitted(context.Background(), pr, LatestOptions{Reviewer: "octocat", PerPage: 50, Page: 1})
 	require.NoError(t, err)
 	assert.Equal(t, int64(20), summary.ID)
 	require.NotNil(t, summary.User)
@@ -121,7 +122,7 @@ func TestLatestSubmittedNoMatches(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	_, err := svc.LatestSubmitted(pr, LatestOptions{})
+	_, err := svc.LatestSubmitted(context.Background(), pr, LatestOptions{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no submitted reviews")
 }
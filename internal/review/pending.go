@@ -1,6 +1,7 @@
 package review
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -26,16 +27,17 @@ type PendingSummary struct {
 	User              *ReviewUser `json:"user,omitempty"`
 }
 
-// PendingSummaries retrieves pending reviews for the requested reviewer.
-
-func (s *Service) PendingSummaries(pr resolver.Identity, opts PendingOptions) ([]PendingSummary, string, error) {
+// PendingSummaries retrieves pending reviews for the requested reviewer,
+// ordered from oldest to most recently updated. It returns an empty slice
+// when the reviewer has no pending review.
+func (s *Service) PendingSummaries(ctx context.Context, pr resolver.Identity, opts PendingOptions) ([]PendingSummary, string, error) {
 	reviewerFilter := strings.TrimSpace(opts.Reviewer)
 	reviewer := reviewerFilter
 	perPage := clampPerPage(opts.PerPage)
 
 	useViewer := false
 	if reviewer == "" {
-		login, err := s.currentViewer()
+		login, err := s.currentViewer(ctx)
 		if err != nil {
 			return nil, "", err
 		}
@@ -108,26 +110,24 @@ func (s *Service) PendingSummaries(pr resolver.Identity, opts PendingOptions) ([
 		}
 
 		var response struct {
-			Data struct {
-				Repository *struct {
-					PullRequest *struct {
-						Reviews *struct {
-							Nodes    []pendingNode `json:"nodes"`
-							PageInfo struct {
-								HasNextPage bool   `json:"hasNextPage"`
-								EndCursor   string `json:"endCursor"`
-							} `json:"pageInfo"`
-						} `json:"reviews"`
-					} `json:"pullRequest"`
-				} `json:"repository"`
-			} `json:"data"`
+			Repository *struct {
+				PullRequest *struct {
+					Reviews *struct {
+						Nodes    []pendingNode `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"reviews"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
 		}
 
-		if err := s.API.GraphQL(query, variables, &response); err != nil {
+		if err := s.API.GraphQL(ctx, query, variables, &response); err != nil {
 			return nil, "", err
 		}
 
-		repo := response.Data.Repository
+		repo := response.Repository
 		if repo == nil || repo.PullRequest == nil || repo.PullRequest.Reviews == nil {
 			return nil, reviewer, fmt.Errorf("pull request %s/%s#%d not found", pr.Owner, pr.Repo, pr.Number)
 		}
@@ -204,10 +204,6 @@ func (s *Service) PendingSummaries(pr resolver.Identity, opts PendingOptions) ([
 		reviewer = reviewerFilter
 	}
 
-	if len(timedSummaries) == 0 {
-		return nil, reviewer, fmt.Errorf("no pending reviews for %s", reviewer)
-	}
-
 	sort.Slice(timedSummaries, func(i, j int) bool {
 		if timedSummaries[i].when.Equal(timedSummaries[j].when) {
 			return timedSummaries[i].summary.DatabaseID < timedSummaries[j].summary.DatabaseID
@@ -224,15 +220,37 @@ func (s *Service) PendingSummaries(pr resolver.Identity, opts PendingOptions) ([
 }
 
 // LatestPending locates the most recent pending review for the requested reviewer.
-func (s *Service) LatestPending(pr resolver.Identity, opts PendingOptions) (*PendingSummary, error) {
-	summaries, reviewer, err := s.PendingSummaries(pr, opts)
+func (s *Service) LatestPending(ctx context.Context, pr resolver.Identity, opts PendingOptions) (*PendingSummary, error) {
+	summaries, reviewer, err := s.PendingSummaries(ctx, pr, opts)
 	if err != nil {
 		return nil, err
 	}
 	if len(summaries) == 0 {
-		return nil, fmt.Errorf("no pending reviews for %s", reviewer)
+		return nil, fmt.Errorf("%w for %s", ErrNoPendingReview, reviewer)
 	}
 
 	latest := summaries[len(summaries)-1]
 	return &latest, nil
 }
+
+// SolePending returns the authenticated viewer's pending review on the pull
+// request. GitHub allows one pending review per user, so finding several is
+// reported as an error rather than guessing.
+func (s *Service) SolePending(ctx context.Context, pr resolver.Identity) (*PendingSummary, error) {
+	summaries, reviewer, err := s.PendingSummaries(ctx, pr, PendingOptions{})
+	if err != nil {
+		return nil, err
+	}
+	switch len(summaries) {
+	case 0:
+		return nil, fmt.Errorf("%w for %s", ErrNoPendingReview, reviewer)
+	case 1:
+		return &summaries[0], nil
+	default:
+		ids := make([]string, len(summaries))
+		for i, summary := range summaries {
+			ids[i] = summary.ID
+		}
+		return nil, fmt.Errorf("%d pending reviews for %s (%s); specify one explicitly", len(summaries), reviewer, strings.Join(ids, ", "))
+	}
+}
@@ -1,6 +1,8 @@
 package review
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -12,6 +14,25 @@ import (
 
 func int64Ptr(v int64) *int64 { return &v }
 
+// assignEnvelope mimics ghcli's GraphQL decoding: a raw response payload has its
+// "data" member unwrapped before populating result.
+func assignEnvelope(result interface{}, payload interface{}) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return err
+	}
+	if len(envelope.Data) > 0 {
+		return json.Unmarshal(envelope.Data, result)
+	}
+	return json.Unmarshal(raw, result)
+}
+
 type testReviewNode struct {
 	ID                string `json:"id"`
 	DatabaseID        *int64 `json:"databaseId"`
@@ -41,7 +62,7 @@ func TestLatestPendingDefaultsToAuthenticatedReviewer(t *testing.T) {
 				} `json:"data"`
 			}{}
 			payload.Data.Viewer.Login = "casey"
-			return assign(result, payload)
+			return assignEnvelope(result, payload)
 		}
 
 		pendingCalls++
@@ -100,12 +121,12 @@ func TestLatestPendingDefaultsToAuthenticatedReviewer(t *testing.T) {
 		payload.Data.Repository.PullRequest.Reviews.PageInfo.HasNextPage = false
 		payload.Data.Repository.PullRequest.Reviews.PageInfo.EndCursor = ""
 
-		return assign(result, payload)
+		return assignEnvelope(result, payload)
 	}
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	summary, err := svc.LatestPending(pr, PendingOptions{})
+	summary, err := svc.LatestPending(context.Background(), pr, PendingOptions{})
 	require.NoError(t, err)
 	require.NotNil(t, summary)
 	assert.Equal(t, "R_pending_7", summary.ID)
@@ -200,12 +221,12 @@ func TestLatestPendingWithReviewerOverride(t *testing.T) {
 			payload.Data.Repository.PullRequest.Reviews.PageInfo.EndCursor = ""
 		}
 
-		return assign(result, payload)
+		return assignEnvelope(result, payload)
 	}
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	summary, err := svc.LatestPending(pr, PendingOptions{Reviewer: "octocat", PerPage: 50})
+	summary, err := svc.LatestPending(context.Background(), pr, PendingOptions{Reviewer: "octocat", PerPage: 50})
 	require.NoError(t, err)
 	require.NotNil(t, summary)
 	assert.Equal(t, "R_pending_42", summary.ID)
@@ -230,7 +251,7 @@ func TestLatestPendingAllowsMissingUserID(t *testing.T) {
 				} `json:"data"`
 			}{}
 			payload.Data.Viewer.Login = "casey"
-			return assign(result, payload)
+			return assignEnvelope(result, payload)
 		}
 
 		nodes := []testReviewNode{
@@ -267,12 +288,12 @@ func TestLatestPendingAllowsMissingUserID(t *testing.T) {
 		payload.Data.Repository.PullRequest.Reviews.Nodes = nodes
 		payload.Data.Repository.PullRequest.Reviews.PageInfo.HasNextPage = false
 		payload.Data.Repository.PullRequest.Reviews.PageInfo.EndCursor = ""
-		return assign(result, payload)
+		return assignEnvelope(result, payload)
 	}
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	summary, err := svc.LatestPending(pr, PendingOptions{})
+	summary, err := svc.LatestPending(context.Background(), pr, PendingOptions{})
 	require.NoError(t, err)
 	require.NotNil(t, summary)
 	require.NotNil(t, summary.User)
@@ -292,7 +313,7 @@ func TestLatestPendingNoMatches(t *testing.T) {
 				} `json:"data"`
 			}{}
 			payload.Data.Viewer.Login = "casey"
-			return assign(result, payload)
+			return assignEnvelope(result, payload)
 		}
 
 		payload := struct {
@@ -313,12 +334,55 @@ func TestLatestPendingNoMatches(t *testing.T) {
 		payload.Data.Repository.PullRequest.Reviews.Nodes = []testReviewNode{}
 		payload.Data.Repository.PullRequest.Reviews.PageInfo.HasNextPage = false
 		payload.Data.Repository.PullRequest.Reviews.PageInfo.EndCursor = ""
-		return assign(result, payload)
+		return assignEnvelope(result, payload)
 	}
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	_, err := svc.LatestPending(pr, PendingOptions{})
+	_, err := svc.LatestPending(context.Background(), pr, PendingOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no pending reviews for casey")
+}
+
+func pendingReviewsGraphQL(nodes []obj) func(string, map[string]interface{}, interface{}) error {
+	return func(query string, variables map[string]interface{}, result interface{}) error {
+		if strings.Contains(query, "ViewerLogin") {
+			return assignEnvelope(result, obj{"data": obj{"viewer": obj{"login": "casey"}}})
+		}
+		return assignEnvelope(result, obj{"data": obj{"repository": obj{"pullRequest": obj{"reviews": obj{
+			"nodes":    nodes,
+			"pageInfo": obj{"hasNextPage": false},
+		}}}}})
+	}
+}
+
+func TestPendingSummariesEmpty(t *testing.T) {
+	api := &fakeAPI{graphqlFunc: pendingReviewsGraphQL([]obj{})}
+
+	summaries, reviewer, err := NewService(api).PendingSummaries(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}, PendingOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "casey", reviewer)
+	assert.NotNil(t, summaries)
+	assert.Empty(t, summaries)
+}
+
+func TestSolePending(t *testing.T) {
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+	mine := obj{"id": "PRR_one", "databaseId": 1, "state": "PENDING", "updatedAt": "2024-06-01T00:00:00Z", "author": obj{"login": "casey"}}
+
+	api := &fakeAPI{graphqlFunc: pendingReviewsGraphQL([]obj{mine})}
+	summary, err := NewService(api).SolePending(context.Background(), pr)
+	require.NoError(t, err)
+	assert.Equal(t, "PRR_one", summary.ID)
+
+	api = &fakeAPI{graphqlFunc: pendingReviewsGraphQL([]obj{})}
+	_, err = NewService(api).SolePending(context.Background(), pr)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no pending reviews for casey")
+
+	second := obj{"id": "PRR_two", "databaseId": 2, "state": "PENDING", "updatedAt": "2024-06-02T00:00:00Z", "author": obj{"login": "casey"}}
+	api = &fakeAPI{graphqlFunc: pendingReviewsGraphQL([]obj{mine, second})}
+	_, err = NewService(api).SolePending(context.Background(), pr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 pending reviews for casey (PRR_one, PRR_two)")
 }
@@ -1,6 +1,7 @@
 package review
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,6 +19,9 @@ type Service struct {
 // ErrViewerLoginUnavailable indicates the authenticated viewer login could not be resolved via GraphQL.
 var ErrViewerLoginUnavailable = errors.New("viewer login unavailable")
 
+// ErrNoPendingReview indicates the reviewer has no pending review on the pull request.
+var ErrNoPendingReview = errors.New("no pending reviews")
+
 // ReviewState contains metadata about a review after opening or submitting it.
 type ReviewState struct {
 	ID          string  `json:"id"`
@@ -32,14 +36,17 @@ type SubmitStatus struct {
 }
 
 // ReviewThread represents an inline comment thread added to a pending review.
+// SubjectType is LINE for line comments and FILE for file-level comments.
 type ReviewThread struct {
-	ID         string `json:"id"`
-	Path       string `json:"path"`
-	IsOutdated bool   `json:"is_outdated"`
-	Line       *int   `json:"line,omitempty"`
+	ID          string `json:"id"`
+	Path        string `json:"path"`
+	SubjectType string `json:"subject_type,omitempty"`
+	IsOutdated  bool   `json:"is_outdated"`
+	Line        *int   `json:"line,omitempty"`
 }
 
-// ThreadInput describes the inline comment details for AddThread.
+// ThreadInput describes the inline comment details for AddThread. A FileLevel
+// thread comments on the whole file and takes no line, side, or start fields.
 type ThreadInput struct {
 	ReviewID  string
 	Path      string
@@ -48,6 +55,7 @@ type ThreadInput struct {
 	StartLine *int
 	StartSide *string
 	Body      string
+	FileLevel bool
 }
 
 // SubmitInput contains the payload for submitting a pending review.
@@ -80,8 +88,14 @@ func NewService(api ghcli.API) *Service {
 }
 
 // Start opens a pending review for the specified pull request.
-func (s *Service) Start(pr resolver.Identity, commitOID string) (*ReviewState, error) {
-	nodeID, headSHA, err := s.pullRequestIdentifiers(pr)
+func (s *Service) Start(ctx context.Context, pr resolver.Identity, commitOID string) (*ReviewState, error) {
+	return s.start(ctx, pr, commitOID, "")
+}
+
+// start opens a pending review, setting its body when one is given so the
+// body survives even if the review is never submitted.
+func (s *Service) start(ctx context.Context, pr resolver.Identity, commitOID, body string) (*ReviewState, error) {
+	nodeID, headSHA, err := s.pullRequestIdentifiers(ctx, pr)
 	if err != nil {
 		return nil, err
 	}
@@ -97,12 +111,14 @@ func (s *Service) Start(pr resolver.Identity, commitOID string) (*ReviewState, e
   }
 }`
 
-	payload := map[string]interface{}{
-		"input": map[string]interface{}{
-			"pullRequestId": nodeID,
-			"commitOID":     trimmedCommit,
-		},
+	input := map[string]interface{}{
+		"pullRequestId": nodeID,
+		"commitOID":     trimmedCommit,
+	}
+	if trimmed := strings.TrimSpace(body); trimmed != "" {
+		input["body"] = trimmed
 	}
+	payload := map[string]interface{}{"input": input}
 
 	var resp struct {
 		AddPullRequestReview struct {
@@ -114,7 +130,7 @@ func (s *Service) Start(pr resolver.Identity, commitOID string) (*ReviewState, e
 		} `json:"addPullRequestReview"`
 	}
 
-	if err := s.API.GraphQL(mutation, payload, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, mutation, payload, &resp); err != nil {
 		return nil, err
 	}
 
@@ -140,7 +156,7 @@ func (s *Service) Start(pr resolver.Identity, commitOID string) (*ReviewState, e
 }
 
 // AddThread adds an inline review comment thread to an existing pending review.
-func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThread, error) {
+func (s *Service) AddThread(ctx context.Context, pr resolver.Identity, input ThreadInput) (*ReviewThread, error) {
 	trimmedID := strings.TrimSpace(input.ReviewID)
 	if trimmedID == "" {
 		return nil, errors.New("review id is required")
@@ -153,7 +169,11 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 	if trimmedPath == "" {
 		return nil, errors.New("path is required")
 	}
-	if input.Line <= 0 {
+	if input.FileLevel {
+		if input.Line != 0 || input.StartLine != nil || input.StartSide != nil {
+			return nil, errors.New("file-level comments do not take line, start line, or start side")
+		}
+	} else if input.Line <= 0 {
 		return nil, errors.New("line must be positive")
 	}
 
@@ -164,17 +184,21 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 
 	const mutation = `mutation($input:AddPullRequestReviewThreadInput!){
   addPullRequestReviewThread(input:$input){
-    thread { id path isOutdated line }
+    thread { id path subjectType isOutdated line }
   }
 }`
 
 	graphqlInput := map[string]interface{}{
 		"pullRequestReviewId": trimmedID,
 		"path":                trimmedPath,
-		"line":                input.Line,
-		"side":                input.Side,
 		"body":                trimmedBody,
 	}
+	if input.FileLevel {
+		graphqlInput["subjectType"] = "FILE"
+	} else {
+		graphqlInput["line"] = input.Line
+		graphqlInput["side"] = input.Side
+	}
 	if input.StartLine != nil {
 		graphqlInput["startLine"] = *input.StartLine
 	}
@@ -189,15 +213,17 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 	var resp struct {
 		AddPullRequestReviewThread struct {
 			Thread struct {
-				ID         string `json:"id"`
-				Path       string `json:"path"`
-				IsOutdated bool   `json:"isOutdated"`
-				Line       *int   `json:"line"`
+				ID          string `json:"id"`
+				Path        string `json:"path"`
+				SubjectType string `json:"subjectType"`
+				IsOutdated  bool   `json:"isOutdated"`
+				Line        *int   `json:"line"`
 			} `json:"thread"`
 		} `json:"addPullRequestReviewThread"`
 	}
 
-	if err := s.API.GraphQL(mutation, payload, &resp); err != nil {
+	ctx = ghcli.WithDedupe(ctx, s.threadLanded(trimmedID, trimmedPath, input.Line, trimmedBody))
+	if err := s.API.GraphQL(ctx, mutation, payload, &resp); err != nil {
 		return nil, err
 	}
 
@@ -211,7 +237,7 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 			reqJSON, respJSON)
 	}
 
-	result := ReviewThread{ID: trimmedThreadID, Path: trimmedThreadPath, IsOutdated: thread.IsOutdated}
+	result := ReviewThread{ID: trimmedThreadID, Path: trimmedThreadPath, SubjectType: thread.SubjectType, IsOutdated: thread.IsOutdated}
 	if thread.Line != nil {
 		result.Line = thread.Line
 	}
@@ -219,7 +245,7 @@ func (s *Service) AddThread(pr resolver.Identity, input ThreadInput) (*ReviewThr
 }
 
 // Submit finalizes a pending review with the given event and optional body.
-func (s *Service) Submit(_ resolver.Identity, input SubmitInput) (*SubmitStatus, error) {
+func (s *Service) Submit(ctx context.Context, _ resolver.Identity, input SubmitInput) (*SubmitStatus, error) {
 	reviewID := strings.TrimSpace(input.ReviewID)
 	if reviewID == "" {
 		return nil, errors.New("review id is required")
@@ -242,7 +268,8 @@ func (s *Service) Submit(_ resolver.Identity, input SubmitInput) (*SubmitStatus,
 	variables := map[string]interface{}{"input": graphqlInput}
 
 	var response struct{}
-	if err := s.API.GraphQL(query, variables, &response); err != nil {
+	ctx = ghcli.WithDedupe(ctx, s.reviewSubmitted(reviewID))
+	if err := s.API.GraphQL(ctx, query, variables, &response); err != nil {
 		var gqlErr *ghcli.GraphQLError
 		if errors.As(err, &gqlErr) {
 			return &SubmitStatus{Success: false, Errors: gqlErr.Errors}, nil
@@ -254,7 +281,7 @@ func (s *Service) Submit(_ resolver.Identity, input SubmitInput) (*SubmitStatus,
 }
 
 // DeleteComment deletes a comment from a pending review.
-func (s *Service) DeleteComment(_ resolver.Identity, input DeleteCommentInput) error {
+func (s *Service) DeleteComment(ctx context.Context, _ resolver.Identity, input DeleteCommentInput) error {
 	commentID := strings.TrimSpace(input.CommentID)
 	if commentID == "" {
 		return errors.New("comment id is required")
@@ -276,7 +303,7 @@ func (s *Service) DeleteComment(_ resolver.Identity, input DeleteCommentInput) e
 	}
 
 	var resp struct{}
-	if err := s.API.GraphQL(mutation, variables, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, mutation, variables, &resp); err != nil {
 		return err
 	}
 
@@ -284,7 +311,7 @@ func (s *Service) DeleteComment(_ resolver.Identity, input DeleteCommentInput) e
 }
 
 // UpdateComment updates the body of a review comment.
-func (s *Service) UpdateComment(_ resolver.Identity, input UpdateCommentInput) error {
+func (s *Service) UpdateComment(ctx context.Context, _ resolver.Identity, input UpdateCommentInput) error {
 	commentID := strings.TrimSpace(input.CommentID)
 	if commentID == "" {
 		return errors.New("comment id is required")
@@ -312,7 +339,7 @@ func (s *Service) UpdateComment(_ resolver.Identity, input UpdateCommentInput) e
 	}
 
 	var resp struct{}
-	if err := s.API.GraphQL(mutation, variables, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, mutation, variables, &resp); err != nil {
 		return err
 	}
 
@@ -320,7 +347,7 @@ func (s *Service) UpdateComment(_ resolver.Identity, input UpdateCommentInput) e
 }
 
 // UpdateReview updates the body of a submitted pull request review.
-func (s *Service) UpdateReview(_ resolver.Identity, input UpdateReviewInput) error {
+func (s *Service) UpdateReview(ctx context.Context, _ resolver.Identity, input UpdateReviewInput) error {
 	reviewID := strings.TrimSpace(input.ReviewID)
 	if reviewID == "" {
 		return errors.New("review id is required")
@@ -348,29 +375,27 @@ func (s *Service) UpdateReview(_ resolver.Identity, input UpdateReviewInput) err
 	}
 
 	var resp struct{}
-	if err := s.API.GraphQL(mutation, variables, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, mutation, variables, &resp); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *Service) currentViewer() (string, error) {
+func (s *Service) currentViewer(ctx context.Context) (string, error) {
 	const query = `query ViewerLogin { viewer { login } }`
 
 	var response struct {
-		Data struct {
-			Viewer struct {
-				Login string `json:"login"`
-			} `json:"viewer"`
-		} `json:"data"`
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
 	}
 
-	if err := s.API.GraphQL(query, nil, &response); err != nil {
+	if err := s.API.GraphQL(ctx, query, nil, &response); err != nil {
 		return "", err
 	}
 
-	login := strings.TrimSpace(response.Data.Viewer.Login)
+	login := strings.TrimSpace(response.Viewer.Login)
 	if login == "" {
 		return "", ErrViewerLoginUnavailable
 	}
@@ -378,7 +403,7 @@ func (s *Service) currentViewer() (string, error) {
 	return login, nil
 }
 
-func (s *Service) pullRequestIdentifiers(pr resolver.Identity) (string, string, error) {
+func (s *Service) pullRequestIdentifiers(ctx context.Context, pr resolver.Identity) (string, string, error) {
 	const query = `query($owner:String!,$name:String!,$number:Int!){
   repository(owner:$owner,name:$name){
     pullRequest(number:$number){ id headRefOid }
@@ -400,7 +425,7 @@ func (s *Service) pullRequestIdentifiers(pr resolver.Identity) (string, string,
 		} `json:"repository"`
 	}
 
-	if err := s.API.GraphQL(query, variables, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, query, variables, &resp); err != nil {
 		return "", "", err
 	}
 
@@ -1,8 +1,10 @@
 package review
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,14 +19,14 @@ type fakeAPI struct {
 	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
 }
 
-func (f *fakeAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+func (f *fakeAPI) REST(_ context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
 	if f.restFunc == nil {
 		return errors.New("unexpected REST call")
 	}
 	return f.restFunc(method, path, params, body, result)
 }
 
-func (f *fakeAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+func (f *fakeAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	if f.graphqlFunc == nil {
 		return errors.New("unexpected GraphQL call")
 	}
@@ -74,7 +76,7 @@ func TestServiceStart(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	state, err := svc.Start(pr, "")
+	state, err := svc.Start(context.Background(), pr, "")
 	require.NoError(t, err)
 	assert.Equal(t, "PRR_review", state.ID)
 	assert.Equal(t, "PENDING", state.State)
@@ -114,7 +116,7 @@ func TestServiceStartErrorOnEmptyReview(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	_, err := svc.Start(pr, "")
+	_, err := svc.Start(context.Background(), pr, "")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "returned empty id")
 }
@@ -152,7 +154,7 @@ func TestServiceStartErrorOnEmptyState(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	_, err := svc.Start(pr, "")
+	_, err := svc.Start(context.Background(), pr, "")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "returned empty state")
 }
@@ -184,7 +186,7 @@ func TestServiceAddThread(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	thread, err := svc.AddThread(pr, ThreadInput{ReviewID: " PRR_review ", Path: " file.go ", Line: 10, Side: "RIGHT", Body: " note "})
+	thread, err := svc.AddThread(context.Background(), pr, ThreadInput{ReviewID: " PRR_review ", Path: " file.go ", Line: 10, Side: "RIGHT", Body: " note "})
 	require.NoError(t, err)
 	assert.Equal(t, "THR1", thread.ID)
 	assert.Equal(t, "file.go", thread.Path)
@@ -193,6 +195,39 @@ func TestServiceAddThread(t *testing.T) {
 	assert.Equal(t, 10, *thread.Line)
 }
 
+func TestServiceAddThreadFileLevel(t *testing.T) {
+	api := &fakeAPI{}
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		input, ok := variables["input"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "FILE", input["subjectType"])
+		assert.NotContains(t, input, "line")
+		assert.NotContains(t, input, "side")
+
+		payload := map[string]interface{}{
+			"addPullRequestReviewThread": map[string]interface{}{
+				"thread": map[string]interface{}{
+					"id":          "THR1",
+					"path":        "file.go",
+					"subjectType": "FILE",
+					"isOutdated":  false,
+				},
+			},
+		}
+		return assign(result, payload)
+	}
+
+	svc := NewService(api)
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+	thread, err := svc.AddThread(context.Background(), pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Body: "split this file", FileLevel: true})
+	require.NoError(t, err)
+	assert.Equal(t, "FILE", thread.SubjectType)
+	assert.Nil(t, thread.Line)
+
+	_, err = svc.AddThread(context.Background(), pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 3, Body: "x", FileLevel: true})
+	require.EqualError(t, err, "file-level comments do not take line, start line, or start side")
+}
+
 func TestServiceAddThreadErrorsOnIncompleteResponse(t *testing.T) {
 	api := &fakeAPI{}
 	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
@@ -209,17 +244,94 @@ func TestServiceAddThreadErrorsOnIncompleteResponse(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	_, err := svc.AddThread(pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 10, Side: "RIGHT", Body: "note"})
+	_, err := svc.AddThread(context.Background(), pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 10, Side: "RIGHT", Body: "note"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "returned incomplete thread data")
 }
 
+func TestServiceAddThreadDedupesLandedRetry(t *testing.T) {
+	api := &fakeAPI{}
+	calls := 0
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		calls++
+		switch {
+		case strings.Contains(query, "addPullRequestReviewThread"):
+			return &ghcli.APIError{StatusCode: 502, Message: "Bad Gateway"}
+		case strings.Contains(query, "PendingReviewThreads"):
+			assert.Equal(t, "PRR_review", variables["id"])
+			payload := map[string]interface{}{
+				"node": map[string]interface{}{
+					"pullRequest": map[string]interface{}{
+						"reviewThreads": map[string]interface{}{
+							"nodes": []map[string]interface{}{
+								{
+									"id":         "THR_other",
+									"path":       "file.go",
+									"line":       10,
+									"isOutdated": false,
+									"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+										{"body": "note", "pullRequestReview": map[string]interface{}{"id": "PRR_submitted"}},
+									}},
+								},
+								{
+									"id":         "THR_landed",
+									"path":       "file.go",
+									"line":       10,
+									"isOutdated": false,
+									"comments": map[string]interface{}{"nodes": []map[string]interface{}{
+										{"body": "note", "pullRequestReview": map[string]interface{}{"id": "PRR_review"}},
+									}},
+								},
+							},
+						},
+					},
+				},
+			}
+			return assign(result, payload)
+		default:
+			return errors.New("unexpected query")
+		}
+	}
+
+	svc := NewService(ghcli.NewRetryingAPI(api, ghcli.RetryPolicy{MaxRetries: 2}))
+	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+	thread, err := svc.AddThread(context.Background(), pr, ThreadInput{ReviewID: "PRR_review", Path: "file.go", Line: 10, Side: "RIGHT", Body: "note"})
+	require.NoError(t, err)
+	assert.Equal(t, "THR_landed", thread.ID)
+	assert.Equal(t, 2, calls)
+}
+
+func TestServiceSubmitRetriesWhenReviewStillPending(t *testing.T) {
+	api := &fakeAPI{}
+	submits := 0
+	api.graphqlFunc = func(query string, variables map[string]interface{}, result interface{}) error {
+		switch {
+		case strings.Contains(query, "submitPullRequestReview"):
+			submits++
+			if submits == 1 {
+				return &ghcli.APIError{StatusCode: 503, Message: "Service Unavailable"}
+			}
+			return assign(result, map[string]interface{}{})
+		case strings.Contains(query, "ReviewState"):
+			return assign(result, map[string]interface{}{"node": map[string]interface{}{"id": "PRR_review", "state": "PENDING"}})
+		default:
+			return errors.New("unexpected query")
+		}
+	}
+
+	svc := NewService(ghcli.NewRetryingAPI(api, ghcli.RetryPolicy{MaxRetries: 2}))
+	status, err := svc.Submit(context.Background(), resolver.Identity{}, SubmitInput{ReviewID: "PRR_review", Event: "COMMENT"})
+	require.NoError(t, err)
+	assert.True(t, status.Success)
+	assert.Equal(t, 2, submits)
+}
+
 func TestServiceAddThreadRequiresGraphQLReviewID(t *testing.T) {
 	api := &fakeAPI{}
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
 
-	_, err := svc.AddThread(pr, ThreadInput{ReviewID: "511", Path: "file.go", Line: 10, Side: "RIGHT", Body: "note"})
+	_, err := svc.AddThread(context.Background(), pr, ThreadInput{ReviewID: "511", Path: "file.go", Line: 10, Side: "RIGHT", Body: "note"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "GraphQL node id")
 }
@@ -229,7 +341,7 @@ func TestServiceAddThreadRequiresPath(t *testing.T) {
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
 
-	_, err := svc.AddThread(pr, ThreadInput{ReviewID: "PRR_review", Path: "", Line: 10, Side: "RIGHT", Body: "note"})
+	_, err := svc.AddThread(context.Background(), pr, ThreadInput{ReviewID: "PRR_review", Path: "", Line: 10, Side: "RIGHT", Body: "note"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "path is required")
 }
@@ -256,7 +368,7 @@ func TestServiceSubmit(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	status, err := svc.Submit(pr, SubmitInput{ReviewID: " PRR_kwM123456 ", Event: "COMMENT", Body: " Looks good "})
+	status, err := svc.Submit(context.Background(), pr, SubmitInput{ReviewID: " PRR_kwM123456 ", Event: "COMMENT", Body: " Looks good "})
 	require.NoError(t, err)
 	assert.True(t, status.Success)
 	assert.Empty(t, status.Errors)
@@ -276,7 +388,7 @@ func TestServiceSubmitHandlesNullReviewData(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	status, err := svc.Submit(pr, SubmitInput{ReviewID: "PRR_kwM123", Event: "APPROVE"})
+	status, err := svc.Submit(context.Background(), pr, SubmitInput{ReviewID: "PRR_kwM123", Event: "APPROVE"})
 	require.NoError(t, err)
 	assert.True(t, status.Success)
 	assert.Empty(t, status.Errors)
@@ -290,7 +402,7 @@ func TestServiceSubmitGraphQLErrors(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	status, err := svc.Submit(pr, SubmitInput{ReviewID: "PRR_kwM123", Event: "COMMENT"})
+	status, err := svc.Submit(context.Background(), pr, SubmitInput{ReviewID: "PRR_kwM123", Event: "COMMENT"})
 	require.NoError(t, err)
 	assert.False(t, status.Success)
 	require.Len(t, status.Errors, 1)
@@ -305,7 +417,7 @@ func TestServiceSubmitPropagatesAPIErrors(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	_, err := svc.Submit(pr, SubmitInput{ReviewID: "PRR_kwM123", Event: "COMMENT"})
+	_, err := svc.Submit(context.Background(), pr, SubmitInput{ReviewID: "PRR_kwM123", Event: "COMMENT"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "network down")
 }
@@ -319,7 +431,7 @@ func TestServiceSubmitErrorOnMissingReviewID(t *testing.T) {
 
 	svc := NewService(api)
 	pr := resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
-	_, err := svc.Submit(pr, SubmitInput{ReviewID: " ", Event: "APPROVE"})
+	_, err := svc.Submit(context.Background(), pr, SubmitInput{ReviewID: " ", Event: "APPROVE"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "review id is required")
 }
@@ -0,0 +1,43 @@
+package review
+
+import (
+	"errors"
+	"strings"
+)
+
+// TrimSuggestion drops the single trailing newline that files and YAML block
+// scalars end with, so it is not mistaken for an extra blank line.
+func TrimSuggestion(suggestion string) string {
+	suggestion = strings.TrimSuffix(suggestion, "\n")
+	return strings.TrimSuffix(suggestion, "\r")
+}
+
+// SuggestionBody appends a suggested-change block that replaces the commented
+// lines with replacement; an empty replacement deletes them. The fence is made
+// longer than any backtick run in replacement so it cannot be closed early.
+func SuggestionBody(body, replacement string) string {
+	fence := "```"
+	for strings.Contains(replacement, fence) {
+		fence += "`"
+	}
+	block := fence + "suggestion\n"
+	if replacement != "" {
+		block += replacement + "\n"
+	}
+	block += fence
+
+	body = strings.TrimRight(body, "\n")
+	if strings.TrimSpace(body) == "" {
+		return block
+	}
+	return body + "\n\n" + block
+}
+
+// CheckSuggestionSides verifies that a suggestion targets the new version of
+// the file, which is the only side GitHub can apply changes to.
+func CheckSuggestionSides(side, startSide string) error {
+	if !strings.EqualFold(side, "RIGHT") || (startSide != "" && !strings.EqualFold(startSide, "RIGHT")) {
+		return errors.New("suggestions must target RIGHT side lines")
+	}
+	return nil
+}
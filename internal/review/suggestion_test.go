@@ -0,0 +1,53 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestionBody(t *testing.T) {
+	assert.Equal(t, "Use the helper.\n\n```suggestion\nreturn helper(x)\n```", SuggestionBody("Use the helper.\n", "return helper(x)"))
+	assert.Equal(t, "```suggestion\n```", SuggestionBody("", ""))
+	assert.Equal(t, "````suggestion\nfmt := \"```\"\n````", SuggestionBody(" ", "fmt := \"```\""))
+}
+
+func TestTrimSuggestion(t *testing.T) {
+	assert.Equal(t, "a\nb", TrimSuggestion("a\nb\n"))
+	assert.Equal(t, "a\n", TrimSuggestion("a\n\n"))
+	assert.Equal(t, "a", TrimSuggestion("a\r\n"))
+}
+
+func TestCheckSuggestionSides(t *testing.T) {
+	require.NoError(t, CheckSuggestionSides("RIGHT", ""))
+	require.NoError(t, CheckSuggestionSides("RIGHT", "RIGHT"))
+	require.Error(t, CheckSuggestionSides("LEFT", ""))
+	require.Error(t, CheckSuggestionSides("RIGHT", "LEFT"))
+}
+
+func TestManifestSuggestion(t *testing.T) {
+	manifest, err := ParseManifest([]byte(`
+comments:
+  - path: a.go
+    line: 12
+    start_line: 11
+    suggestion: |
+      x := 1
+      y := 2
+  - path: b.go
+    line: 3
+    side: LEFT
+    body: old code
+    suggestion: ""
+`))
+	require.NoError(t, err)
+
+	err = manifest.Normalize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "comments[1]: suggestions must target RIGHT side lines")
+	assert.NotContains(t, err.Error(), "comments[0]")
+
+	input := manifest.Comments[0].threadInput("PRR_1")
+	assert.Equal(t, "```suggestion\nx := 1\ny := 2\n```", input.Body)
+}
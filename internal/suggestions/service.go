@@ -0,0 +1,316 @@
+package suggestions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/diff"
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/report"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+	"github.com/agynio/gh-pr-review/internal/threads"
+)
+
+// Service lists and applies suggestions for a pull request.
+type Service struct {
+	API ghcli.API
+}
+
+// NewService constructs a suggestions service.
+func NewService(api ghcli.API) *Service {
+	return &Service{API: api}
+}
+
+// ListOptions filters the suggestions returned by List.
+type ListOptions struct {
+	OnlyUnresolved bool
+}
+
+// List returns the suggestions left in the pull request's review threads.
+func (s *Service) List(ctx context.Context, pr resolver.Identity, opts ListOptions) ([]Suggestion, error) {
+	all, err := s.fetch(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	suggestions := make([]Suggestion, 0, len(all))
+	for _, suggestion := range all {
+		if opts.OnlyUnresolved && suggestion.IsResolved {
+			continue
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
+func (s *Service) fetch(ctx context.Context, pr resolver.Identity) ([]Suggestion, error) {
+	threadList, _, err := report.NewService(s.API).Threads(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	return Extract(threadList), nil
+}
+
+// Outcomes reported by Apply.
+const (
+	StatusApplied  = "applied"
+	StatusConflict = "conflict"
+	StatusSkipped  = "skipped"
+)
+
+// ApplyOptions selects the suggestions to apply and where.
+type ApplyOptions struct {
+	// IDs selects suggestions by id, or by thread id to take the latest
+	// suggestion in that thread.
+	IDs []string
+	// All selects the latest suggestion of every unresolved thread.
+	All bool
+	// Root is the worktree directory that suggestion paths are relative to.
+	Root string
+	// Resolve resolves each thread whose suggestion was applied.
+	Resolve bool
+}
+
+// ApplyOutcome reports what happened to one selected suggestion.
+type ApplyOutcome struct {
+	ID        string `json:"id"`
+	ThreadID  string `json:"thread_id"`
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Status    string `json:"status"`
+	Resolved  bool   `json:"resolved,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ApplyResult lists the outcome of every selected suggestion.
+type ApplyResult struct {
+	Suggestions []ApplyOutcome `json:"suggestions"`
+}
+
+// Failed reports whether any selected suggestion was not applied.
+func (r *ApplyResult) Failed() bool {
+	for _, outcome := range r.Suggestions {
+		if outcome.Status != StatusApplied || outcome.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply patches the local files with the selected suggestions. A suggestion is
+// only applied when its lines in the worktree still match the pull request
+// head as shown in the diff; otherwise it is reported as a conflict and the
+// file is left untouched by it.
+func (s *Service) Apply(ctx context.Context, pr resolver.Identity, opts ApplyOptions) (*ApplyResult, error) {
+	if !opts.All && len(opts.IDs) == 0 {
+		return nil, errors.New("no suggestions selected")
+	}
+	all, err := s.fetch(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectSuggestions(all, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := diff.NewService(s.API).Index(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApplyResult{Suggestions: make([]ApplyOutcome, len(selected))}
+	files := make(map[string]*worktreeFile)
+	for i, suggestion := range selected {
+		outcome := &result.Suggestions[i]
+		*outcome = ApplyOutcome{
+			ID:        suggestion.ID,
+			ThreadID:  suggestion.ThreadID,
+			Path:      suggestion.Path,
+			StartLine: suggestion.StartLine,
+			Line:      suggestion.Line,
+		}
+		if suggestion.IsOutdated {
+			outcome.Status, outcome.Error = StatusSkipped, "thread is outdated"
+			continue
+		}
+		head, ok := index.Content(suggestion.Path, diff.SideRight, suggestion.StartLine, suggestion.Line)
+		if !ok {
+			outcome.Status, outcome.Error = StatusSkipped, "lines are not part of the pull request diff"
+			continue
+		}
+
+		file, ok := files[suggestion.Path]
+		if !ok {
+			file, err = readWorktreeFile(filepath.Join(opts.Root, filepath.FromSlash(suggestion.Path)))
+			if err != nil {
+				outcome.Status, outcome.Error = StatusConflict, err.Error()
+				continue
+			}
+			files[suggestion.Path] = file
+		}
+		if err := file.stage(suggestion, head); err != nil {
+			outcome.Status, outcome.Error = StatusConflict, err.Error()
+			continue
+		}
+		outcome.Status = StatusApplied
+	}
+
+	written := make(map[string]error, len(files))
+	for path, file := range files {
+		written[path] = file.write()
+	}
+
+	threadService := threads.NewService(s.API)
+	for i := range result.Suggestions {
+		outcome := &result.Suggestions[i]
+		if outcome.Status != StatusApplied {
+			continue
+		}
+		if err := written[outcome.Path]; err != nil {
+			outcome.Status, outcome.Error = StatusConflict, err.Error()
+			continue
+		}
+		if !opts.Resolve {
+			continue
+		}
+		if _, err := threadService.Resolve(ctx, pr, threads.ActionOptions{ThreadID: outcome.ThreadID}); err != nil {
+			outcome.Error = fmt.Sprintf("applied, but resolving the thread failed: %s", err)
+			continue
+		}
+		outcome.Resolved = true
+	}
+	return result, nil
+}
+
+// selectSuggestions picks suggestions by id, by thread (latest suggestion), or
+// every unresolved thread's latest suggestion.
+func selectSuggestions(all []Suggestion, opts ApplyOptions) ([]Suggestion, error) {
+	latestByThread := make(map[string]Suggestion)
+	var threadOrder []string
+	byID := make(map[string]Suggestion, len(all))
+	for _, suggestion := range all {
+		byID[suggestion.ID] = suggestion
+		if _, seen := latestByThread[suggestion.ThreadID]; !seen {
+			threadOrder = append(threadOrder, suggestion.ThreadID)
+		}
+		latestByThread[suggestion.ThreadID] = suggestion
+	}
+
+	if opts.All {
+		var selected []Suggestion
+		for _, threadID := range threadOrder {
+			if suggestion := latestByThread[threadID]; !suggestion.IsResolved {
+				selected = append(selected, suggestion)
+			}
+		}
+		return selected, nil
+	}
+
+	selected := make([]Suggestion, 0, len(opts.IDs))
+	for _, id := range opts.IDs {
+		id = strings.TrimSpace(id)
+		if suggestion, ok := byID[id]; ok {
+			selected = append(selected, suggestion)
+			continue
+		}
+		if suggestion, ok := latestByThread[id]; ok {
+			selected = append(selected, suggestion)
+			continue
+		}
+		return nil, fmt.Errorf("no suggestion found for %s", id)
+	}
+	return selected, nil
+}
+
+// worktreeFile holds a local file's lines and the edits staged against them.
+type worktreeFile struct {
+	path     string
+	mode     os.FileMode
+	lines    []string
+	crlf     bool
+	trailing bool
+	edits    []edit
+}
+
+type edit struct {
+	start, end  int
+	replacement []string
+}
+
+func readWorktreeFile(path string) (*worktreeFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	content := string(data)
+	file := &worktreeFile{
+		path:     path,
+		mode:     info.Mode().Perm(),
+		crlf:     strings.Contains(content, "\r\n"),
+		trailing: strings.HasSuffix(content, "\n"),
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.TrimSuffix(content, "\n")
+	if content != "" || !file.trailing {
+		file.lines = strings.Split(content, "\n")
+	}
+	return file, nil
+}
+
+// stage records the edit for suggestion after checking that the local lines
+// still match head and do not overlap an edit staged earlier.
+func (f *worktreeFile) stage(suggestion Suggestion, head []string) error {
+	start, end := suggestion.StartLine, suggestion.Line
+	if end > len(f.lines) {
+		return fmt.Errorf("%s has %d lines; suggestion targets lines %d-%d", suggestion.Path, len(f.lines), start, end)
+	}
+	local := f.lines[start-1 : end]
+	if strings.Join(local, "\n") != strings.Join(head, "\n") {
+		return fmt.Errorf("local lines %d-%d of %s differ from the pull request head", start, end, suggestion.Path)
+	}
+	for _, staged := range f.edits {
+		if start <= staged.end && staged.start <= end {
+			return fmt.Errorf("lines %d-%d of %s overlap another applied suggestion", start, end, suggestion.Path)
+		}
+	}
+
+	f.edits = append(f.edits, edit{start: start, end: end, replacement: suggestion.Replacement})
+	return nil
+}
+
+// write applies the staged edits from the bottom of the file up, so earlier
+// line numbers stay valid, and preserves line endings and the final newline.
+func (f *worktreeFile) write() error {
+	if len(f.edits) == 0 {
+		return nil
+	}
+	sort.Slice(f.edits, func(i, j int) bool { return f.edits[i].start > f.edits[j].start })
+	lines := append([]string(nil), f.lines...)
+	for _, e := range f.edits {
+		tail := append([]string(nil), lines[e.end:]...)
+		lines = append(append(lines[:e.start-1], e.replacement...), tail...)
+	}
+
+	newline := "\n"
+	if f.crlf {
+		newline = "\r\n"
+	}
+	content := strings.Join(lines, newline)
+	if f.trailing && len(lines) > 0 {
+		content += newline
+	}
+	if err := os.WriteFile(f.path, []byte(content), f.mode); err != nil {
+		return fmt.Errorf("write %s: %w", f.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package suggestions
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// fakeAPI answers the review threads report and thread resolution. Apply
+// also lists the pull request files to read head contents, so every REST call
+// gets a.go's patch.
+type fakeAPI struct {
+	t        *testing.T
+	threads  []map[string]interface{}
+	resolved []string
+}
+
+func assign(result interface{}, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (f *fakeAPI) REST(_ context.Context, _, _ string, _ map[string]string, _ interface{}, result interface{}) error {
+	return assign(result, []map[string]interface{}{
+		{"filename": "a.go", "status": "modified", "patch": "@@ -1,4 +1,6 @@\n one\n+two\n+three\n four\n five\n+six"},
+	})
+}
+
+func (f *fakeAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	switch {
+	case strings.Contains(query, "query Report("):
+		return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+			"reviews":       map[string]interface{}{"nodes": []interface{}{}},
+			"reviewThreads": map[string]interface{}{"nodes": f.threads},
+		}}})
+	case strings.Contains(query, "viewerCanResolve"):
+		return assign(result, map[string]interface{}{"node": map[string]interface{}{"id": variables["id"], "viewerCanResolve": true}})
+	case strings.Contains(query, "resolveReviewThread("):
+		id := variables["threadId"].(string)
+		f.resolved = append(f.resolved, id)
+		return assign(result, map[string]interface{}{"resolveReviewThread": map[string]interface{}{"thread": map[string]interface{}{"id": id, "isResolved": true}}})
+	default:
+		f.t.Fatalf("unexpected query: %s", query)
+		return nil
+	}
+}
+
+func thread(id string, start, line int, bodies ...string) map[string]interface{} {
+	comments := make([]map[string]interface{}, len(bodies))
+	for i, body := range bodies {
+		comments[i] = map[string]interface{}{
+			"id": id + "_c" + string(rune('1'+i)), "body": body, "createdAt": "2024-06-01T00:00:00Z",
+			"author": map[string]interface{}{"login": "alice"},
+		}
+	}
+	node := map[string]interface{}{"id": id, "path": "a.go", "line": line, "diffSide": "RIGHT", "comments": map[string]interface{}{"nodes": comments}}
+	if start > 0 {
+		node["startLine"] = start
+	}
+	return node
+}
+
+func writeFile(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "a.go")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestListFiltersResolved(t *testing.T) {
+	resolved := thread("PRRT_b", 0, 6, "```suggestion\nSIX\n```")
+	resolved["isResolved"] = true
+	api := &fakeAPI{t: t, threads: []map[string]interface{}{thread("PRRT_a", 0, 2, "```suggestion\nTWO\n```"), resolved}}
+
+	all, err := NewService(api).List(context.Background(), resolver.Identity{}, ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	unresolved, err := NewService(api).List(context.Background(), resolver.Identity{}, ListOptions{OnlyUnresolved: true})
+	require.NoError(t, err)
+	require.Len(t, unresolved, 1)
+	assert.Equal(t, "PRRT_a", unresolved[0].ThreadID)
+}
+
+func TestApplyPatchesWorktreeAndResolves(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "one\r\ntwo\r\nthree\r\nfour\r\nfive\r\nsix\r\n")
+	api := &fakeAPI{t: t, threads: []map[string]interface{}{
+		thread("PRRT_a", 2, 3, "```suggestion\nstale\n```", "```suggestion\n2\n3\nthree and a half\n```"),
+		thread("PRRT_b", 0, 6, "```suggestion\n```"),
+	}}
+
+	result, err := NewService(api).Apply(context.Background(), resolver.Identity{}, ApplyOptions{All: true, Root: dir, Resolve: true})
+	require.NoError(t, err)
+	assert.False(t, result.Failed())
+	assert.Equal(t, []ApplyOutcome{
+		{ID: "PRRT_a_c2", ThreadID: "PRRT_a", Path: "a.go", StartLine: 2, Line: 3, Status: StatusApplied, Resolved: true},
+		{ID: "PRRT_b_c1", ThreadID: "PRRT_b", Path: "a.go", StartLine: 6, Line: 6, Status: StatusApplied, Resolved: true},
+	}, result.Suggestions)
+	assert.Equal(t, []string{"PRRT_a", "PRRT_b"}, api.resolved)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "one\r\n2\r\n3\r\nthree and a half\r\nfour\r\nfive\r\n", string(data))
+}
+
+func TestApplyReportsConflicts(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "one\ntwo (edited locally)\nthree\nfour\nfive\nsix")
+	api := &fakeAPI{t: t, threads: []map[string]interface{}{
+		thread("PRRT_a", 0, 2, "```suggestion\nTWO\n```"),
+		thread("PRRT_b", 5, 6, "```suggestion\nFIVE\nSIX\n```"),
+		thread("PRRT_c", 0, 6, "```suggestion\nsix!\n```"),
+		thread("PRRT_d", 0, 9, "```suggestion\nnine\n```"),
+	}}
+
+	result, err := NewService(api).Apply(context.Background(), resolver.Identity{}, ApplyOptions{IDs: []string{"PRRT_a", "PRRT_b_c1", "PRRT_c", "PRRT_d"}, Root: dir})
+	require.NoError(t, err)
+	assert.True(t, result.Failed())
+
+	statuses := make([]string, len(result.Suggestions))
+	for i, outcome := range result.Suggestions {
+		statuses[i] = outcome.Status
+	}
+	assert.Equal(t, []string{StatusConflict, StatusApplied, StatusConflict, StatusSkipped}, statuses)
+	assert.Contains(t, result.Suggestions[0].Error, "differ from the pull request head")
+	assert.Contains(t, result.Suggestions[2].Error, "overlap another applied suggestion")
+	assert.Empty(t, api.resolved)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo (edited locally)\nthree\nfour\nFIVE\nSIX", string(data))
+}
+
+func TestApplyRejectsUnknownID(t *testing.T) {
+	api := &fakeAPI{t: t, threads: []map[string]interface{}{thread("PRRT_a", 0, 2, "```suggestion\nTWO\n```")}}
+	_, err := NewService(api).Apply(context.Background(), resolver.Identity{}, ApplyOptions{IDs: []string{"PRRC_missing"}})
+	require.EqualError(t, err, "no suggestion found for PRRC_missing")
+}
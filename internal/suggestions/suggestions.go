@@ -0,0 +1,119 @@
+// Package suggestions extracts suggested changes from review threads and
+// applies them to a local checkout.
+package suggestions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+// Suggestion is one ```suggestion block left in a review thread. It replaces
+// lines StartLine..Line of Path on the pull request head with Replacement,
+// one entry per line; an empty Replacement deletes them.
+type Suggestion struct {
+	ID          string   `json:"id"`
+	ThreadID    string   `json:"thread_id"`
+	CommentID   string   `json:"comment_id"`
+	Author      string   `json:"author"`
+	Path        string   `json:"path"`
+	StartLine   int      `json:"start_line,omitempty"`
+	Line        int      `json:"line,omitempty"`
+	Replacement []string `json:"replacement"`
+	IsResolved  bool     `json:"is_resolved"`
+	IsOutdated  bool     `json:"is_outdated"`
+}
+
+// Extract collects the suggestions from every comment of the threads, in
+// thread and comment order. Suggestions on LEFT-side and file-level threads
+// are ignored since GitHub cannot apply them. Outdated threads have no current
+// line range.
+func Extract(threads []report.Thread) []Suggestion {
+	var suggestions []Suggestion
+	for _, thread := range threads {
+		if strings.EqualFold(thread.DiffSide, "LEFT") || thread.SubjectType == "FILE" {
+			continue
+		}
+		var startLine, line int
+		if thread.Line != nil && !thread.IsOutdated {
+			line = *thread.Line
+			startLine = line
+			if thread.StartLine != nil && *thread.StartLine > 0 && *thread.StartLine < line {
+				startLine = *thread.StartLine
+			}
+		}
+
+		for _, comment := range thread.Comments {
+			for i, replacement := range ParseBlocks(comment.Body) {
+				id := comment.NodeID
+				if i > 0 {
+					id = fmt.Sprintf("%s#%d", comment.NodeID, i+1)
+				}
+				if replacement == nil {
+					replacement = []string{}
+				}
+				suggestions = append(suggestions, Suggestion{
+					ID:          id,
+					ThreadID:    thread.ID,
+					CommentID:   comment.NodeID,
+					Author:      comment.AuthorLogin,
+					Path:        thread.Path,
+					StartLine:   startLine,
+					Line:        line,
+					Replacement: replacement,
+					IsResolved:  thread.IsResolved,
+					IsOutdated:  thread.IsOutdated || line == 0,
+				})
+			}
+		}
+	}
+	return suggestions
+}
+
+// ParseBlocks returns the lines inside each ```suggestion fence in body. An
+// empty fence yields nil, which deletes the lines, while a fence holding one
+// blank line yields [""]. A fence is closed by a line of at least as many
+// backticks; unclosed fences are ignored, matching how GitHub renders them.
+func ParseBlocks(body string) [][]string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	var blocks [][]string
+	for i := 0; i < len(lines); i++ {
+		fence, ok := openingFence(lines[i])
+		if !ok {
+			continue
+		}
+		var content []string
+		closed := false
+		for j := i + 1; j < len(lines); j++ {
+			if isClosingFence(lines[j], fence) {
+				closed = true
+				i = j
+				break
+			}
+			content = append(content, lines[j])
+		}
+		if !closed {
+			break
+		}
+		blocks = append(blocks, content)
+	}
+	return blocks
+}
+
+func openingFence(line string) (int, bool) {
+	trimmed := strings.TrimSpace(line)
+	fence := len(trimmed) - len(strings.TrimLeft(trimmed, "`"))
+	if fence < 3 {
+		return 0, false
+	}
+	if strings.TrimSpace(trimmed[fence:]) != "suggestion" {
+		return 0, false
+	}
+	return fence, true
+}
+
+func isClosingFence(line string, fence int) bool {
+	trimmed := strings.TrimSpace(line)
+	return len(trimmed) >= fence && strings.Trim(trimmed, "`") == ""
+}
@@ -0,0 +1,47 @@
+package suggestions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func TestParseBlocks(t *testing.T) {
+	body := "Consider:\r\n```suggestion\r\nreturn nil\r\n```\r\nor\n````suggestion\n```go\nx\n```\n````\n```suggestion\n```\n```suggestion\nunclosed"
+	assert.Equal(t, [][]string{{"return nil"}, {"```go", "x", "```"}, nil}, ParseBlocks(body))
+	assert.Empty(t, ParseBlocks("```go\nfmt.Println()\n```"))
+}
+
+func TestParseBlocksKeepsDeletionApartFromBlankLine(t *testing.T) {
+	assert.Equal(t, [][]string{nil}, ParseBlocks("```suggestion\n```"))
+	assert.Equal(t, [][]string{{""}}, ParseBlocks("```suggestion\n\n```"))
+}
+
+func TestExtract(t *testing.T) {
+	line, start := 12, 10
+	threads := []report.Thread{
+		{
+			ID: "PRRT_a", Path: "a.go", Line: &line, StartLine: &start, DiffSide: "RIGHT",
+			Comments: []report.ThreadComment{
+				{NodeID: "PRRC_1", AuthorLogin: "alice", Body: "```suggestion\none\n```\n\n```suggestion\ntwo\n```"},
+				{NodeID: "PRRC_2", AuthorLogin: "bob", Body: "thanks"},
+			},
+		},
+		{
+			ID: "PRRT_left", Path: "a.go", Line: &line, DiffSide: "LEFT",
+			Comments: []report.ThreadComment{{NodeID: "PRRC_3", Body: "```suggestion\nx\n```"}},
+		},
+		{
+			ID: "PRRT_old", Path: "b.go", IsOutdated: true, IsResolved: true, DiffSide: "RIGHT",
+			Comments: []report.ThreadComment{{NodeID: "PRRC_4", AuthorLogin: "carol", Body: "```suggestion\ny\n```"}},
+		},
+	}
+
+	assert.Equal(t, []Suggestion{
+		{ID: "PRRC_1", ThreadID: "PRRT_a", CommentID: "PRRC_1", Author: "alice", Path: "a.go", StartLine: 10, Line: 12, Replacement: []string{"one"}},
+		{ID: "PRRC_1#2", ThreadID: "PRRT_a", CommentID: "PRRC_1", Author: "alice", Path: "a.go", StartLine: 10, Line: 12, Replacement: []string{"two"}},
+		{ID: "PRRC_4", ThreadID: "PRRT_old", CommentID: "PRRC_4", Author: "carol", Path: "b.go", Replacement: []string{"y"}, IsResolved: true, IsOutdated: true},
+	}, Extract(threads))
+}
@@ -0,0 +1,184 @@
+package threads
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agynio/gh-pr-review/internal/diff"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// Bulk outcome statuses reported in BulkOutcome.Status.
+const (
+	StatusChanged = "changed"
+	StatusPlanned = "planned"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+// DefaultConcurrency bounds the mutations a bulk operation runs at once.
+const DefaultConcurrency = 4
+
+// BulkOptions selects the threads a bulk resolve or unresolve acts on. Only
+// threads not already in the requested state are considered.
+type BulkOptions struct {
+	MineOnly     bool
+	OutdatedOnly bool
+	// Paths keeps threads whose file matches any of the glob patterns.
+	Paths []string
+	// Author keeps threads started by this login.
+	Author string
+	// UpdatedBefore keeps threads with no comment activity since this time.
+	UpdatedBefore time.Time
+	// DryRun reports the selected threads without changing them.
+	DryRun      bool
+	Concurrency int
+}
+
+// BulkOutcome reports what happened to one selected thread.
+type BulkOutcome struct {
+	ThreadNodeID string `json:"thread_node_id"`
+	Path         string `json:"path"`
+	Line         *int   `json:"line,omitempty"`
+	IsResolved   bool   `json:"is_resolved"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+// BulkResult lists the outcome of every selected thread.
+type BulkResult struct {
+	DryRun  bool          `json:"dry_run"`
+	Threads []BulkOutcome `json:"threads"`
+}
+
+// Failed reports whether any selected thread could not be changed.
+func (r *BulkResult) Failed() bool {
+	for _, outcome := range r.Threads {
+		if outcome.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveAll resolves every unresolved thread matching opts.
+func (s *Service) ResolveAll(ctx context.Context, pr resolver.Identity, opts BulkOptions) (*BulkResult, error) {
+	return s.changeResolutionAll(ctx, pr, opts, true)
+}
+
+// UnresolveAll reopens every resolved thread matching opts.
+func (s *Service) UnresolveAll(ctx context.Context, pr resolver.Identity, opts BulkOptions) (*BulkResult, error) {
+	return s.changeResolutionAll(ctx, pr, opts, false)
+}
+
+func (s *Service) changeResolutionAll(ctx context.Context, pr resolver.Identity, opts BulkOptions, resolve bool) (*BulkResult, error) {
+	pull, err := s.loadPullContext(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := s.collectThreads(ctx, pull)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		selected []Thread
+		allowed  = make(map[string]bool)
+	)
+	for _, node := range nodes {
+		if node.IsResolved == resolve || !opts.matches(node) {
+			continue
+		}
+		thread, mine := node.summary()
+		if opts.MineOnly && !mine {
+			continue
+		}
+		selected = append(selected, thread)
+		allowed[node.ID] = (resolve && node.ViewerCanResolve) || (!resolve && node.ViewerCanUnresolve)
+	}
+	sortThreads(selected)
+
+	result := &BulkResult{DryRun: opts.DryRun, Threads: make([]BulkOutcome, len(selected))}
+	for i, thread := range selected {
+		result.Threads[i] = BulkOutcome{
+			ThreadNodeID: thread.ThreadID,
+			Path:         thread.Path,
+			Line:         thread.Line,
+			IsResolved:   thread.IsResolved,
+			Status:       StatusPlanned,
+		}
+		if !allowed[thread.ThreadID] {
+			result.Threads[i].Status = StatusSkipped
+			result.Threads[i].Error = "viewer cannot resolve this thread"
+			if !resolve {
+				result.Threads[i].Error = "viewer cannot unresolve this thread"
+			}
+		}
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range result.Threads {
+		outcome := &result.Threads[i]
+		if outcome.Status == StatusSkipped {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var (
+				action ActionResult
+				err    error
+			)
+			if resolve {
+				action, err = s.performResolve(ctx, outcome.ThreadNodeID)
+			} else {
+				action, err = s.performUnresolve(ctx, outcome.ThreadNodeID)
+			}
+			if err != nil {
+				outcome.Status = StatusFailed
+				outcome.Error = err.Error()
+				return
+			}
+			outcome.Status = StatusChanged
+			outcome.IsResolved = action.IsResolved
+		}()
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// matches applies the filters that depend only on the thread itself.
+func (o BulkOptions) matches(node threadNode) bool {
+	if o.OutdatedOnly && !node.IsOutdated {
+		return false
+	}
+	if len(o.Paths) > 0 && !diff.MatchPath(node.Path, o.Paths) {
+		return false
+	}
+	if author := strings.TrimSpace(o.Author); author != "" {
+		comments := node.Comments.Nodes
+		if len(comments) == 0 || comments[0].Author == nil || !strings.EqualFold(comments[0].Author.Login, author) {
+			return false
+		}
+	}
+	if !o.UpdatedBefore.IsZero() {
+		if latest, ok := node.lastActivity(); ok && !latest.Before(o.UpdatedBefore) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+func bulkThread(id, path, author string, resolved, outdated, canResolve bool, updatedAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                 id,
+		"isResolved":         resolved,
+		"isOutdated":         outdated,
+		"path":               path,
+		"line":               10,
+		"viewerCanResolve":   canResolve,
+		"viewerCanUnresolve": canResolve,
+		"comments": map[string]interface{}{
+			"nodes": []map[string]interface{}{
+				{"updatedAt": updatedAt, "databaseId": 1, "author": map[string]interface{}{"login": author}},
+			},
+		},
+	}
+}
+
+func bulkAPI(t *testing.T, nodes []map[string]interface{}, mutate func(threadID string) error) *fakeAPI {
+	var mu sync.Mutex
+	return &fakeAPI{
+		restFunc: restStub(t, "octo", "demo", "octo/demo", 5, "PR_node", nil),
+		graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+			switch query {
+			case listThreadsQuery:
+				return assign(result, map[string]interface{}{"node": map[string]interface{}{"reviewThreads": map[string]interface{}{
+					"nodes":    nodes,
+					"pageInfo": map[string]interface{}{"hasNextPage": false},
+				}}})
+			case resolveThreadMutation, unresolveThreadMutation:
+				threadID := variables["threadId"].(string)
+				mu.Lock()
+				err := mutate(threadID)
+				mu.Unlock()
+				if err != nil {
+					return err
+				}
+				key := "resolveReviewThread"
+				if query == unresolveThreadMutation {
+					key = "unresolveReviewThread"
+				}
+				return assign(result, map[string]interface{}{key: map[string]interface{}{
+					"thread": map[string]interface{}{"id": threadID, "isResolved": query == resolveThreadMutation},
+				}})
+			default:
+				return errors.New("unexpected query")
+			}
+		},
+	}
+}
+
+func TestResolveAllFiltersAndReportsOutcomes(t *testing.T) {
+	old := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	nodes := []map[string]interface{}{
+		bulkThread("T1", "internal/a.go", "alice", false, true, true, old),
+		bulkThread("T2", "internal/b.go", "alice", false, true, true, old.Add(time.Hour)),
+		bulkThread("T3", "internal/c.go", "alice", false, true, false, old),
+		bulkThread("T4", "internal/d.go", "alice", true, true, true, old),
+		bulkThread("T5", "internal/e.go", "alice", false, false, true, old),
+		bulkThread("T6", "docs/f.md", "alice", false, true, true, old),
+		bulkThread("T7", "internal/g.go", "bob", false, true, true, old),
+		bulkThread("T8", "internal/h.go", "alice", false, true, true, recent),
+	}
+	var mutated []string
+	api := bulkAPI(t, nodes, func(threadID string) error {
+		mutated = append(mutated, threadID)
+		if threadID == "T2" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	svc := NewService(api)
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+	opts := BulkOptions{
+		OutdatedOnly:  true,
+		Paths:         []string{"*.go"},
+		Author:        "Alice",
+		UpdatedBefore: recent,
+		Concurrency:   2,
+	}
+	result, err := svc.ResolveAll(context.Background(), identity, opts)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"T1", "T2"}, mutated)
+	assert.True(t, result.Failed())
+
+	require.Len(t, result.Threads, 3)
+	byID := make(map[string]BulkOutcome)
+	for _, outcome := range result.Threads {
+		byID[outcome.ThreadNodeID] = outcome
+	}
+	assert.Equal(t, StatusChanged, byID["T1"].Status)
+	assert.True(t, byID["T1"].IsResolved)
+	assert.Equal(t, StatusFailed, byID["T2"].Status)
+	assert.Equal(t, "boom", byID["T2"].Error)
+	assert.Equal(t, StatusSkipped, byID["T3"].Status)
+	assert.Equal(t, "viewer cannot resolve this thread", byID["T3"].Error)
+	assert.Equal(t, "T2", result.Threads[0].ThreadNodeID, "threads are ordered by latest activity")
+}
+
+func TestResolveAllOlderThanSeesCommentsPastFirstPage(t *testing.T) {
+	old := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	active := bulkThread("T1", "a.go", "alice", false, false, true, old)
+	active["latestComment"] = map[string]interface{}{"nodes": []map[string]interface{}{{"updatedAt": old.AddDate(0, 1, 0)}}}
+	nodes := []map[string]interface{}{active, bulkThread("T2", "b.go", "alice", false, false, true, old)}
+	var mutated []string
+	api := bulkAPI(t, nodes, func(threadID string) error {
+		mutated = append(mutated, threadID)
+		return nil
+	})
+
+	result, err := NewService(api).ResolveAll(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}, BulkOptions{
+		UpdatedBefore: old.AddDate(0, 0, 7),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T2"}, mutated)
+	require.Len(t, result.Threads, 1)
+	assert.Equal(t, "T2", result.Threads[0].ThreadNodeID)
+}
+
+func TestUnresolveAllDryRun(t *testing.T) {
+	ts := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	nodes := []map[string]interface{}{
+		bulkThread("T1", "a.go", "alice", true, false, true, ts),
+		bulkThread("T2", "b.go", "alice", false, false, true, ts),
+	}
+	api := bulkAPI(t, nodes, func(threadID string) error {
+		t.Fatalf("dry run mutated %s", threadID)
+		return nil
+	})
+
+	svc := NewService(api)
+	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
+	result, err := svc.UnresolveAll(context.Background(), identity, BulkOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.False(t, result.Failed())
+	require.Len(t, result.Threads, 1)
+	assert.Equal(t, "T1", result.Threads[0].ThreadNodeID)
+	assert.Equal(t, StatusPlanned, result.Threads[0].Status)
+	assert.True(t, result.Threads[0].IsResolved)
+}
@@ -1,6 +1,7 @@
 package threads
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -29,13 +30,14 @@ type ListOptions struct {
 
 // Thread represents a normalized review thread payload for JSON output.
 type Thread struct {
-	ThreadID   string     `json:"threadId"`
-	IsResolved bool       `json:"isResolved"`
-	ResolvedBy *string    `json:"resolvedBy,omitempty"`
-	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
-	Path       string     `json:"path"`
-	Line       *int       `json:"line,omitempty"`
-	IsOutdated bool       `json:"isOutdated"`
+	ThreadID    string     `json:"threadId"`
+	IsResolved  bool       `json:"isResolved"`
+	ResolvedBy  *string    `json:"resolvedBy,omitempty"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+	Path        string     `json:"path"`
+	Line        *int       `json:"line,omitempty"`
+	SubjectType string     `json:"subjectType,omitempty"`
+	IsOutdated  bool       `json:"isOutdated"`
 }
 
 // ActionOptions controls resolve/unresolve operations.
@@ -55,103 +57,131 @@ type pullContext struct {
 }
 
 // List fetches review threads for the provided pull request, applies filters, and returns sorted results.
-func (s *Service) List(pr resolver.Identity, opts ListOptions) ([]Thread, error) {
-	ctx, err := s.loadPullContext(pr)
+func (s *Service) List(ctx context.Context, pr resolver.Identity, opts ListOptions) ([]Thread, error) {
+	pull, err := s.loadPullContext(ctx, pr)
 	if err != nil {
 		return nil, err
 	}
 
-	nodes, err := s.collectThreads(ctx)
+	nodes, err := s.collectThreads(ctx, pull)
 	if err != nil {
 		return nil, err
 	}
 
 	allThreads := make([]Thread, 0)
-
 	for _, node := range nodes {
 		if opts.OnlyUnresolved && node.IsResolved {
 			continue
 		}
-
-		mine := node.ViewerCanResolve || node.ViewerCanUnresolve
-		var (
-			latest   time.Time
-			hasStamp bool
-		)
-
-		for _, comment := range node.Comments.Nodes {
-			if comment.ViewerDidAuthor {
-				mine = true
-			}
-			if !hasStamp || comment.UpdatedAt.After(latest) {
-				latest = comment.UpdatedAt
-				hasStamp = true
-			}
-		}
-
+		thread, mine := node.summary()
 		if opts.MineOnly && !mine {
 			continue
 		}
+		allThreads = append(allThreads, thread)
+	}
+	sortThreads(allThreads)
 
-		var resolvedBy *string
-		if node.ResolvedBy != nil && node.ResolvedBy.Login != "" {
-			login := node.ResolvedBy.Login
-			resolvedBy = &login
-		}
+	return allThreads, nil
+}
+
+// Resolve marks a thread as resolved when permissions and current state allow it.
+func (s *Service) Resolve(ctx context.Context, pr resolver.Identity, opts ActionOptions) (ActionResult, error) {
+	return s.changeResolution(ctx, pr, opts, true)
+}
+
+// CheckResolvable reports an error when the viewer could not resolve the
+// thread, without changing it. An already resolved thread passes.
+func (s *Service) CheckResolvable(ctx context.Context, pr resolver.Identity, opts ActionOptions) error {
+	_, err := s.checkResolution(ctx, pr, opts, true)
+	return err
+}
+
+// Unresolve reopens a thread when permitted.
+func (s *Service) Unresolve(ctx context.Context, pr resolver.Identity, opts ActionOptions) (ActionResult, error) {
+	return s.changeResolution(ctx, pr, opts, false)
+}
 
-		var updatedAt *time.Time
-		if hasStamp {
-			ts := latest
-			updatedAt = &ts
+// lastActivity returns the latest comment update on the thread, including
+// its last comment when the thread has more than the fetched page.
+func (node threadNode) lastActivity() (time.Time, bool) {
+	var (
+		latest   time.Time
+		hasStamp bool
+	)
+	for _, comment := range node.Comments.Nodes {
+		if !hasStamp || comment.UpdatedAt.After(latest) {
+			latest, hasStamp = comment.UpdatedAt, true
 		}
+	}
+	for _, comment := range node.LatestComment.Nodes {
+		if !hasStamp || comment.UpdatedAt.After(latest) {
+			latest, hasStamp = comment.UpdatedAt, true
+		}
+	}
+	return latest, hasStamp
+}
 
-		var linePtr *int
-		if node.Line != nil {
-			value := *node.Line
-			linePtr = &value
+// summary normalizes node for output and reports whether the thread involves
+// the viewer or can be resolved by them.
+func (node threadNode) summary() (Thread, bool) {
+	mine := node.ViewerCanResolve || node.ViewerCanUnresolve
+	for _, comment := range node.Comments.Nodes {
+		if comment.ViewerDidAuthor {
+			mine = true
 		}
+	}
+	latest, hasStamp := node.lastActivity()
+
+	var resolvedBy *string
+	if node.ResolvedBy != nil && node.ResolvedBy.Login != "" {
+		login := node.ResolvedBy.Login
+		resolvedBy = &login
+	}
 
-		allThreads = append(allThreads, Thread{
-			ThreadID:   node.ID,
-			IsResolved: node.IsResolved,
-			ResolvedBy: resolvedBy,
-			UpdatedAt:  updatedAt,
-			Path:       node.Path,
-			Line:       linePtr,
-			IsOutdated: node.IsOutdated,
-		})
+	var updatedAt *time.Time
+	if hasStamp {
+		ts := latest
+		updatedAt = &ts
 	}
 
-	sort.SliceStable(allThreads, func(i, j int) bool {
-		left := allThreads[i].UpdatedAt
-		right := allThreads[j].UpdatedAt
+	var linePtr *int
+	if node.Line != nil {
+		value := *node.Line
+		linePtr = &value
+	}
+
+	return Thread{
+		ThreadID:    node.ID,
+		IsResolved:  node.IsResolved,
+		ResolvedBy:  resolvedBy,
+		UpdatedAt:   updatedAt,
+		Path:        node.Path,
+		Line:        linePtr,
+		SubjectType: node.SubjectType,
+		IsOutdated:  node.IsOutdated,
+	}, mine
+}
+
+// sortThreads orders threads by most recent activity, newest first.
+func sortThreads(threads []Thread) {
+	sort.SliceStable(threads, func(i, j int) bool {
+		left := threads[i].UpdatedAt
+		right := threads[j].UpdatedAt
 
 		switch {
 		case left == nil && right == nil:
-			return allThreads[i].ThreadID < allThreads[j].ThreadID
+			return threads[i].ThreadID < threads[j].ThreadID
 		case left == nil:
 			return false
 		case right == nil:
 			return true
 		default:
 			if left.Equal(*right) {
-				return allThreads[i].ThreadID < allThreads[j].ThreadID
+				return threads[i].ThreadID < threads[j].ThreadID
 			}
 			return left.After(*right)
 		}
 	})
-
-	return allThreads, nil
-}
-
-// Resolve marks a thread as resolved when permissions and current state allow it.
-func (s *Service) Resolve(pr resolver.Identity, opts ActionOptions) (ActionResult, error) {
-	return s.changeResolution(pr, opts, true)
-}
-
-// Unresolve reopens a thread when permitted.
-func (s *Service) Unresolve(pr resolver.Identity, opts ActionOptions) (ActionResult, error) {
-	return s.changeResolution(pr, opts, false)
 }
 
 type threadsQueryResponse struct {
@@ -172,6 +202,7 @@ type threadNode struct {
 	IsOutdated         bool   `json:"isOutdated"`
 	Path               string `json:"path"`
 	Line               *int   `json:"line"`
+	SubjectType        string `json:"subjectType"`
 	ViewerCanResolve   bool   `json:"viewerCanResolve"`
 	ViewerCanUnresolve bool   `json:"viewerCanUnresolve"`
 	ResolvedBy         *struct {
@@ -182,11 +213,21 @@ type threadNode struct {
 			ViewerDidAuthor bool      `json:"viewerDidAuthor"`
 			UpdatedAt       time.Time `json:"updatedAt"`
 			DatabaseID      int64     `json:"databaseId"`
+			Author          *struct {
+				Login string `json:"login"`
+			} `json:"author"`
 		} `json:"nodes"`
 	} `json:"comments"`
+	// LatestComment holds the thread's last comment, which comments only
+	// reaches when the thread has at most 100 of them.
+	LatestComment struct {
+		Nodes []struct {
+			UpdatedAt time.Time `json:"updatedAt"`
+		} `json:"nodes"`
+	} `json:"latestComment"`
 }
 
-func (s *Service) fetchThreads(nodeID string, after *string) (*threadsQueryResponse, error) {
+func (s *Service) fetchThreads(ctx context.Context, nodeID string, after *string) (*threadsQueryResponse, error) {
 	variables := map[string]interface{}{
 		"id": nodeID,
 	}
@@ -195,25 +236,25 @@ func (s *Service) fetchThreads(nodeID string, after *string) (*threadsQueryRespo
 	}
 
 	var resp threadsQueryResponse
-	if err := s.API.GraphQL(listThreadsQuery, variables, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, listThreadsQuery, variables, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-func (s *Service) collectThreads(ctx pullContext) ([]threadNode, error) {
+func (s *Service) collectThreads(ctx context.Context, pull pullContext) ([]threadNode, error) {
 	allThreads := make([]threadNode, 0)
 	var after *string
 
 	for {
-		resp, err := s.fetchThreads(ctx.nodeID, after)
+		resp, err := s.fetchThreads(ctx, pull.nodeID, after)
 		if err != nil {
 			return nil, err
 		}
 
 		node := resp.Node
 		if node == nil || node.ReviewThreads == nil {
-			return nil, fmt.Errorf("pull request %d not found on %s", ctx.identity.Number, ctx.identity.Host)
+			return nil, fmt.Errorf("pull request %d not found on %s", pull.identity.Number, pull.identity.Host)
 		}
 
 		threads := node.ReviewThreads
@@ -229,12 +270,12 @@ func (s *Service) collectThreads(ctx pullContext) ([]threadNode, error) {
 	return allThreads, nil
 }
 
-func (s *Service) canonicalizeIdentity(pr resolver.Identity) (resolver.Identity, error) {
+func (s *Service) canonicalizeIdentity(ctx context.Context, pr resolver.Identity) (resolver.Identity, error) {
 	var repo struct {
 		FullName string `json:"full_name"`
 	}
 	path := fmt.Sprintf("repos/%s/%s", pr.Owner, pr.Repo)
-	if err := s.API.REST("GET", path, nil, nil, &repo); err != nil {
+	if err := s.API.REST(ctx, "GET", path, nil, nil, &repo); err != nil {
 		return resolver.Identity{}, fmt.Errorf("repository %s/%s not found on %s: %w", pr.Owner, pr.Repo, pr.Host, err)
 	}
 
@@ -249,8 +290,8 @@ func (s *Service) canonicalizeIdentity(pr resolver.Identity) (resolver.Identity,
 	return pr, nil
 }
 
-func (s *Service) loadPullContext(pr resolver.Identity) (pullContext, error) {
-	canonical, err := s.canonicalizeIdentity(pr)
+func (s *Service) loadPullContext(ctx context.Context, pr resolver.Identity) (pullContext, error) {
+	canonical, err := s.canonicalizeIdentity(ctx, pr)
 	if err != nil {
 		return pullContext{}, err
 	}
@@ -259,7 +300,7 @@ func (s *Service) loadPullContext(pr resolver.Identity) (pullContext, error) {
 		NodeID string `json:"node_id"`
 	}
 	path := fmt.Sprintf("repos/%s/%s/pulls/%d", canonical.Owner, canonical.Repo, canonical.Number)
-	if err := s.API.REST("GET", path, nil, nil, &pull); err != nil {
+	if err := s.API.REST(ctx, "GET", path, nil, nil, &pull); err != nil {
 		return pullContext{}, fmt.Errorf("pull request %d not found on %s: %w", canonical.Number, canonical.Host, err)
 	}
 	if strings.TrimSpace(pull.NodeID) == "" {
@@ -269,41 +310,53 @@ func (s *Service) loadPullContext(pr resolver.Identity) (pullContext, error) {
 	return pullContext{identity: canonical, nodeID: pull.NodeID}, nil
 }
 
-func (s *Service) changeResolution(pr resolver.Identity, opts ActionOptions, resolve bool) (ActionResult, error) {
+func (s *Service) changeResolution(ctx context.Context, pr resolver.Identity, opts ActionOptions, resolve bool) (ActionResult, error) {
+	thread, err := s.checkResolution(ctx, pr, opts, resolve)
+	if err != nil {
+		return ActionResult{}, err
+	}
+	if thread.IsResolved == resolve {
+		return ActionResult{ThreadNodeID: thread.ID, IsResolved: thread.IsResolved}, nil
+	}
+
+	threadID := strings.TrimSpace(opts.ThreadID)
+	if resolve {
+		return s.performResolve(ctx, threadID)
+	}
+	return s.performUnresolve(ctx, threadID)
+}
+
+// checkResolution fetches the thread and verifies the viewer may move it to
+// the desired state. A thread already in that state needs no permission.
+func (s *Service) checkResolution(ctx context.Context, pr resolver.Identity, opts ActionOptions, resolve bool) (*threadDetails, error) {
 	threadID := strings.TrimSpace(opts.ThreadID)
 	if threadID == "" {
-		return ActionResult{}, errors.New("thread id is required")
+		return nil, errors.New("thread id is required")
 	}
 
-	thread, err := s.fetchThread(pr.Host, threadID)
+	thread, err := s.fetchThread(ctx, pr.Host, threadID)
 	if err != nil {
-		return ActionResult{}, err
+		return nil, err
 	}
 
-	desired := resolve
-	if thread.IsResolved == desired {
-		return ActionResult{ThreadNodeID: thread.ID, IsResolved: thread.IsResolved}, nil
+	if thread.IsResolved == resolve {
+		return thread, nil
 	}
-
 	if resolve && !thread.ViewerCanResolve {
-		return ActionResult{}, errors.New("viewer cannot resolve this thread")
+		return nil, errors.New("viewer cannot resolve this thread")
 	}
 	if !resolve && !thread.ViewerCanUnresolve {
-		return ActionResult{}, errors.New("viewer cannot unresolve this thread")
+		return nil, errors.New("viewer cannot unresolve this thread")
 	}
-
-	if resolve {
-		return s.performResolve(threadID)
-	}
-	return s.performUnresolve(threadID)
+	return thread, nil
 }
 
-func (s *Service) fetchThread(host, threadID string) (*threadDetails, error) {
+func (s *Service) fetchThread(ctx context.Context, host, threadID string) (*threadDetails, error) {
 	variables := map[string]interface{}{"id": threadID}
 	var resp struct {
 		Node *threadDetails `json:"node"`
 	}
-	if err := s.API.GraphQL(threadDetailsQuery, variables, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, threadDetailsQuery, variables, &resp); err != nil {
 		return nil, err
 	}
 	if resp.Node == nil {
@@ -319,7 +372,7 @@ type threadDetails struct {
 	ViewerCanUnresolve bool   `json:"viewerCanUnresolve"`
 }
 
-func (s *Service) performResolve(threadID string) (ActionResult, error) {
+func (s *Service) performResolve(ctx context.Context, threadID string) (ActionResult, error) {
 	variables := map[string]interface{}{"threadId": threadID}
 	var resp struct {
 		Resolve struct {
@@ -329,13 +382,13 @@ func (s *Service) performResolve(threadID string) (ActionResult, error) {
 			} `json:"thread"`
 		} `json:"resolveReviewThread"`
 	}
-	if err := s.API.GraphQL(resolveThreadMutation, variables, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, resolveThreadMutation, variables, &resp); err != nil {
 		return ActionResult{}, err
 	}
 	return ActionResult{ThreadNodeID: resp.Resolve.Thread.ID, IsResolved: resp.Resolve.Thread.IsResolved}, nil
 }
 
-func (s *Service) performUnresolve(threadID string) (ActionResult, error) {
+func (s *Service) performUnresolve(ctx context.Context, threadID string) (ActionResult, error) {
 	variables := map[string]interface{}{"threadId": threadID}
 	var resp struct {
 		Unresolve struct {
@@ -345,7 +398,7 @@ func (s *Service) performUnresolve(threadID string) (ActionResult, error) {
 			} `json:"thread"`
 		} `json:"unresolveReviewThread"`
 	}
-	if err := s.API.GraphQL(unresolveThreadMutation, variables, &resp); err != nil {
+	if err := s.API.GraphQL(ctx, unresolveThreadMutation, variables, &resp); err != nil {
 		return ActionResult{}, err
 	}
 	return ActionResult{ThreadNodeID: resp.Unresolve.Thread.ID, IsResolved: resp.Unresolve.Thread.IsResolved}, nil
@@ -362,6 +415,7 @@ query Threads($id: ID!, $after: String) {
           isOutdated
           path
           line
+          subjectType
           viewerCanResolve
           viewerCanUnresolve
           resolvedBy { login }
@@ -370,8 +424,12 @@ query Threads($id: ID!, $after: String) {
               databaseId
               viewerDidAuthor
               updatedAt
+              author { login }
             }
           }
+          latestComment: comments(last: 1) {
+            nodes { updatedAt }
+          }
         }
         pageInfo {
           hasNextPage
@@ -1,6 +1,7 @@
 package threads
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
@@ -18,14 +19,14 @@ type fakeAPI struct {
 	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
 }
 
-func (f *fakeAPI) REST(method, path string, params map[string]string, body interface{}, result interface{}) error {
+func (f *fakeAPI) REST(_ context.Context, method, path string, params map[string]string, body interface{}, result interface{}) error {
 	if f.restFunc == nil {
 		return errors.New("unexpected REST call")
 	}
 	return f.restFunc(method, path, params, body, result)
 }
 
-func (f *fakeAPI) GraphQL(query string, variables map[string]interface{}, result interface{}) error {
+func (f *fakeAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	if f.graphqlFunc == nil {
 		return errors.New("unexpected GraphQL call")
 	}
@@ -72,6 +73,7 @@ func TestServiceListFiltersAndSort(t *testing.T) {
 								"isOutdated":         false,
 								"path":               "internal/file.go",
 								"line":               42,
+								"subjectType":        "LINE",
 								"viewerCanResolve":   false,
 								"viewerCanUnresolve": false,
 								"comments": map[string]interface{}{
@@ -109,7 +111,7 @@ func TestServiceListFiltersAndSort(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	threads, err := svc.List(identity, ListOptions{OnlyUnresolved: true, MineOnly: true})
+	threads, err := svc.List(context.Background(), identity, ListOptions{OnlyUnresolved: true, MineOnly: true})
 	require.NoError(t, err)
 	require.Len(t, threads, 1)
 
@@ -120,6 +122,7 @@ func TestServiceListFiltersAndSort(t *testing.T) {
 	assert.Equal(t, "internal/file.go", entry.Path)
 	require.NotNil(t, entry.Line)
 	assert.Equal(t, 42, *entry.Line)
+	assert.Equal(t, "LINE", entry.SubjectType)
 }
 
 func TestServiceListMineIncludesUnresolvePermission(t *testing.T) {
@@ -177,7 +180,7 @@ func TestServiceListMineIncludesUnresolvePermission(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	threads, err := svc.List(identity, ListOptions{MineOnly: true})
+	threads, err := svc.List(context.Background(), identity, ListOptions{MineOnly: true})
 	require.NoError(t, err)
 	require.Len(t, threads, 1)
 	assert.Equal(t, "T-resolved", threads[0].ThreadID)
@@ -208,7 +211,7 @@ func TestServiceListUnresolvedEmptyReturnsSlice(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	threads, err := svc.List(identity, ListOptions{OnlyUnresolved: true})
+	threads, err := svc.List(context.Background(), identity, ListOptions{OnlyUnresolved: true})
 	require.NoError(t, err)
 	require.NotNil(t, threads)
 	assert.Empty(t, threads)
@@ -236,7 +239,7 @@ func TestResolveRequiresPermission(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	_, err := svc.Resolve(identity, ActionOptions{ThreadID: "T1"})
+	_, err := svc.Resolve(context.Background(), identity, ActionOptions{ThreadID: "T1"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "cannot resolve")
 }
@@ -265,7 +268,7 @@ func TestResolveNoop(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	res, err := svc.Resolve(identity, ActionOptions{ThreadID: "T2"})
+	res, err := svc.Resolve(context.Background(), identity, ActionOptions{ThreadID: "T2"})
 	require.NoError(t, err)
 	assert.True(t, res.IsResolved)
 	assert.Equal(t, "T2", res.ThreadNodeID)
@@ -307,7 +310,7 @@ func TestResolveMutatesThread(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	res, err := svc.Resolve(identity, ActionOptions{ThreadID: "T3"})
+	res, err := svc.Resolve(context.Background(), identity, ActionOptions{ThreadID: "T3"})
 	require.NoError(t, err)
 	assert.True(t, mutationCalled)
 	assert.True(t, res.IsResolved)
@@ -336,7 +339,7 @@ func TestUnresolveRequiresPermission(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	_, err := svc.Unresolve(identity, ActionOptions{ThreadID: "T7"})
+	_, err := svc.Unresolve(context.Background(), identity, ActionOptions{ThreadID: "T7"})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "cannot unresolve")
 }
@@ -365,7 +368,7 @@ func TestUnresolveNoop(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	res, err := svc.Unresolve(identity, ActionOptions{ThreadID: "T8"})
+	res, err := svc.Unresolve(context.Background(), identity, ActionOptions{ThreadID: "T8"})
 	require.NoError(t, err)
 	assert.False(t, res.IsResolved)
 	assert.Equal(t, "T8", res.ThreadNodeID)
@@ -406,7 +409,7 @@ func TestUnresolveMutatesThread(t *testing.T) {
 	}
 
 	identity := resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}
-	res, err := svc.Unresolve(identity, ActionOptions{ThreadID: "T9"})
+	res, err := svc.Unresolve(context.Background(), identity, ActionOptions{ThreadID: "T9"})
 	require.NoError(t, err)
 	assert.True(t, mutationCalled)
 	assert.False(t, res.IsResolved)
@@ -0,0 +1,278 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/preview"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// ThreadView is a single review thread with its full conversation and the
+// code it was anchored to.
+type ThreadView struct {
+	ThreadID           string        `json:"thread_id"`
+	Path               string        `json:"path"`
+	SubjectType        string        `json:"subject_type,omitempty"`
+	Side               string        `json:"side,omitempty"`
+	Line               *int          `json:"line,omitempty"`
+	StartLine          *int          `json:"start_line,omitempty"`
+	OriginalLine       *int          `json:"original_line,omitempty"`
+	OriginalStartLine  *int          `json:"original_start_line,omitempty"`
+	OriginalCommit     string        `json:"original_commit,omitempty"`
+	IsResolved         bool          `json:"is_resolved"`
+	ResolvedBy         *string       `json:"resolved_by,omitempty"`
+	IsOutdated         bool          `json:"is_outdated"`
+	ViewerCanReply     bool          `json:"viewer_can_reply"`
+	ViewerCanResolve   bool          `json:"viewer_can_resolve"`
+	ViewerCanUnresolve bool          `json:"viewer_can_unresolve"`
+	DiffHunk           string        `json:"diff_hunk,omitempty"`
+	CodeContext        []string      `json:"code_context,omitempty"`
+	Comments           []ViewComment `json:"comments"`
+}
+
+// ViewComment is one comment of a ThreadView.
+type ViewComment struct {
+	ID          string `json:"id"`
+	DatabaseID  int    `json:"database_id,omitempty"`
+	AuthorLogin string `json:"author_login,omitempty"`
+	Body        string `json:"body"`
+	HtmlURL     string `json:"html_url,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+	ReplyToID   string `json:"reply_to_id,omitempty"`
+	ReviewID    string `json:"review_id,omitempty"`
+	ReviewState string `json:"review_state,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+}
+
+type viewThreadNode struct {
+	ID                 string `json:"id"`
+	IsResolved         bool   `json:"isResolved"`
+	IsOutdated         bool   `json:"isOutdated"`
+	Path               string `json:"path"`
+	SubjectType        string `json:"subjectType"`
+	DiffSide           string `json:"diffSide"`
+	Line               *int   `json:"line"`
+	StartLine          *int   `json:"startLine"`
+	OriginalLine       *int   `json:"originalLine"`
+	OriginalStartLine  *int   `json:"originalStartLine"`
+	ViewerCanReply     bool   `json:"viewerCanReply"`
+	ViewerCanResolve   bool   `json:"viewerCanResolve"`
+	ViewerCanUnresolve bool   `json:"viewerCanUnresolve"`
+	ResolvedBy         *struct {
+		Login string `json:"login"`
+	} `json:"resolvedBy"`
+	PullRequest *struct {
+		Number     int `json:"number"`
+		Repository *struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+	} `json:"pullRequest"`
+	Comments struct {
+		Nodes []struct {
+			ID         string `json:"id"`
+			DatabaseID int    `json:"databaseId"`
+			Body       string `json:"body"`
+			URL        string `json:"url"`
+			DiffHunk   string `json:"diffHunk"`
+			CreatedAt  string `json:"createdAt"`
+			UpdatedAt  string `json:"updatedAt"`
+			Author     *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			ReplyTo *struct {
+				ID string `json:"id"`
+			} `json:"replyTo"`
+			PullRequestReview *struct {
+				ID    string `json:"id"`
+				State string `json:"state"`
+			} `json:"pullRequestReview"`
+			Commit *struct {
+				OID string `json:"oid"`
+			} `json:"commit"`
+			OriginalCommit *struct {
+				OID string `json:"oid"`
+			} `json:"originalCommit"`
+		} `json:"nodes"`
+		PageInfo struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+	} `json:"comments"`
+}
+
+// checkPullRequest rejects a thread that belongs to a pull request other than
+// pr, comparing the repository as well since PR numbers repeat across them.
+func (node *viewThreadNode) checkPullRequest(pr resolver.Identity) error {
+	if node.PullRequest == nil {
+		return nil
+	}
+	if node.PullRequest.Repository != nil && pr.Owner != "" && pr.Repo != "" {
+		want := pr.Owner + "/" + pr.Repo
+		if got := node.PullRequest.Repository.NameWithOwner; got != "" && !strings.EqualFold(got, want) {
+			return fmt.Errorf("thread %s belongs to %s#%d, not %s#%d", node.ID, got, node.PullRequest.Number, want, pr.Number)
+		}
+	}
+	if pr.Number != 0 && node.PullRequest.Number != pr.Number {
+		return fmt.Errorf("thread %s belongs to pull request #%d, not #%d", node.ID, node.PullRequest.Number, pr.Number)
+	}
+	return nil
+}
+
+// View loads one review thread of the pull request with every comment, the
+// diff hunk it was left on, and the line-numbered code it covers.
+func (s *Service) View(ctx context.Context, pr resolver.Identity, threadID string) (*ThreadView, error) {
+	threadID = strings.TrimSpace(threadID)
+	if threadID == "" {
+		return nil, errors.New("thread id is required")
+	}
+
+	var (
+		view  *ThreadView
+		after *string
+	)
+	for {
+		variables := map[string]interface{}{"id": threadID}
+		if after != nil {
+			variables["after"] = *after
+		}
+		var resp struct {
+			Node *viewThreadNode `json:"node"`
+		}
+		if err := s.API.GraphQL(ctx, viewThreadQuery, variables, &resp); err != nil {
+			return nil, err
+		}
+		node := resp.Node
+		if node == nil || node.ID == "" {
+			return nil, fmt.Errorf("thread %s not found on %s", threadID, pr.Host)
+		}
+		if err := node.checkPullRequest(pr); err != nil {
+			return nil, err
+		}
+
+		if view == nil {
+			view = newThreadView(node)
+		}
+		for _, comment := range node.Comments.Nodes {
+			entry := ViewComment{
+				ID:         comment.ID,
+				DatabaseID: comment.DatabaseID,
+				Body:       comment.Body,
+				HtmlURL:    comment.URL,
+				CreatedAt:  comment.CreatedAt,
+				UpdatedAt:  comment.UpdatedAt,
+			}
+			if comment.Author != nil {
+				entry.AuthorLogin = comment.Author.Login
+			}
+			if comment.ReplyTo != nil {
+				entry.ReplyToID = comment.ReplyTo.ID
+			}
+			if comment.PullRequestReview != nil {
+				entry.ReviewID = comment.PullRequestReview.ID
+				entry.ReviewState = comment.PullRequestReview.State
+			}
+			if comment.Commit != nil {
+				entry.Commit = comment.Commit.OID
+			}
+			if len(view.Comments) == 0 {
+				view.DiffHunk = comment.DiffHunk
+				if comment.OriginalCommit != nil {
+					view.OriginalCommit = comment.OriginalCommit.OID
+				}
+			}
+			view.Comments = append(view.Comments, entry)
+		}
+
+		if !node.Comments.PageInfo.HasNextPage || node.Comments.PageInfo.EndCursor == "" {
+			break
+		}
+		cursor := node.Comments.PageInfo.EndCursor
+		after = &cursor
+	}
+
+	// The diff hunk is taken from the original commit, so the original
+	// lines locate the commented code within it.
+	if view.DiffHunk != "" && view.OriginalLine != nil {
+		start := 0
+		if view.OriginalStartLine != nil {
+			start = *view.OriginalStartLine
+		}
+		view.CodeContext = preview.CodeContext(view.DiffHunk, view.Side, start, *view.OriginalLine)
+	}
+	return view, nil
+}
+
+func newThreadView(node *viewThreadNode) *ThreadView {
+	view := &ThreadView{
+		ThreadID:           node.ID,
+		Path:               node.Path,
+		SubjectType:        node.SubjectType,
+		Side:               node.DiffSide,
+		Line:               node.Line,
+		StartLine:          node.StartLine,
+		OriginalLine:       node.OriginalLine,
+		OriginalStartLine:  node.OriginalStartLine,
+		IsResolved:         node.IsResolved,
+		IsOutdated:         node.IsOutdated,
+		ViewerCanReply:     node.ViewerCanReply,
+		ViewerCanResolve:   node.ViewerCanResolve,
+		ViewerCanUnresolve: node.ViewerCanUnresolve,
+		Comments:           []ViewComment{},
+	}
+	if node.ResolvedBy != nil && node.ResolvedBy.Login != "" {
+		login := node.ResolvedBy.Login
+		view.ResolvedBy = &login
+	}
+	return view
+}
+
+const viewThreadQuery = `
+query ThreadView($id: ID!, $after: String) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      id
+      isResolved
+      isOutdated
+      path
+      subjectType
+      diffSide
+      line
+      startLine
+      originalLine
+      originalStartLine
+      viewerCanReply
+      viewerCanResolve
+      viewerCanUnresolve
+      resolvedBy { login }
+      pullRequest {
+        number
+        repository { nameWithOwner }
+      }
+      comments(first: 100, after: $after) {
+        nodes {
+          id
+          databaseId
+          body
+          url
+          diffHunk
+          createdAt
+          updatedAt
+          author { login }
+          replyTo { id }
+          pullRequestReview { id state }
+          commit { oid }
+          originalCommit { oid }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,120 @@
+package threads
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+func viewPage(number int, comments []map[string]interface{}, next string) map[string]interface{} {
+	return map[string]interface{}{"node": map[string]interface{}{
+		"id":                 "PRRT_1",
+		"isResolved":         true,
+		"isOutdated":         false,
+		"path":               "main.go",
+		"subjectType":        "LINE",
+		"diffSide":           "RIGHT",
+		"line":               12,
+		"startLine":          11,
+		"originalLine":       12,
+		"originalStartLine":  11,
+		"viewerCanReply":     true,
+		"viewerCanResolve":   false,
+		"viewerCanUnresolve": true,
+		"resolvedBy":         map[string]interface{}{"login": "bob"},
+		"pullRequest": map[string]interface{}{
+			"number":     number,
+			"repository": map[string]interface{}{"nameWithOwner": "octo/demo"},
+		},
+		"comments": map[string]interface{}{
+			"nodes":    comments,
+			"pageInfo": map[string]interface{}{"hasNextPage": next != "", "endCursor": next},
+		},
+	}}
+}
+
+func TestViewCollectsConversationAndCodeContext(t *testing.T) {
+	root := map[string]interface{}{
+		"id": "PRRC_1", "databaseId": 1, "body": "Rename this", "url": "https://example.com/c1",
+		"diffHunk":  "@@ -10,2 +10,3 @@\n context\n+old := 1\n+value := 2",
+		"createdAt": "2025-12-03T10:00:00Z", "updatedAt": "2025-12-03T10:00:00Z",
+		"author":            map[string]interface{}{"login": "alice"},
+		"pullRequestReview": map[string]interface{}{"id": "PRR_1", "state": "COMMENTED"},
+		"commit":            map[string]interface{}{"oid": "def456"},
+		"originalCommit":    map[string]interface{}{"oid": "abc123"},
+	}
+	reply := map[string]interface{}{
+		"id": "PRRC_2", "body": "Done", "createdAt": "2025-12-03T11:00:00Z",
+		"author":  map[string]interface{}{"login": "bob"},
+		"replyTo": map[string]interface{}{"id": "PRRC_1"},
+	}
+
+	var cursors []interface{}
+	svc := NewService(&fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Equal(t, viewThreadQuery, query)
+		require.Equal(t, "PRRT_1", variables["id"])
+		cursors = append(cursors, variables["after"])
+		if variables["after"] == nil {
+			return assign(result, viewPage(5, []map[string]interface{}{root}, "c1"))
+		}
+		return assign(result, viewPage(5, []map[string]interface{}{reply}, ""))
+	}})
+
+	view, err := svc.View(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}, "PRRT_1")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, "c1"}, cursors)
+
+	assert.Equal(t, "PRRT_1", view.ThreadID)
+	assert.True(t, view.IsResolved)
+	require.NotNil(t, view.ResolvedBy)
+	assert.Equal(t, "bob", *view.ResolvedBy)
+	assert.Equal(t, "abc123", view.OriginalCommit)
+	assert.Equal(t, []string{"11: +old := 1", "12: +value := 2"}, view.CodeContext)
+
+	require.Len(t, view.Comments, 2)
+	assert.Equal(t, ViewComment{
+		ID: "PRRC_1", DatabaseID: 1, AuthorLogin: "alice", Body: "Rename this", HtmlURL: "https://example.com/c1",
+		CreatedAt: "2025-12-03T10:00:00Z", UpdatedAt: "2025-12-03T10:00:00Z",
+		ReviewID: "PRR_1", ReviewState: "COMMENTED", Commit: "def456",
+	}, view.Comments[0])
+	assert.Equal(t, "PRRC_1", view.Comments[1].ReplyToID)
+}
+
+func TestViewRejectsThreadFromAnotherPullRequest(t *testing.T) {
+	svc := NewService(&fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		return assign(result, viewPage(6, nil, ""))
+	}})
+
+	_, err := svc.View(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}, "PRRT_1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "belongs to pull request #6, not #5")
+}
+
+func TestViewRejectsThreadFromAnotherRepository(t *testing.T) {
+	svc := NewService(&fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		page := viewPage(5, nil, "")
+		pull := page["node"].(map[string]interface{})["pullRequest"].(map[string]interface{})
+		pull["repository"] = map[string]interface{}{"nameWithOwner": "someone/fork"}
+		return assign(result, page)
+	}})
+
+	_, err := svc.View(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 5}, "PRRT_1")
+	require.EqualError(t, err, "thread PRRT_1 belongs to someone/fork#5, not octo/demo#5")
+
+	_, err = svc.View(context.Background(), resolver.Identity{Owner: "SomeOne", Repo: "Fork", Number: 5}, "PRRT_1")
+	require.NoError(t, err)
+}
+
+func TestViewThreadNotFound(t *testing.T) {
+	svc := NewService(&fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		return assign(result, map[string]interface{}{"node": nil})
+	}})
+
+	_, err := svc.View(context.Background(), resolver.Identity{Owner: "octo", Repo: "demo", Number: 5, Host: "github.com"}, "PRRT_404")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "thread PRRT_404 not found on github.com")
+}
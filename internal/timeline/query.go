@@ -0,0 +1,66 @@
+package timeline
+
+const timelineQuery = `query Timeline($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      timelineItems(first: 100, after: $after, itemTypes: [ISSUE_COMMENT, PULL_REQUEST_REVIEW, PULL_REQUEST_COMMIT, HEAD_REF_FORCE_PUSHED_EVENT, REVIEW_REQUESTED_EVENT, REVIEW_DISMISSED_EVENT]) {
+        nodes {
+          __typename
+          ... on IssueComment {
+            id
+            author { login }
+            body
+            createdAt
+            url
+          }
+          ... on PullRequestReview {
+            id
+            author { login }
+            body
+            state
+            createdAt
+            submittedAt
+            url
+          }
+          ... on PullRequestCommit {
+            id
+            url
+            commit {
+              oid
+              messageHeadline
+              committedDate
+              author { name user { login } }
+            }
+          }
+          ... on HeadRefForcePushedEvent {
+            id
+            actor { login }
+            createdAt
+            beforeCommit { oid }
+            afterCommit { oid }
+          }
+          ... on ReviewRequestedEvent {
+            id
+            actor { login }
+            createdAt
+            requestedReviewer {
+              __typename
+              ... on User { login }
+              ... on Bot { login }
+              ... on Mannequin { login }
+              ... on Team { slug }
+            }
+          }
+          ... on ReviewDismissedEvent {
+            id
+            actor { login }
+            createdAt
+            dismissalMessage
+            review { id author { login } }
+          }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
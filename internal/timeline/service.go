@@ -0,0 +1,276 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/report"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// maxPages bounds how many timeline pages are fetched; hitting it marks the
+// timeline as truncated.
+const maxPages = 50
+
+// Service assembles pull request timelines.
+type Service struct {
+	API ghcli.API
+}
+
+// NewService constructs a timeline service.
+func NewService(api ghcli.API) *Service {
+	return &Service{API: api}
+}
+
+// Options filters the events returned by Fetch. Empty filters match
+// everything.
+type Options struct {
+	Types  []Type
+	Author string
+}
+
+func (o Options) wants(t Type) bool {
+	if len(o.Types) == 0 {
+		return true
+	}
+	for _, want := range o.Types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch merges conversation comments, reviews, review comments, commits, and
+// review request and dismissal events into one chronological timeline.
+func (s *Service) Fetch(ctx context.Context, pr resolver.Identity, opts Options) (Timeline, error) {
+	events, truncated, err := s.timelineItems(ctx, pr)
+	if err != nil {
+		return Timeline{}, err
+	}
+
+	if opts.wants(TypeReviewComment) {
+		threads, threadsTruncated, err := report.NewService(s.API).Threads(ctx, pr)
+		if err != nil {
+			return Timeline{}, err
+		}
+		truncated = truncated || threadsTruncated
+		events = append(events, reviewComments(threads)...)
+	}
+
+	author := strings.TrimSpace(opts.Author)
+	filtered := make([]Event, 0, len(events))
+	for _, event := range events {
+		if !opts.wants(event.Type) {
+			continue
+		}
+		if author != "" && !strings.EqualFold(event.Author, author) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	Sort(filtered)
+	return Timeline{Events: filtered, Truncated: truncated}, nil
+}
+
+type login struct {
+	Login string `json:"login"`
+}
+
+func (l *login) String() string {
+	if l == nil {
+		return ""
+	}
+	return l.Login
+}
+
+type oid struct {
+	OID string `json:"oid"`
+}
+
+type timelineNode struct {
+	Typename    string     `json:"__typename"`
+	ID          string     `json:"id"`
+	Author      *login     `json:"author"`
+	Actor       *login     `json:"actor"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	SubmittedAt *time.Time `json:"submittedAt"`
+	URL         string     `json:"url"`
+	Commit      *struct {
+		OID             string    `json:"oid"`
+		MessageHeadline string    `json:"messageHeadline"`
+		CommittedDate   time.Time `json:"committedDate"`
+		Author          *struct {
+			Name string `json:"name"`
+			User *login `json:"user"`
+		} `json:"author"`
+	} `json:"commit"`
+	BeforeCommit      *oid `json:"beforeCommit"`
+	AfterCommit       *oid `json:"afterCommit"`
+	RequestedReviewer *struct {
+		Login string `json:"login"`
+		Slug  string `json:"slug"`
+	} `json:"requestedReviewer"`
+	DismissalMessage *string `json:"dismissalMessage"`
+	Review           *struct {
+		ID     string `json:"id"`
+		Author *login `json:"author"`
+	} `json:"review"`
+}
+
+// timelineItems pages through the pull request timeline, converting each item
+// into an Event.
+func (s *Service) timelineItems(ctx context.Context, pr resolver.Identity) ([]Event, bool, error) {
+	var events []Event
+	var after *string
+	for pages := 0; ; pages++ {
+		if pages >= maxPages {
+			return events, true, nil
+		}
+		variables := map[string]interface{}{
+			"owner":  pr.Owner,
+			"name":   pr.Repo,
+			"number": pr.Number,
+		}
+		if after != nil {
+			variables["after"] = *after
+		}
+
+		var response struct {
+			Repository *struct {
+				PullRequest *struct {
+					TimelineItems struct {
+						Nodes    []timelineNode `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"timelineItems"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := s.API.GraphQL(ctx, timelineQuery, variables, &response); err != nil {
+			return nil, false, err
+		}
+		if response.Repository == nil || response.Repository.PullRequest == nil {
+			return nil, false, errors.New("pull request not found or inaccessible")
+		}
+
+		items := response.Repository.PullRequest.TimelineItems
+		for _, node := range items.Nodes {
+			event, ok, err := node.event()
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				events = append(events, event)
+			}
+		}
+		if !items.PageInfo.HasNextPage {
+			return events, false, nil
+		}
+		if strings.TrimSpace(items.PageInfo.EndCursor) == "" {
+			return events, true, nil
+		}
+		cursor := items.PageInfo.EndCursor
+		after = &cursor
+	}
+}
+
+// event converts a timeline node; unknown item types report false.
+func (n timelineNode) event() (Event, bool, error) {
+	event := Event{ID: n.ID, URL: n.URL}
+	switch n.Typename {
+	case "IssueComment":
+		event.Type = TypeIssueComment
+		event.Author = n.Author.String()
+		event.Body = n.Body
+	case "PullRequestReview":
+		event.Type = TypeReview
+		event.Author = n.Author.String()
+		event.Body = n.Body
+		event.State = n.State
+		if n.SubmittedAt != nil {
+			event.CreatedAt = *n.SubmittedAt
+		}
+	case "PullRequestCommit":
+		if n.Commit == nil {
+			return Event{}, false, fmt.Errorf("timeline commit %s missing commit", n.ID)
+		}
+		event.Type = TypeCommit
+		event.Commit = n.Commit.OID
+		event.Body = n.Commit.MessageHeadline
+		event.CreatedAt = n.Commit.CommittedDate
+		if author := n.Commit.Author; author != nil {
+			event.Author = author.Name
+			if author.User != nil && author.User.Login != "" {
+				event.Author = author.User.Login
+			}
+		}
+	case "HeadRefForcePushedEvent":
+		event.Type = TypeForcePushed
+		event.Author = n.Actor.String()
+		if n.BeforeCommit != nil {
+			event.BeforeCommit = n.BeforeCommit.OID
+		}
+		if n.AfterCommit != nil {
+			event.Commit = n.AfterCommit.OID
+		}
+	case "ReviewRequestedEvent":
+		event.Type = TypeReviewRequested
+		event.Author = n.Actor.String()
+		if reviewer := n.RequestedReviewer; reviewer != nil {
+			event.RequestedReviewer = reviewer.Login
+			if reviewer.Slug != "" {
+				event.RequestedReviewer = reviewer.Slug
+			}
+		}
+	case "ReviewDismissedEvent":
+		event.Type = TypeReviewDismissed
+		event.Author = n.Actor.String()
+		if n.DismissalMessage != nil {
+			event.Body = *n.DismissalMessage
+		}
+		if n.Review != nil {
+			event.ReviewID = n.Review.ID
+			event.ReviewAuthor = n.Review.Author.String()
+		}
+	default:
+		return Event{}, false, nil
+	}
+
+	if event.CreatedAt.IsZero() {
+		if n.CreatedAt == nil {
+			return Event{}, false, fmt.Errorf("timeline item %s missing createdAt", n.ID)
+		}
+		event.CreatedAt = *n.CreatedAt
+	}
+	event.CreatedAt = event.CreatedAt.UTC()
+	return event, true, nil
+}
+
+// reviewComments converts every review thread comment into an Event.
+func reviewComments(threads []report.Thread) []Event {
+	var events []Event
+	for _, thread := range threads {
+		for _, comment := range thread.Comments {
+			events = append(events, Event{
+				Type:      TypeReviewComment,
+				ID:        comment.NodeID,
+				Author:    comment.AuthorLogin,
+				CreatedAt: comment.CreatedAt.UTC(),
+				Body:      comment.Body,
+				ThreadID:  thread.ID,
+				Path:      thread.Path,
+				Line:      thread.Line,
+			})
+		}
+	}
+	return events
+}
@@ -0,0 +1,150 @@
+package timeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+type obj = map[string]interface{}
+
+type fakeAPI struct {
+	t     *testing.T
+	pages [][]obj
+	calls int
+}
+
+func assign(result interface{}, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (f *fakeAPI) REST(context.Context, string, string, map[string]string, interface{}, interface{}) error {
+	return errors.New("unexpected REST call")
+}
+
+func (f *fakeAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	switch {
+	case strings.Contains(query, "query Timeline("):
+		page := f.calls
+		f.calls++
+		if page > 0 {
+			assert.Equal(f.t, "cursor1", variables["after"])
+		}
+		return assign(result, obj{"repository": obj{"pullRequest": obj{"timelineItems": obj{
+			"nodes":    f.pages[page],
+			"pageInfo": obj{"hasNextPage": page+1 < len(f.pages), "endCursor": "cursor1"},
+		}}}})
+	case strings.Contains(query, "query Report("):
+		return assign(result, obj{"repository": obj{"pullRequest": obj{
+			"reviews": obj{"nodes": []obj{}},
+			"reviewThreads": obj{"nodes": []obj{{
+				"id": "PRRT_1", "path": "main.go", "line": 7,
+				"comments": obj{"nodes": []obj{{
+					"id": "PRRC_1", "databaseId": 1, "body": "nit", "createdAt": "2024-06-01T10:05:00Z",
+					"author": obj{"login": "bob"},
+				}}},
+			}}},
+		}}})
+	default:
+		f.t.Fatalf("unexpected query: %s", query)
+		return nil
+	}
+}
+
+var testPR = resolver.Identity{Owner: "octo", Repo: "demo", Number: 7}
+
+func timelinePages() [][]obj {
+	return [][]obj{
+		{
+			{"__typename": "PullRequestCommit", "id": "PRC_1", "commit": obj{
+				"oid": "abc", "messageHeadline": "Add feature", "committedDate": "2024-06-01T09:00:00Z",
+				"author": obj{"name": "Alice", "user": obj{"login": "alice"}},
+			}},
+			{"__typename": "ReviewRequestedEvent", "id": "RRE_1", "actor": obj{"login": "alice"}, "createdAt": "2024-06-01T09:01:00Z",
+				"requestedReviewer": obj{"__typename": "Team", "slug": "core"}},
+			{"__typename": "PullRequestReview", "id": "PRR_1", "author": obj{"login": "bob"}, "body": "",
+				"state": "CHANGES_REQUESTED", "createdAt": "2024-06-01T10:00:00Z", "submittedAt": "2024-06-01T10:05:00Z"},
+		},
+		{
+			{"__typename": "IssueComment", "id": "IC_1", "author": obj{"login": "carol"}, "body": "LGTM overall", "createdAt": "2024-06-01T09:30:00Z"},
+			{"__typename": "HeadRefForcePushedEvent", "id": "HRF_1", "actor": obj{"login": "alice"}, "createdAt": "2024-06-01T11:00:00Z",
+				"beforeCommit": obj{"oid": "abc"}, "afterCommit": obj{"oid": "def"}},
+			{"__typename": "ReviewDismissedEvent", "id": "RDE_1", "actor": obj{"login": "alice"}, "createdAt": "2024-06-01T12:00:00Z",
+				"dismissalMessage": "addressed", "review": obj{"id": "PRR_1", "author": obj{"login": "bob"}}},
+		},
+	}
+}
+
+func TestFetchMergesAndSorts(t *testing.T) {
+	api := &fakeAPI{t: t, pages: timelinePages()}
+
+	result, err := NewService(api).Fetch(context.Background(), testPR, Options{})
+	require.NoError(t, err)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, 2, api.calls)
+
+	ids := make([]string, len(result.Events))
+	for i, event := range result.Events {
+		ids[i] = string(event.Type) + ":" + event.ID
+	}
+	assert.Equal(t, []string{
+		"commit:PRC_1",
+		"review_requested:RRE_1",
+		"issue_comment:IC_1",
+		"review:PRR_1",
+		"review_comment:PRRC_1",
+		"force_pushed:HRF_1",
+		"review_dismissed:RDE_1",
+	}, ids)
+
+	commit := result.Events[0]
+	assert.Equal(t, "alice", commit.Author)
+	assert.Equal(t, "abc", commit.Commit)
+	assert.Equal(t, "Add feature", commit.Body)
+	assert.Equal(t, "core", result.Events[1].RequestedReviewer)
+	assert.Equal(t, "CHANGES_REQUESTED", result.Events[3].State)
+
+	comment := result.Events[4]
+	assert.Equal(t, "PRRT_1", comment.ThreadID)
+	assert.Equal(t, "main.go", comment.Path)
+	require.NotNil(t, comment.Line)
+	assert.Equal(t, 7, *comment.Line)
+
+	assert.Equal(t, "def", result.Events[5].Commit)
+	assert.Equal(t, "abc", result.Events[5].BeforeCommit)
+	assert.Equal(t, "bob", result.Events[6].ReviewAuthor)
+	assert.Equal(t, "addressed", result.Events[6].Body)
+}
+
+func TestFetchFiltersTypesAndAuthor(t *testing.T) {
+	api := &fakeAPI{t: t, pages: timelinePages()}
+
+	result, err := NewService(api).Fetch(context.Background(), testPR, Options{
+		Types:  []Type{TypeIssueComment, TypeReview},
+		Author: "BOB",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, "PRR_1", result.Events[0].ID)
+}
+
+func TestParseType(t *testing.T) {
+	got, err := ParseType(" Issue_Comment ")
+	require.NoError(t, err)
+	assert.Equal(t, TypeIssueComment, got)
+
+	_, err = ParseType("push")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid type "push"`)
+}
@@ -0,0 +1,95 @@
+// Package timeline merges a pull request's conversation, reviews, review
+// comments, and key events into one chronological stream.
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Type identifies the kind of a timeline Event.
+type Type string
+
+const (
+	TypeIssueComment    Type = "issue_comment"
+	TypeReview          Type = "review"
+	TypeReviewComment   Type = "review_comment"
+	TypeCommit          Type = "commit"
+	TypeForcePushed     Type = "force_pushed"
+	TypeReviewRequested Type = "review_requested"
+	TypeReviewDismissed Type = "review_dismissed"
+)
+
+var allTypes = []Type{
+	TypeIssueComment,
+	TypeReview,
+	TypeReviewComment,
+	TypeCommit,
+	TypeForcePushed,
+	TypeReviewRequested,
+	TypeReviewDismissed,
+}
+
+// ParseType validates a --type value.
+func ParseType(value string) (Type, error) {
+	normalized := Type(strings.ToLower(strings.TrimSpace(value)))
+	for _, t := range allTypes {
+		if t == normalized {
+			return t, nil
+		}
+	}
+	names := make([]string, len(allTypes))
+	for i, t := range allTypes {
+		names[i] = string(t)
+	}
+	return "", fmt.Errorf("invalid type %q: must be one of %s", value, strings.Join(names, ", "))
+}
+
+// Event is one entry of the timeline. Author is the user who wrote the
+// comment or review, pushed the commit, or triggered the event. Fields that do
+// not apply to Type are omitted.
+type Event struct {
+	Type      Type      `json:"type"`
+	ID        string    `json:"id"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Body      string    `json:"body,omitempty"`
+	URL       string    `json:"url,omitempty"`
+
+	// State is the review state for review events.
+	State string `json:"state,omitempty"`
+
+	// ThreadID, Path, and Line locate review comments.
+	ThreadID string `json:"thread_id,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Line     *int   `json:"line,omitempty"`
+
+	// Commit is the pushed commit for commit events and the new head for
+	// force pushes; BeforeCommit is the head a force push replaced.
+	Commit       string `json:"commit,omitempty"`
+	BeforeCommit string `json:"before_commit,omitempty"`
+
+	// RequestedReviewer is the user login or team slug asked to review.
+	RequestedReviewer string `json:"requested_reviewer,omitempty"`
+
+	// ReviewID and ReviewAuthor identify the review a dismissal applies to.
+	ReviewID     string `json:"review_id,omitempty"`
+	ReviewAuthor string `json:"review_author,omitempty"`
+}
+
+// Timeline is the serialized output of the timeline command. Truncated is set
+// when pagination stopped before every item was fetched.
+type Timeline struct {
+	Events    []Event `json:"events"`
+	Truncated bool    `json:"truncated,omitempty"`
+}
+
+// Sort orders events chronologically, keeping the fetch order for ties so a
+// review stays ahead of the comments submitted with it.
+func Sort(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+}
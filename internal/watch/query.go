@@ -0,0 +1,14 @@
+package watch
+
+// probeQuery is the cheap per-poll query; the full report is fetched only
+// when its result changes.
+const probeQuery = `query WatchProbe($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      state
+      updatedAt
+      reviews { totalCount }
+      reviewThreads { totalCount }
+    }
+  }
+}`
@@ -0,0 +1,167 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agynio/gh-pr-review/internal/ghcli"
+	"github.com/agynio/gh-pr-review/internal/report"
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+// DefaultInterval is the delay between polls when Options.Interval is unset.
+const DefaultInterval = time.Minute
+
+// fullRefreshEvery forces a full fetch every few polls even when the probe is
+// unchanged: resolving a thread does not touch the pull request's updatedAt.
+const fullRefreshEvery = 5
+
+// Service polls a pull request for review activity.
+type Service struct {
+	API ghcli.API
+
+	sleep func(ctx context.Context, d time.Duration) error
+	now   func() time.Time
+}
+
+// NewService constructs a watch service.
+func NewService(api ghcli.API) *Service {
+	return &Service{API: api}
+}
+
+// Options configures Watch.
+type Options struct {
+	Interval time.Duration
+	// Until stops the watch once any condition holds.
+	Until []Condition
+}
+
+type probe struct {
+	State     string
+	UpdatedAt string
+	Reviews   int
+	Threads   int
+}
+
+// Watch polls the pull request and passes every new event to emit until the
+// pull request is merged or closed or an Until condition holds. When ctx ends
+// first, its cause is returned.
+// The report is re-fetched only when a cheap probe of the pull request
+// changes, and periodically to catch thread resolutions.
+func (s *Service) Watch(ctx context.Context, pr resolver.Identity, opts Options, emit func(Event) error) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	reports := report.NewService(s.API)
+
+	var (
+		last     *probe
+		snapshot *report.Report
+	)
+	for poll := 0; ; poll++ {
+		current, err := s.probe(ctx, pr)
+		if err != nil {
+			return err
+		}
+
+		if last == nil || *current != *last || poll%fullRefreshEvery == 0 {
+			next, err := reports.Fetch(ctx, pr, report.Options{IncludeCommentNodeID: true})
+			if err != nil {
+				return err
+			}
+			if snapshot != nil {
+				for _, event := range Diff(*snapshot, next) {
+					event.ObservedAt = s.timestamp()
+					if err := emit(event); err != nil {
+						return err
+					}
+					if holdsAny(opts.Until, func(c Condition) bool { return c.heldBy(event) }) {
+						return nil
+					}
+				}
+			}
+			snapshot = &next
+			if holdsAny(opts.Until, func(c Condition) bool { return c.heldIn(next) }) {
+				return nil
+			}
+		}
+		last = current
+
+		switch current.State {
+		case "MERGED", "CLOSED":
+			event := Event{Type: TypePRMerged, ObservedAt: s.timestamp()}
+			if current.State == "CLOSED" {
+				event.Type = TypePRClosed
+			}
+			return emit(event)
+		}
+
+		if err := s.wait(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+func holdsAny(conditions []Condition, held func(Condition) bool) bool {
+	for _, condition := range conditions {
+		if held(condition) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) probe(ctx context.Context, pr resolver.Identity) (*probe, error) {
+	var response struct {
+		Repository *struct {
+			PullRequest *struct {
+				State     string `json:"state"`
+				UpdatedAt string `json:"updatedAt"`
+				Reviews   struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"reviews"`
+				ReviewThreads struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	variables := map[string]interface{}{"owner": pr.Owner, "name": pr.Repo, "number": pr.Number}
+	if err := s.API.GraphQL(ctx, probeQuery, variables, &response); err != nil {
+		return nil, err
+	}
+	if response.Repository == nil || response.Repository.PullRequest == nil {
+		return nil, fmt.Errorf("pull request %s/%s#%d not found", pr.Owner, pr.Repo, pr.Number)
+	}
+	pull := response.Repository.PullRequest
+	return &probe{
+		State:     pull.State,
+		UpdatedAt: pull.UpdatedAt,
+		Reviews:   pull.Reviews.TotalCount,
+		Threads:   pull.ReviewThreads.TotalCount,
+	}, nil
+}
+
+func (s *Service) timestamp() string {
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	return now().UTC().Format(time.RFC3339)
+}
+
+func (s *Service) wait(ctx context.Context, d time.Duration) error {
+	if s.sleep != nil {
+		return s.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/resolver"
+)
+
+type fakeAPI struct {
+	graphqlFunc func(query string, variables map[string]interface{}, result interface{}) error
+}
+
+func (f *fakeAPI) REST(context.Context, string, string, map[string]string, interface{}, interface{}) error {
+	return errors.New("unexpected REST call")
+}
+
+func (f *fakeAPI) GraphQL(_ context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	return f.graphqlFunc(query, variables, result)
+}
+
+func assign(result interface{}, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+// poll scripts one round of the fake pull request.
+type poll struct {
+	state     string
+	updatedAt string
+	reviews   []map[string]interface{}
+}
+
+func review(id string, databaseID int, state string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          id,
+		"databaseId":  databaseID,
+		"state":       state,
+		"submittedAt": "2025-01-01T00:00:00Z",
+		"author":      map[string]interface{}{"login": "alice"},
+	}
+}
+
+// scriptedAPI answers the probe with the current poll and counts report fetches.
+func scriptedAPI(t *testing.T, polls []poll, current *int, fetches *int) *fakeAPI {
+	return &fakeAPI{graphqlFunc: func(query string, variables map[string]interface{}, result interface{}) error {
+		require.Less(t, *current, len(polls))
+		p := polls[*current]
+		if query == probeQuery {
+			return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+				"state":         p.state,
+				"updatedAt":     p.updatedAt,
+				"reviews":       map[string]interface{}{"totalCount": len(p.reviews)},
+				"reviewThreads": map[string]interface{}{"totalCount": 0},
+			}}})
+		}
+		require.True(t, strings.Contains(query, "query Report("), "unexpected query")
+		*fetches++
+		return assign(result, map[string]interface{}{"repository": map[string]interface{}{"pullRequest": map[string]interface{}{
+			"reviews":       map[string]interface{}{"nodes": p.reviews, "pageInfo": map[string]interface{}{"hasNextPage": false}},
+			"reviewThreads": map[string]interface{}{"nodes": []interface{}{}, "pageInfo": map[string]interface{}{"hasNextPage": false}},
+		}}})
+	}}
+}
+
+func newTestService(api *fakeAPI, current *int, sleeps *[]time.Duration) *Service {
+	service := NewService(api)
+	service.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	service.sleep = func(_ context.Context, d time.Duration) error {
+		*sleeps = append(*sleeps, d)
+		*current++
+		return nil
+	}
+	return service
+}
+
+var testPR = resolver.Identity{Owner: "octo", Repo: "demo", Number: 7, Host: "github.com"}
+
+func TestWatchStopsWhenApproved(t *testing.T) {
+	polls := []poll{
+		{state: "OPEN", updatedAt: "t1"},
+		{state: "OPEN", updatedAt: "t1"},
+		{state: "OPEN", updatedAt: "t2", reviews: []map[string]interface{}{review("PRR_1", 1, "APPROVED")}},
+	}
+	var current, fetches int
+	var sleeps []time.Duration
+	service := newTestService(scriptedAPI(t, polls, &current, &fetches), &current, &sleeps)
+
+	var events []Event
+	err := service.Watch(context.Background(), testPR, Options{Interval: 30 * time.Second, Until: []Condition{UntilApproved}}, func(e Event) error {
+		events = append(events, e)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, fetches, "unchanged probe must not refetch the report")
+	assert.Equal(t, []time.Duration{30 * time.Second, 30 * time.Second}, sleeps)
+	require.Len(t, events, 1)
+	assert.Equal(t, TypeReviewSubmitted, events[0].Type)
+	assert.Equal(t, "2025-01-02T03:04:05Z", events[0].ObservedAt)
+	assert.Equal(t, "PRR_1", events[0].Review.ID)
+}
+
+func TestWatchEmitsMergedAndStops(t *testing.T) {
+	polls := []poll{
+		{state: "OPEN", updatedAt: "t1"},
+		{state: "MERGED", updatedAt: "t2", reviews: []map[string]interface{}{review("PRR_1", 1, "COMMENTED")}},
+	}
+	var current, fetches int
+	var sleeps []time.Duration
+	service := newTestService(scriptedAPI(t, polls, &current, &fetches), &current, &sleeps)
+
+	var types []Type
+	err := service.Watch(context.Background(), testPR, Options{}, func(e Event) error {
+		types = append(types, e.Type)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Type{TypeReviewSubmitted, TypePRMerged}, types)
+	assert.Equal(t, []time.Duration{DefaultInterval}, sleeps)
+}
+
+func TestWatchReturnsContextCause(t *testing.T) {
+	polls := []poll{{state: "OPEN", updatedAt: "t1"}}
+	var current, fetches int
+	service := NewService(scriptedAPI(t, polls, &current, &fetches))
+
+	stop := errors.New("stop")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(stop)
+
+	err := service.Watch(ctx, testPR, Options{Interval: time.Hour}, func(Event) error {
+		t.Fatal("no events expected")
+		return nil
+	})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, fetches)
+}
+
+func TestWatchPropagatesEmitError(t *testing.T) {
+	polls := []poll{
+		{state: "OPEN", updatedAt: "t1"},
+		{state: "CLOSED", updatedAt: "t2"},
+	}
+	var current, fetches int
+	var sleeps []time.Duration
+	service := newTestService(scriptedAPI(t, polls, &current, &fetches), &current, &sleeps)
+
+	broken := errors.New("broken pipe")
+	err := service.Watch(context.Background(), testPR, Options{}, func(e Event) error {
+		assert.Equal(t, TypePRClosed, e.Type)
+		return broken
+	})
+	require.ErrorIs(t, err, broken)
+}
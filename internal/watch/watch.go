@@ -0,0 +1,163 @@
+package watch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+// Type identifies the kind of a watch event.
+type Type string
+
+// Event types emitted by Watch.
+const (
+	TypeReviewSubmitted  Type = "review_submitted"
+	TypeThreadCreated    Type = "thread_created"
+	TypeCommentAdded     Type = "comment_added"
+	TypeThreadResolved   Type = "thread_resolved"
+	TypeThreadUnresolved Type = "thread_unresolved"
+	TypePRMerged         Type = "pr_merged"
+	TypePRClosed         Type = "pr_closed"
+)
+
+var allTypes = []Type{
+	TypeReviewSubmitted,
+	TypeThreadCreated,
+	TypeCommentAdded,
+	TypeThreadResolved,
+	TypeThreadUnresolved,
+	TypePRMerged,
+	TypePRClosed,
+}
+
+// Event is one change observed between two polls. Review, Thread, and
+// Comment use the same shapes as `review view`.
+type Event struct {
+	Type       Type                  `json:"type"`
+	ObservedAt string                `json:"observed_at"`
+	ThreadID   string                `json:"thread_id,omitempty"`
+	Review     *report.ReportReview  `json:"review,omitempty"`
+	Thread     *report.ReportComment `json:"thread,omitempty"`
+	Comment    *report.ThreadReply   `json:"comment,omitempty"`
+}
+
+// Condition stops Watch once it holds.
+type Condition string
+
+// Conditions accepted by ParseCondition besides event types.
+const (
+	// UntilApproved holds once an approving review is submitted.
+	UntilApproved Condition = "approved"
+	// UntilChangesRequested holds once a review requesting changes is submitted.
+	UntilChangesRequested Condition = "changes_requested"
+	// UntilResolved holds while no review thread is unresolved.
+	UntilResolved Condition = "resolved"
+)
+
+// ParseCondition accepts approved, changes_requested, resolved, or an event
+// type, which holds once such an event is emitted.
+func ParseCondition(value string) (Condition, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	switch Condition(normalized) {
+	case UntilApproved, UntilChangesRequested, UntilResolved:
+		return Condition(normalized), nil
+	}
+	for _, t := range allTypes {
+		if Type(normalized) == t {
+			return Condition(normalized), nil
+		}
+	}
+	names := []string{string(UntilApproved), string(UntilChangesRequested), string(UntilResolved)}
+	for _, t := range allTypes {
+		names = append(names, string(t))
+	}
+	return "", fmt.Errorf("invalid --until %q: must be one of %s", value, strings.Join(names, ", "))
+}
+
+// heldBy reports whether event satisfies an event-based condition.
+func (c Condition) heldBy(event Event) bool {
+	switch c {
+	case UntilApproved:
+		return event.Type == TypeReviewSubmitted && event.Review.State == report.StateApproved
+	case UntilChangesRequested:
+		return event.Type == TypeReviewSubmitted && event.Review.State == report.StateChangesRequested
+	case UntilResolved:
+		return false
+	default:
+		return Type(c) == event.Type
+	}
+}
+
+// heldIn reports whether a state-based condition holds for snapshot.
+func (c Condition) heldIn(snapshot report.Report) bool {
+	if c != UntilResolved {
+		return false
+	}
+	for _, review := range snapshot.Reviews {
+		for _, thread := range review.Comments {
+			if !thread.IsResolved {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Diff lists the events that turn prev into next: newly submitted reviews,
+// new threads, new replies in existing threads, and resolution changes.
+func Diff(prev, next report.Report) []Event {
+	reviews := make(map[string]bool)
+	threads := make(map[string]report.ReportComment)
+	replies := make(map[string]bool)
+	for _, review := range prev.Reviews {
+		reviews[review.ID] = true
+		for _, thread := range review.Comments {
+			threads[thread.ThreadID] = thread
+			for _, reply := range thread.ThreadComments {
+				replies[replyKey(thread.ThreadID, reply)] = true
+			}
+		}
+	}
+
+	var events []Event
+	for _, review := range next.Reviews {
+		if !reviews[review.ID] {
+			submitted := review
+			submitted.Comments = nil
+			events = append(events, Event{Type: TypeReviewSubmitted, Review: &submitted})
+		}
+	}
+	for _, review := range next.Reviews {
+		for _, thread := range review.Comments {
+			before, seen := threads[thread.ThreadID]
+			if !seen {
+				created := thread
+				events = append(events, Event{Type: TypeThreadCreated, ThreadID: thread.ThreadID, Thread: &created})
+				continue
+			}
+			for _, reply := range thread.ThreadComments {
+				if replies[replyKey(thread.ThreadID, reply)] {
+					continue
+				}
+				added := reply
+				events = append(events, Event{Type: TypeCommentAdded, ThreadID: thread.ThreadID, Comment: &added})
+			}
+			if before.IsResolved != thread.IsResolved {
+				kind := TypeThreadResolved
+				if !thread.IsResolved {
+					kind = TypeThreadUnresolved
+				}
+				events = append(events, Event{Type: kind, ThreadID: thread.ThreadID})
+			}
+		}
+	}
+	return events
+}
+
+func replyKey(threadID string, reply report.ThreadReply) string {
+	if reply.CommentNodeID != nil && *reply.CommentNodeID != "" {
+		return *reply.CommentNodeID
+	}
+	return threadID + "\x00" + reply.AuthorLogin + "\x00" + reply.CreatedAt + "\x00" + reply.Body
+}
@@ -0,0 +1,99 @@
+package watch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestParseCondition(t *testing.T) {
+	for input, want := range map[string]Condition{
+		"approved":           UntilApproved,
+		" Changes_Requested": UntilChangesRequested,
+		"resolved":           UntilResolved,
+		"pr_merged":          Condition(TypePRMerged),
+		"comment_added":      Condition(TypeCommentAdded),
+	} {
+		got, err := ParseCondition(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseCondition("merged")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --until \"merged\"")
+}
+
+func TestDiff(t *testing.T) {
+	prev := report.Report{Reviews: []report.ReportReview{{
+		ID:    "PRR_1",
+		State: report.StateCommented,
+		Comments: []report.ReportComment{{
+			ThreadID:       "PRRT_1",
+			IsResolved:     false,
+			ThreadComments: []report.ThreadReply{{CommentNodeID: strPtr("PRRC_2"), Body: "first"}},
+		}},
+	}}}
+	next := report.Report{Reviews: []report.ReportReview{
+		{
+			ID:    "PRR_1",
+			State: report.StateCommented,
+			Comments: []report.ReportComment{{
+				ThreadID:   "PRRT_1",
+				IsResolved: true,
+				ThreadComments: []report.ThreadReply{
+					{CommentNodeID: strPtr("PRRC_2"), Body: "first"},
+					{CommentNodeID: strPtr("PRRC_3"), Body: "second"},
+				},
+			}},
+		},
+		{
+			ID:       "PRR_2",
+			State:    report.StateApproved,
+			Comments: []report.ReportComment{{ThreadID: "PRRT_2", Body: "new thread"}},
+		},
+	}}
+
+	events := Diff(prev, next)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, TypeReviewSubmitted, events[0].Type)
+	require.NotNil(t, events[0].Review)
+	assert.Equal(t, "PRR_2", events[0].Review.ID)
+	assert.Nil(t, events[0].Review.Comments)
+
+	assert.Equal(t, TypeCommentAdded, events[1].Type)
+	assert.Equal(t, "PRRT_1", events[1].ThreadID)
+	assert.Equal(t, "second", events[1].Comment.Body)
+
+	assert.Equal(t, TypeThreadResolved, events[2].Type)
+	assert.Equal(t, "PRRT_1", events[2].ThreadID)
+
+	assert.Equal(t, TypeThreadCreated, events[3].Type)
+	assert.Equal(t, "new thread", events[3].Thread.Body)
+
+	assert.Empty(t, Diff(next, next))
+
+	reopened := Diff(next, prev)
+	require.Len(t, reopened, 1)
+	assert.Equal(t, TypeThreadUnresolved, reopened[0].Type)
+}
+
+func TestConditionHeld(t *testing.T) {
+	approved := Event{Type: TypeReviewSubmitted, Review: &report.ReportReview{State: report.StateApproved}}
+	assert.True(t, UntilApproved.heldBy(approved))
+	assert.False(t, UntilChangesRequested.heldBy(approved))
+	assert.True(t, Condition(TypeReviewSubmitted).heldBy(approved))
+	assert.False(t, UntilApproved.heldBy(Event{Type: TypeThreadCreated}))
+
+	open := report.Report{Reviews: []report.ReportReview{{Comments: []report.ReportComment{{IsResolved: false}}}}}
+	done := report.Report{Reviews: []report.ReportReview{{Comments: []report.ReportComment{{IsResolved: true}}}}}
+	assert.False(t, UntilResolved.heldIn(open))
+	assert.True(t, UntilResolved.heldIn(done))
+	assert.False(t, UntilApproved.heldIn(done))
+}
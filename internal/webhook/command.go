@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+)
+
+// Command runs a shell command for every event, one at a time. The event is
+// written to the command's stdin as JSON and summarized in GH_PR_REVIEW_*
+// environment variables.
+type Command struct {
+	Line   string
+	Stdout io.Writer
+	Stderr io.Writer
+
+	mu sync.Mutex
+}
+
+// Handle runs the command for event and fails when it exits non-zero.
+func (c *Command) Handle(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.Line)
+	cmd.Stdin = bytes.NewReader(append(data, '\n'))
+	cmd.Stdout = c.Stdout
+	cmd.Stderr = c.Stderr
+	cmd.Env = append(os.Environ(),
+		"GH_PR_REVIEW_EVENT="+event.Event,
+		"GH_PR_REVIEW_ACTION="+event.Action,
+		"GH_PR_REVIEW_REPO="+event.Repository,
+		"GH_PR_REVIEW_PR="+strconv.Itoa(event.PullRequest),
+		"GH_PR_REVIEW_DELIVERY="+event.DeliveryID,
+	)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run %q for %s.%s: %w", c.Line, event.Event, event.Action, err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandReceivesEvent(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	c := &Command{
+		Line:   `printf '%s %s %s %s ' "$GH_PR_REVIEW_EVENT" "$GH_PR_REVIEW_ACTION" "$GH_PR_REVIEW_REPO" "$GH_PR_REVIEW_PR"; cat`,
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	event := Event{Event: EventReview, Action: "submitted", Repository: "octo/demo", PullRequest: 7}
+	require.NoError(t, c.Handle(context.Background(), event))
+	assert.Equal(t, "pull_request_review submitted octo/demo 7 "+
+		`{"event":"pull_request_review","action":"submitted","repository":"octo/demo","pull_request":7}`+"\n", stdout.String())
+
+	c.Line = "echo boom >&2; exit 3"
+	err := c.Handle(context.Background(), event)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pull_request_review.submitted")
+	assert.Equal(t, "boom\n", stderr.String())
+}
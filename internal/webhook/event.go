@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+	"github.com/agynio/gh-pr-review/internal/threads"
+)
+
+// Webhook event names handled by Parse.
+const (
+	EventReview        = "pull_request_review"
+	EventReviewComment = "pull_request_review_comment"
+	EventReviewThread  = "pull_request_review_thread"
+)
+
+// ErrUnsupportedEvent is returned by Parse for events other than the review
+// events above.
+var ErrUnsupportedEvent = errors.New("unsupported webhook event")
+
+// Event is a normalized review webhook delivery. Exactly one of Review,
+// Comment, or Thread is set, using the same shapes as `review view` and
+// `threads list`.
+type Event struct {
+	Event       string                `json:"event"`
+	Action      string                `json:"action"`
+	DeliveryID  string                `json:"delivery_id,omitempty"`
+	Repository  string                `json:"repository"`
+	PullRequest int                   `json:"pull_request"`
+	Sender      string                `json:"sender,omitempty"`
+	Review      *report.ReportReview  `json:"review,omitempty"`
+	Comment     *report.ReportComment `json:"comment,omitempty"`
+	// InReplyToID is the REST id of the comment a review comment replies to.
+	InReplyToID int             `json:"in_reply_to_id,omitempty"`
+	Thread      *threads.Thread `json:"thread,omitempty"`
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type reviewPayload struct {
+	NodeID      string  `json:"node_id"`
+	State       string  `json:"state"`
+	Body        *string `json:"body"`
+	SubmittedAt *string `json:"submitted_at"`
+	User        *user   `json:"user"`
+}
+
+type commentPayload struct {
+	NodeID      string    `json:"node_id"`
+	Path        string    `json:"path"`
+	Line        *int      `json:"line"`
+	SubjectType string    `json:"subject_type"`
+	Body        string    `json:"body"`
+	CreatedAt   string    `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	InReplyToID int       `json:"in_reply_to_id"`
+	User        *user     `json:"user"`
+}
+
+type payload struct {
+	Action     string `json:"action"`
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	PullRequest *struct {
+		Number int `json:"number"`
+	} `json:"pull_request"`
+	Sender  *user           `json:"sender"`
+	Review  *reviewPayload  `json:"review"`
+	Comment *commentPayload `json:"comment"`
+	Thread  *struct {
+		NodeID   string           `json:"node_id"`
+		Comments []commentPayload `json:"comments"`
+	} `json:"thread"`
+}
+
+// Parse normalizes the body of a review webhook named by the X-GitHub-Event
+// header.
+func Parse(name string, body []byte) (Event, error) {
+	switch name {
+	case EventReview, EventReviewComment, EventReviewThread:
+	default:
+		return Event{}, fmt.Errorf("%w %q", ErrUnsupportedEvent, name)
+	}
+
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return Event{}, fmt.Errorf("decode %s payload: %w", name, err)
+	}
+	if p.Repository == nil || p.PullRequest == nil {
+		return Event{}, fmt.Errorf("%s payload missing repository or pull_request", name)
+	}
+
+	event := Event{
+		Event:       name,
+		Action:      p.Action,
+		Repository:  p.Repository.FullName,
+		PullRequest: p.PullRequest.Number,
+		Sender:      login(p.Sender),
+	}
+	switch name {
+	case EventReview:
+		if p.Review == nil {
+			return Event{}, fmt.Errorf("%s payload missing review", name)
+		}
+		event.Review = normalizeReview(p.Review)
+	case EventReviewComment:
+		if p.Comment == nil {
+			return Event{}, fmt.Errorf("%s payload missing comment", name)
+		}
+		event.Comment = normalizeComment(p.Comment)
+		event.InReplyToID = p.Comment.InReplyToID
+	case EventReviewThread:
+		if p.Thread == nil {
+			return Event{}, fmt.Errorf("%s payload missing thread", name)
+		}
+		thread := threads.Thread{ThreadID: p.Thread.NodeID, IsResolved: p.Action == "resolved"}
+		if thread.IsResolved && event.Sender != "" {
+			resolvedBy := event.Sender
+			thread.ResolvedBy = &resolvedBy
+		}
+		for i, comment := range p.Thread.Comments {
+			if i == 0 {
+				thread.Path = comment.Path
+				thread.Line = comment.Line
+				thread.SubjectType = subjectType(comment.SubjectType)
+				thread.IsOutdated = isOutdated(&comment)
+			}
+			if !comment.UpdatedAt.IsZero() && (thread.UpdatedAt == nil || comment.UpdatedAt.After(*thread.UpdatedAt)) {
+				updatedAt := comment.UpdatedAt
+				thread.UpdatedAt = &updatedAt
+			}
+		}
+		event.Thread = &thread
+	}
+	return event, nil
+}
+
+func normalizeReview(review *reviewPayload) *report.ReportReview {
+	normalized := &report.ReportReview{
+		ID:          review.NodeID,
+		State:       report.State(strings.ToUpper(review.State)),
+		SubmittedAt: review.SubmittedAt,
+		AuthorLogin: login(review.User),
+	}
+	if review.Body != nil {
+		if trimmed := strings.TrimSpace(*review.Body); trimmed != "" {
+			normalized.Body = &trimmed
+		}
+	}
+	return normalized
+}
+
+// normalizeComment maps a REST review comment onto ReportComment. Webhooks do
+// not carry the thread node ID or its resolution, so ThreadID is empty and
+// IsResolved is false.
+func normalizeComment(comment *commentPayload) *report.ReportComment {
+	nodeID := comment.NodeID
+	return &report.ReportComment{
+		CommentNodeID:  &nodeID,
+		Path:           comment.Path,
+		Line:           comment.Line,
+		SubjectType:    subjectType(comment.SubjectType),
+		AuthorLogin:    login(comment.User),
+		Body:           comment.Body,
+		CreatedAt:      comment.CreatedAt,
+		IsOutdated:     isOutdated(comment),
+		ThreadComments: []report.ThreadReply{},
+	}
+}
+
+// subjectType upper-cases the REST value ("line", "file") to match GraphQL.
+func subjectType(value string) string {
+	return strings.ToUpper(value)
+}
+
+// isOutdated reports a line comment whose line no longer exists in the diff.
+func isOutdated(comment *commentPayload) bool {
+	return comment.Line == nil && !strings.EqualFold(comment.SubjectType, "file")
+}
+
+func login(u *user) string {
+	if u == nil {
+		return ""
+	}
+	return u.Login
+}
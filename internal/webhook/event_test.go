@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agynio/gh-pr-review/internal/report"
+)
+
+func recorded(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseReview(t *testing.T) {
+	event, err := Parse(EventReview, recorded(t, EventReview))
+	require.NoError(t, err)
+
+	assert.Equal(t, EventReview, event.Event)
+	assert.Equal(t, "submitted", event.Action)
+	assert.Equal(t, "octo/demo", event.Repository)
+	assert.Equal(t, 7, event.PullRequest)
+	assert.Equal(t, "bob", event.Sender)
+	require.NotNil(t, event.Review)
+	assert.Equal(t, "PRR_kwDOAAABbcdEFG13", event.Review.ID)
+	assert.Equal(t, report.StateApproved, event.Review.State)
+	assert.Equal(t, "bob", event.Review.AuthorLogin)
+	require.NotNil(t, event.Review.Body)
+	assert.Equal(t, "Looks good once the nit is addressed.", *event.Review.Body)
+	require.NotNil(t, event.Review.SubmittedAt)
+	assert.Equal(t, "2024-06-01T10:12:02Z", *event.Review.SubmittedAt)
+	assert.Nil(t, event.Comment)
+	assert.Nil(t, event.Thread)
+}
+
+func TestParseReviewComment(t *testing.T) {
+	event, err := Parse(EventReviewComment, recorded(t, EventReviewComment))
+	require.NoError(t, err)
+
+	assert.Equal(t, "created", event.Action)
+	assert.Equal(t, 3001, event.InReplyToID)
+	require.NotNil(t, event.Comment)
+	comment := event.Comment
+	require.NotNil(t, comment.CommentNodeID)
+	assert.Equal(t, "PRRC_kwDOAAABbhi7891", *comment.CommentNodeID)
+	assert.Equal(t, "internal/fetcher.go", comment.Path)
+	require.NotNil(t, comment.Line)
+	assert.Equal(t, 42, *comment.Line)
+	assert.Equal(t, "LINE", comment.SubjectType)
+	assert.Equal(t, "alice", comment.AuthorLogin)
+	assert.Equal(t, "Fixed in the latest push.", comment.Body)
+	assert.Equal(t, "2024-06-01T10:05:41Z", comment.CreatedAt)
+	assert.False(t, comment.IsOutdated)
+	assert.Empty(t, comment.ThreadID)
+	assert.NotNil(t, comment.ThreadComments)
+}
+
+func TestParseReviewThread(t *testing.T) {
+	event, err := Parse(EventReviewThread, recorded(t, EventReviewThread))
+	require.NoError(t, err)
+
+	assert.Equal(t, "resolved", event.Action)
+	require.NotNil(t, event.Thread)
+	thread := event.Thread
+	assert.Equal(t, "PRRT_kwDOAAABbFg12345", thread.ThreadID)
+	assert.True(t, thread.IsResolved)
+	require.NotNil(t, thread.ResolvedBy)
+	assert.Equal(t, "bob", *thread.ResolvedBy)
+	assert.Equal(t, "internal/fetcher.go", thread.Path)
+	require.NotNil(t, thread.Line)
+	assert.Equal(t, 42, *thread.Line)
+	assert.Equal(t, "LINE", thread.SubjectType)
+	require.NotNil(t, thread.UpdatedAt)
+	assert.Equal(t, time.Date(2024, 6, 1, 10, 5, 41, 0, time.UTC), *thread.UpdatedAt)
+}
+
+func TestParseUnresolvedThreadHasNoResolver(t *testing.T) {
+	body := []byte(`{"action":"unresolved","thread":{"node_id":"PRRT_1","comments":[{"path":"a.go","subject_type":"line"}]},
+		"pull_request":{"number":7},"repository":{"full_name":"octo/demo"},"sender":{"login":"bob"}}`)
+	event, err := Parse(EventReviewThread, body)
+	require.NoError(t, err)
+	assert.False(t, event.Thread.IsResolved)
+	assert.Nil(t, event.Thread.ResolvedBy)
+	assert.True(t, event.Thread.IsOutdated)
+}
+
+func TestParseRejectsOtherEvents(t *testing.T) {
+	_, err := Parse("push", []byte(`{}`))
+	require.ErrorIs(t, err, ErrUnsupportedEvent)
+
+	_, err = Parse(EventReview, []byte(`{"action":"submitted"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing repository or pull_request")
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// maxPayloadBytes matches GitHub's cap on webhook payloads.
+const maxPayloadBytes = 25 << 20
+
+// Handler receives GitHub webhook deliveries, verifies their signature, and
+// passes normalized review events to Handle. Deliveries of other events are
+// acknowledged and ignored.
+type Handler struct {
+	Secret []byte
+	Handle func(ctx context.Context, event Event) error
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "read payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := VerifySignature(h.Secret, body, r.Header.Get(SignatureHeader)); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	name := r.Header.Get("X-GitHub-Event")
+	if name == "ping" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	event, err := Parse(name, body)
+	if errors.Is(err, ErrUnsupportedEvent) {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	event.DeliveryID = r.Header.Get("X-GitHub-Delivery")
+
+	if err := h.Handle(r.Context(), event); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
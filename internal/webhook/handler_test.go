@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("It's a Secret to Everybody")
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte("Hello, World!")
+	// Example from GitHub's "Validating webhook deliveries" documentation.
+	const expected = "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	assert.Equal(t, expected, Sign(testSecret, body))
+	require.NoError(t, VerifySignature(testSecret, body, expected))
+
+	assert.ErrorIs(t, VerifySignature(testSecret, body, ""), ErrMissingSignature)
+	assert.ErrorIs(t, VerifySignature(testSecret, body, "sha1=abc"), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature(testSecret, body, "sha256=zz"), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature([]byte("other"), body, expected), ErrInvalidSignature)
+}
+
+func deliver(t *testing.T, h http.Handler, name string, body []byte, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	req.Header.Set("X-GitHub-Event", name)
+	req.Header.Set("X-GitHub-Delivery", "delivery-1")
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerDeliversSignedEvents(t *testing.T) {
+	var got []Event
+	h := &Handler{Secret: testSecret, Handle: func(_ context.Context, event Event) error {
+		got = append(got, event)
+		return nil
+	}}
+
+	body := recorded(t, EventReviewComment)
+	rec := deliver(t, h, EventReviewComment, body, Sign(testSecret, body))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	require.Len(t, got, 1)
+	assert.Equal(t, "delivery-1", got[0].DeliveryID)
+	assert.Equal(t, EventReviewComment, got[0].Event)
+}
+
+func TestHandlerRejectsAndIgnores(t *testing.T) {
+	h := &Handler{Secret: testSecret, Handle: func(context.Context, Event) error {
+		t.Fatal("handle must not be called")
+		return nil
+	}}
+	body := recorded(t, EventReview)
+
+	rec := deliver(t, h, EventReview, body, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = deliver(t, h, EventReview, body, Sign([]byte("wrong"), body))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = deliver(t, h, "ping", []byte(`{}`), Sign(testSecret, []byte(`{}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = deliver(t, h, "push", []byte(`{}`), Sign(testSecret, []byte(`{}`)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	rec = deliver(t, h, EventReview, []byte(`{`), Sign(testSecret, []byte(`{`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandlerReportsHandleFailure(t *testing.T) {
+	h := &Handler{Secret: testSecret, Handle: func(context.Context, Event) error {
+		return errors.New("command failed")
+	}}
+	body := recorded(t, EventReviewThread)
+	rec := deliver(t, h, EventReviewThread, body, Sign(testSecret, body))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "command failed")
+}
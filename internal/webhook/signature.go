@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the delivery body.
+const SignatureHeader = "X-Hub-Signature-256"
+
+const signaturePrefix = "sha256="
+
+var (
+	// ErrMissingSignature is returned when a delivery has no signature header.
+	ErrMissingSignature = errors.New("missing " + SignatureHeader + " header")
+	// ErrInvalidSignature is returned when the signature does not match the body.
+	ErrInvalidSignature = errors.New("signature does not match payload")
+)
+
+// Sign returns the X-Hub-Signature-256 value GitHub sends for body.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks an X-Hub-Signature-256 header value against body in
+// constant time.
+func VerifySignature(secret, body []byte, signature string) error {
+	signature = strings.TrimSpace(signature)
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}